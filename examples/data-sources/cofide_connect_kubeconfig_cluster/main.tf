@@ -0,0 +1,22 @@
+data "cofide_connect_kubeconfig_cluster" "example" {
+  path    = pathexpand("~/.kube/config")
+  context = var.kubernetes_context
+}
+
+resource "cofide_connect_cluster" "example" {
+  name               = "local1"
+  trust_zone_id      = var.trust_zone_id
+  profile            = "kubernetes"
+  kubernetes_context = data.cofide_connect_kubeconfig_cluster.example.kubernetes_context
+
+  trust_provider = {
+    kind = "kubernetes"
+    k8s_psat_config = {
+      enabled                    = true
+      api_server_url             = data.cofide_connect_kubeconfig_cluster.example.api_server_url
+      api_server_ca_cert         = data.cofide_connect_kubeconfig_cluster.example.api_server_ca_cert
+      api_server_tls_server_name = data.cofide_connect_kubeconfig_cluster.example.api_server_tls_server_name
+      api_server_proxy_url       = data.cofide_connect_kubeconfig_cluster.example.api_server_proxy_url
+    }
+  }
+}
@@ -0,0 +1,4 @@
+output "api_server_url" {
+  description = "The URL of the cluster's API server."
+  value       = data.cofide_connect_kubeconfig_cluster.example.api_server_url
+}
@@ -0,0 +1,10 @@
+variable "kubernetes_context" {
+  description = "The kubeconfig context of the cluster."
+  type        = string
+  default     = "kind-local1"
+}
+
+variable "trust_zone_id" {
+  description = "The ID of the trust zone of the cluster."
+  type        = string
+}
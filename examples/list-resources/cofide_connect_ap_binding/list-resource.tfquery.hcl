@@ -0,0 +1,12 @@
+variable "trust_zone_id" {
+  description = "The ID of the trust zone."
+  type        = string
+}
+
+list "cofide_connect_ap_binding" "all" {
+  provider = cofide
+
+  config {
+    trust_zone_id = var.trust_zone_id
+  }
+}
@@ -0,0 +1,12 @@
+variable "trust_zone_id" {
+  description = "The ID of the trust zone."
+  type        = string
+}
+
+list "cofide_connect_cluster" "all" {
+  provider = cofide
+
+  config {
+    trust_zone_id = var.trust_zone_id
+  }
+}
@@ -0,0 +1,13 @@
+variable "trust_zone_id" {
+  description = "The ID of the trust zone."
+  type        = string
+}
+
+list "cofide_connect_role_binding" "all" {
+  provider = cofide
+
+  config {
+    resource_type = "TrustZone"
+    resource_id   = var.trust_zone_id
+  }
+}
@@ -0,0 +1,12 @@
+variable "org_id" {
+  description = "The ID of the organization."
+  type        = string
+}
+
+list "cofide_connect_trust_zone" "all" {
+  provider = cofide
+
+  config {
+    org_id = var.org_id
+  }
+}
@@ -5,7 +5,7 @@ resource "cofide_connect_cluster" "example" {
   kubernetes_context  = var.kubernetes_context
   external_server     = false
   oidc_issuer_url     = var.oidc_issuer_url
-  oidc_issuer_ca_cert = base64encode(file("${path.module}/ca.pem"))
+  oidc_issuer_ca_cert = file("${path.module}/ca.pem")
 
   trust_provider = {
     kind = "kubernetes"
@@ -0,0 +1,3 @@
+resource "cofide_connect_federation_mesh" "example" {
+  trust_zone_ids = var.trust_zone_ids
+}
@@ -0,0 +1,4 @@
+output "federation_ids" {
+  description = "The IDs of the federations of the mesh."
+  value       = cofide_connect_federation_mesh.example.federations[*].id
+}
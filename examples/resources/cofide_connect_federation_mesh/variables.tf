@@ -0,0 +1,5 @@
+variable "trust_zone_ids" {
+  description = "The IDs of the trust zones to federate with each other."
+  type        = set(string)
+  default     = ["example-tz-a-id", "example-tz-b-id", "example-tz-c-id"]
+}
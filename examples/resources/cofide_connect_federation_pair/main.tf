@@ -0,0 +1,4 @@
+resource "cofide_connect_federation_pair" "example" {
+  trust_zone_id        = var.trust_zone_id
+  remote_trust_zone_id = var.remote_trust_zone_id
+}
@@ -0,0 +1,9 @@
+output "federation_id" {
+  description = "The ID of the federation from the trust zone to the remote trust zone."
+  value       = cofide_connect_federation_pair.example.federation_id
+}
+
+output "reverse_federation_id" {
+  description = "The ID of the federation from the remote trust zone to the trust zone."
+  value       = cofide_connect_federation_pair.example.reverse_federation_id
+}
@@ -0,0 +1,11 @@
+variable "trust_zone_id" {
+  description = "The ID of the trust zone."
+  type        = string
+  default     = "example-tz-id"
+}
+
+variable "remote_trust_zone_id" {
+  description = "The ID of the remote trust zone."
+  type        = string
+  default     = "example-remote-tz-id"
+}
@@ -0,0 +1,10 @@
+terraform {
+  required_providers {
+    cofide = {
+      source  = "cofide/cofide"
+      version = "~> 0.9.0"
+    }
+  }
+}
+
+provider "cofide" {}
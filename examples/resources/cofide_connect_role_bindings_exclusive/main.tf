@@ -0,0 +1,9 @@
+resource "cofide_connect_role_bindings_exclusive" "example" {
+  role_id = var.role_id
+  resource = {
+    type = var.resource_type
+    id   = var.resource_id
+  }
+  users  = var.user_subjects
+  groups = var.group_claim_values
+}
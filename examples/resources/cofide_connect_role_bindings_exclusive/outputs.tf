@@ -0,0 +1,4 @@
+output "role_bindings_id" {
+  description = "The ID of the role bindings."
+  value       = cofide_connect_role_bindings_exclusive.example.id
+}
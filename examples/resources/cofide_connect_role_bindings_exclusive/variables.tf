@@ -0,0 +1,29 @@
+variable "role_id" {
+  description = "The ID of the role."
+  type        = string
+  default     = "example-role-id"
+}
+
+variable "resource_type" {
+  description = "The type of the resource."
+  type        = string
+  default     = "TrustZone"
+}
+
+variable "resource_id" {
+  description = "The ID of the resource."
+  type        = string
+  default     = "example-tz-id"
+}
+
+variable "user_subjects" {
+  description = "The subjects of the users that hold the role."
+  type        = set(string)
+  default     = ["user@example.com"]
+}
+
+variable "group_claim_values" {
+  description = "The claim values of the groups that hold the role."
+  type        = set(string)
+  default     = ["platform-engineers"]
+}
@@ -0,0 +1,28 @@
+resource "cofide_connect_trust_zone_exchange_policies" "example" {
+  trust_zone_id = var.trust_zone_id
+
+  exchange_policies = [
+    {
+      name   = "deny-untrusted-workload"
+      action = "DENY"
+
+      subject_identity = [
+        { exact = "spiffe://example.org/untrusted-workload" }
+      ]
+    },
+    {
+      name   = "allow-api"
+      action = "ALLOW"
+
+      subject_identity = [
+        { glob = "spiffe://example.org/ns/foo/sa/*" }
+      ]
+
+      target_audience = [
+        { exact = "https://api.example.org" }
+      ]
+
+      outbound_scopes = ["read"]
+    },
+  ]
+}
@@ -0,0 +1,4 @@
+output "exchange_policy_ids" {
+  description = "The IDs of the exchange policies of the trust zone."
+  value       = cofide_connect_trust_zone_exchange_policies.example.exchange_policies[*].id
+}
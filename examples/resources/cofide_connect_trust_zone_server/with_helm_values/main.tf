@@ -2,7 +2,7 @@ resource "cofide_connect_trust_zone_server" "example" {
   trust_zone_id = var.trust_zone_id
   cluster_id    = var.cluster_id
 
-  helm_values = yamlencode({
+  helm_values_object = {
     replicaCount = 1
     resources = {
       limits = {
@@ -10,7 +10,7 @@ resource "cofide_connect_trust_zone_server" "example" {
         memory = "256Mi"
       }
     }
-  })
+  }
 
   connect_k8s_psat_config = {
     audiences                   = ["spire-server"]
@@ -0,0 +1,36 @@
+resource "cofide_connect_workload_identity" "example" {
+  name   = var.name
+  org_id = var.org_id
+
+  kubernetes = {
+    namespace_selector = {
+      match_labels = {
+        "kubernetes.io/metadata.name" = "default"
+      }
+    }
+    pod_selector = {
+      match_labels = {
+        "app" = "my-app"
+      }
+    }
+  }
+
+  bindings = [
+    {
+      trust_zone_id = var.trust_zone_id
+      federations = [
+        {
+          trust_zone_id = var.remote_trust_zone_id
+        }
+      ]
+    },
+    {
+      trust_zone_id = var.remote_trust_zone_id
+      federations = [
+        {
+          trust_zone_id = var.trust_zone_id
+        }
+      ]
+    },
+  ]
+}
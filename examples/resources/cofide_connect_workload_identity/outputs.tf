@@ -0,0 +1,9 @@
+output "attestation_policy_id" {
+  description = "The ID of the attestation policy."
+  value       = cofide_connect_workload_identity.example.id
+}
+
+output "ap_binding_ids" {
+  description = "The IDs of the attestation policy bindings."
+  value       = cofide_connect_workload_identity.example.bindings[*].id
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/cofide/terraform-provider-cofide/internal/consts"
+	"github.com/cofide/terraform-provider-cofide/internal/credentials"
+)
+
+var (
+	ErrMissingAPIToken   = errors.New("API token must be specified in provider configuration, via the COFIDE_API_TOKEN environment variable, or via the credentials file at ~/.cofide/credentials.")
+	ErrMissingConnectURL = errors.New("Connect URL must be specified in provider configuration or via the COFIDE_CONNECT_URL environment variable")
+)
+
+// Settings holds the settings used to connect to the Cofide Connect API.
+type Settings struct {
+	APIToken           string
+	ConnectURL         string
+	InsecureSkipVerify bool
+}
+
+// ResolveSettings fills in the settings that were not configured explicitly.
+// An empty API token is read from the COFIDE_API_TOKEN environment variable,
+// falling back to the credentials file; an empty Connect URL is read from
+// COFIDE_CONNECT_URL; and a nil insecureSkipVerify is read from
+// COFIDE_INSECURE_SKIP_VERIFY.
+//
+// Failing to read the credentials file is not fatal, since the token may not
+// be needed from it, so the error is returned separately as credentialsErr.
+func ResolveSettings(apiToken, connectURL string, insecureSkipVerify *bool) (settings Settings, credentialsErr error) {
+	if apiToken == "" {
+		apiToken = os.Getenv(consts.APITokenEnvVarKey)
+	}
+	if apiToken == "" {
+		apiToken, credentialsErr = credentials.LoadFromFile()
+	}
+
+	if connectURL == "" {
+		connectURL = os.Getenv(consts.ConnectURLEnvVarKey)
+	}
+
+	var skipVerify bool
+	if insecureSkipVerify != nil {
+		skipVerify = *insecureSkipVerify
+	} else if envVal, ok := os.LookupEnv(consts.InsecureSkipVerifyEnvVar); ok {
+		if parsed, err := strconv.ParseBool(envVal); err == nil {
+			skipVerify = parsed
+		}
+	}
+
+	return Settings{
+		APIToken:           apiToken,
+		ConnectURL:         connectURL,
+		InsecureSkipVerify: skipVerify,
+	}, credentialsErr
+}
+
+// Validate returns ErrMissingAPIToken or ErrMissingConnectURL if a required
+// setting is empty.
+func (s Settings) Validate() error {
+	if s.APIToken == "" {
+		return ErrMissingAPIToken
+	}
+	if s.ConnectURL == "" {
+		return ErrMissingConnectURL
+	}
+	return nil
+}
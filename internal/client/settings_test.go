@@ -0,0 +1,82 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cofide/terraform-provider-cofide/internal/consts"
+)
+
+func TestResolveSettings(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name               string
+		env                map[string]string
+		apiToken           string
+		connectURL         string
+		insecureSkipVerify *bool
+		want               Settings
+		wantErr            error
+	}{
+		{
+			name:    "nothing configured",
+			want:    Settings{},
+			wantErr: ErrMissingAPIToken,
+		},
+		{
+			name: "from environment",
+			env: map[string]string{
+				consts.APITokenEnvVarKey:        "env-token",
+				consts.ConnectURLEnvVarKey:      "env.example.com",
+				consts.InsecureSkipVerifyEnvVar: "true",
+			},
+			want: Settings{APIToken: "env-token", ConnectURL: "env.example.com", InsecureSkipVerify: true},
+		},
+		{
+			name: "explicit settings take precedence",
+			env: map[string]string{
+				consts.APITokenEnvVarKey:        "env-token",
+				consts.ConnectURLEnvVarKey:      "env.example.com",
+				consts.InsecureSkipVerifyEnvVar: "true",
+			},
+			apiToken:           "token",
+			connectURL:         "example.com",
+			insecureSkipVerify: boolPtr(false),
+			want:               Settings{APIToken: "token", ConnectURL: "example.com"},
+		},
+		{
+			name:     "missing connect URL",
+			apiToken: "token",
+			want:     Settings{APIToken: "token"},
+			wantErr:  ErrMissingConnectURL,
+		},
+		{
+			name:       "unparseable insecure skip verify is ignored",
+			env:        map[string]string{consts.InsecureSkipVerifyEnvVar: "maybe"},
+			apiToken:   "token",
+			connectURL: "example.com",
+			want:       Settings{APIToken: "token", ConnectURL: "example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Isolate from the user's environment and credentials file.
+			t.Setenv("HOME", t.TempDir())
+			for _, key := range []string{consts.APITokenEnvVarKey, consts.ConnectURLEnvVarKey, consts.InsecureSkipVerifyEnvVar} {
+				t.Setenv(key, tt.env[key])
+			}
+			if _, ok := tt.env[consts.InsecureSkipVerifyEnvVar]; !ok {
+				t.Setenv(consts.InsecureSkipVerifyEnvVar, "false")
+			}
+
+			got, credentialsErr := ResolveSettings(tt.apiToken, tt.connectURL, tt.insecureSkipVerify)
+			require.NoError(t, credentialsErr)
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, got.Validate(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package cofidectl
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const usage = `Usage: terraform-provider-cofide convert-config [--config <file>] [--out <file>]
+
+Converts a cofidectl configuration file into Terraform configuration for the
+equivalent Cofide Connect resources.
+
+Options:
+`
+
+// Main runs the convert-config subcommand with the given command-line
+// arguments, which exclude the subcommand name.
+func Main(args []string, stdout, stderr io.Writer) error {
+	var configPath, out string
+
+	flags := flag.NewFlagSet("convert-config", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&configPath, "config", "cofide.yaml", "path of the cofidectl configuration file")
+	flags.StringVar(&out, "out", "", "file to write the configuration to (default: standard output)")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	config, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	hcl, warnings, err := Convert(config)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "Warning: %s\n", warning)
+	}
+
+	if out == "" {
+		_, err = io.WriteString(stdout, hcl)
+		return err
+	}
+	return os.WriteFile(out, []byte(hcl), 0o644)
+}
@@ -0,0 +1,142 @@
+// Package cofidectl converts cofidectl configuration files (`cofide.yaml`)
+// into Terraform configuration for the equivalent Cofide Connect resources.
+//
+// The configuration is cofidectl's v1alpha1 config message serialised as
+// YAML with snake_case field names. Objects may refer to each other by name
+// or, in configurations written by newer cofidectl versions, by ID:
+//
+//	trust_zones:
+//	  - name: tz1
+//	    trust_domain: td1
+//	    federations:
+//	      - from: tz1            # or trust_zone_id
+//	        to: tz2              # or remote_trust_zone_id
+//	    attestation_policies:
+//	      - trust_zone: tz1      # or trust_zone_id
+//	        policy: ap1          # or policy_id
+//	        federates_with: [tz2]
+//	clusters:
+//	  - name: local1
+//	    trust_zone: tz1          # or trust_zone_id
+//	    kubernetes_context: kind-local1
+//	    trust_provider:
+//	      kind: kubernetes
+//	    profile: kubernetes
+//	    extra_helm_values: {}
+//	attestation_policies:
+//	  - name: ap1
+//	    kubernetes:
+//	      namespace_selector:
+//	        match_labels:
+//	          kubernetes.io/metadata.name: ns1
+//
+// Older configurations that nest clusters within their trust zone are also
+// accepted. Plugin configuration is ignored.
+package cofidectl
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a cofidectl configuration file.
+type Config struct {
+	TrustZones          []TrustZone         `yaml:"trust_zones"`
+	Clusters            []Cluster           `yaml:"clusters"`
+	AttestationPolicies []AttestationPolicy `yaml:"attestation_policies"`
+}
+
+type TrustZone struct {
+	ID                  string       `yaml:"id"`
+	Name                string       `yaml:"name"`
+	TrustDomain         string       `yaml:"trust_domain"`
+	BundleEndpointURL   string       `yaml:"bundle_endpoint_url"`
+	JWTIssuer           string       `yaml:"jwt_issuer"`
+	Clusters            []Cluster    `yaml:"clusters"`
+	Federations         []Federation `yaml:"federations"`
+	AttestationPolicies []APBinding  `yaml:"attestation_policies"`
+}
+
+type Cluster struct {
+	ID                string         `yaml:"id"`
+	Name              string         `yaml:"name"`
+	TrustZone         string         `yaml:"trust_zone"`
+	TrustZoneID       string         `yaml:"trust_zone_id"`
+	KubernetesContext string         `yaml:"kubernetes_context"`
+	TrustProvider     *TrustProvider `yaml:"trust_provider"`
+	Profile           string         `yaml:"profile"`
+	ExternalServer    *bool          `yaml:"external_server"`
+	OidcIssuerURL     string         `yaml:"oidc_issuer_url"`
+	ExtraHelmValues   map[string]any `yaml:"extra_helm_values"`
+}
+
+type TrustProvider struct {
+	Kind string `yaml:"kind"`
+}
+
+type Federation struct {
+	From              string `yaml:"from"`
+	To                string `yaml:"to"`
+	TrustZoneID       string `yaml:"trust_zone_id"`
+	RemoteTrustZoneID string `yaml:"remote_trust_zone_id"`
+}
+
+type APBinding struct {
+	TrustZone     string                `yaml:"trust_zone"`
+	TrustZoneID   string                `yaml:"trust_zone_id"`
+	Policy        string                `yaml:"policy"`
+	PolicyID      string                `yaml:"policy_id"`
+	FederatesWith []string              `yaml:"federates_with"`
+	Federations   []APBindingFederation `yaml:"federations"`
+}
+
+type APBindingFederation struct {
+	TrustZoneID string `yaml:"trust_zone_id"`
+}
+
+type AttestationPolicy struct {
+	ID         string        `yaml:"id"`
+	Name       string        `yaml:"name"`
+	Kubernetes *APKubernetes `yaml:"kubernetes"`
+	Static     *APStatic     `yaml:"static"`
+}
+
+type APKubernetes struct {
+	NamespaceSelector    *APLabelSelector `yaml:"namespace_selector"`
+	PodSelector          *APLabelSelector `yaml:"pod_selector"`
+	DNSNameTemplates     []string         `yaml:"dns_name_templates"`
+	SpiffeIDPathTemplate string           `yaml:"spiffe_id_path_template"`
+}
+
+type APLabelSelector struct {
+	MatchLabels      map[string]string   `yaml:"match_labels"`
+	MatchExpressions []APMatchExpression `yaml:"match_expressions"`
+}
+
+type APMatchExpression struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values"`
+}
+
+type APStatic struct {
+	SpiffeIDPath string       `yaml:"spiffe_id_path"`
+	ParentIDPath string       `yaml:"parent_id_path"`
+	Selectors    []APSelector `yaml:"selectors"`
+	DNSNames     []string     `yaml:"dns_names"`
+}
+
+type APSelector struct {
+	Type  string `yaml:"type"`
+	Value string `yaml:"value"`
+}
+
+// Parse parses a cofidectl configuration file.
+func Parse(data []byte) (*Config, error) {
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid cofidectl configuration: %w", err)
+	}
+	return &config, nil
+}
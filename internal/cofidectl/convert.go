@@ -0,0 +1,334 @@
+package cofidectl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cofide/terraform-provider-cofide/internal/hclgen"
+)
+
+const (
+	trustZoneType         = "cofide_connect_trust_zone"
+	clusterType           = "cofide_connect_cluster"
+	attestationPolicyType = "cofide_connect_attestation_policy"
+	apBindingType         = "cofide_connect_ap_binding"
+	federationType        = "cofide_connect_federation"
+
+	defaultProfile           = "kubernetes"
+	defaultTrustProviderKind = "kubernetes"
+)
+
+// converter holds the state of a single conversion.
+type converter struct {
+	labels *hclgen.Labeler
+
+	// trustZones and policies map the names and IDs of trust zones and
+	// attestation policies to their resource labels.
+	trustZones map[string]string
+	policies   map[string]string
+
+	blocks   []string
+	warnings []string
+}
+
+// Convert converts a cofidectl configuration into Terraform configuration.
+// It also returns warnings about settings that have no Terraform equivalent
+// and were dropped.
+func Convert(config *Config) (string, []string, error) {
+	c := &converter{
+		labels:     hclgen.NewLabeler(),
+		trustZones: map[string]string{},
+		policies:   map[string]string{},
+	}
+
+	for _, trustZone := range config.TrustZones {
+		c.trustZone(trustZone)
+	}
+	for _, policy := range config.AttestationPolicies {
+		c.attestationPolicy(policy)
+	}
+
+	for _, trustZone := range config.TrustZones {
+		for _, cluster := range trustZone.Clusters {
+			if cluster.TrustZone == "" && cluster.TrustZoneID == "" {
+				cluster.TrustZone = trustZone.Name
+			}
+			if err := c.cluster(cluster); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	for _, cluster := range config.Clusters {
+		if err := c.cluster(cluster); err != nil {
+			return "", nil, err
+		}
+	}
+
+	for _, trustZone := range config.TrustZones {
+		for _, binding := range trustZone.AttestationPolicies {
+			if binding.TrustZone == "" && binding.TrustZoneID == "" {
+				binding.TrustZone = trustZone.Name
+			}
+			if err := c.apBinding(binding); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	for _, trustZone := range config.TrustZones {
+		for _, federation := range trustZone.Federations {
+			if federation.From == "" && federation.TrustZoneID == "" {
+				federation.From = trustZone.Name
+			}
+			if err := c.federation(federation); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString("# Converted from a cofidectl configuration by terraform-provider-cofide convert-config.\n")
+	for _, block := range c.blocks {
+		buf.WriteString("\n" + block)
+	}
+	return buf.String(), c.warnings, nil
+}
+
+func (c *converter) trustZone(trustZone TrustZone) {
+	label := c.labels.Assign(trustZoneType, trustZone.Name)
+	c.trustZones[trustZone.Name] = label
+	if trustZone.ID != "" {
+		c.trustZones[trustZone.ID] = label
+	}
+
+	if trustZone.BundleEndpointURL != "" {
+		c.warn("trust zone %q: bundle_endpoint_url is assigned by Cofide Connect and was not converted", trustZone.Name)
+	}
+	if trustZone.JWTIssuer != "" {
+		c.warn("trust zone %q: jwt_issuer is assigned by Cofide Connect and was not converted", trustZone.Name)
+	}
+
+	c.resource(trustZoneType, label, []hclgen.Attribute{
+		{Name: "name", Value: hclgen.Quote(trustZone.Name)},
+		{Name: "trust_domain", Value: hclgen.Quote(trustZone.TrustDomain)},
+	})
+}
+
+func (c *converter) cluster(cluster Cluster) error {
+	trustZone, err := c.reference(c.trustZones, trustZoneType, "trust zone", cluster.TrustZone, cluster.TrustZoneID)
+	if err != nil {
+		return fmt.Errorf("cluster %q: %w", cluster.Name, err)
+	}
+
+	profile := cluster.Profile
+	if profile == "" {
+		profile = defaultProfile
+	}
+	kind := defaultTrustProviderKind
+	if cluster.TrustProvider != nil && cluster.TrustProvider.Kind != "" {
+		kind = cluster.TrustProvider.Kind
+	}
+
+	attributes := []hclgen.Attribute{
+		{Name: "name", Value: hclgen.Quote(cluster.Name)},
+		{Name: "trust_zone_id", Value: trustZone},
+	}
+	if cluster.KubernetesContext != "" {
+		attributes = append(attributes, hclgen.Attribute{Name: "kubernetes_context", Value: hclgen.Quote(cluster.KubernetesContext)})
+	}
+	attributes = append(attributes,
+		hclgen.Attribute{Name: "profile", Value: hclgen.Quote(profile)},
+		hclgen.Attribute{Name: "trust_provider", Value: hclgen.Object([]hclgen.Attribute{
+			{Name: "kind", Value: hclgen.Quote(kind)},
+		})},
+	)
+	if cluster.ExternalServer != nil {
+		attributes = append(attributes, hclgen.Attribute{Name: "external_server", Value: strconv.FormatBool(*cluster.ExternalServer)})
+	}
+	if cluster.OidcIssuerURL != "" {
+		attributes = append(attributes, hclgen.Attribute{Name: "oidc_issuer_url", Value: hclgen.Quote(cluster.OidcIssuerURL)})
+	}
+	if len(cluster.ExtraHelmValues) > 0 {
+		attributes = append(attributes, hclgen.Attribute{
+			Name:  "extra_helm_values",
+			Value: "yamlencode(" + hclgen.Value(cluster.ExtraHelmValues) + ")",
+		})
+	}
+
+	c.resource(clusterType, c.labels.Assign(clusterType, cluster.Name), attributes)
+	return nil
+}
+
+func (c *converter) attestationPolicy(policy AttestationPolicy) {
+	label := c.labels.Assign(attestationPolicyType, policy.Name)
+	c.policies[policy.Name] = label
+	if policy.ID != "" {
+		c.policies[policy.ID] = label
+	}
+
+	attributes := []hclgen.Attribute{
+		{Name: "name", Value: hclgen.Quote(policy.Name)},
+	}
+
+	if k8s := policy.Kubernetes; k8s != nil {
+		var kubernetes []hclgen.Attribute
+		if k8s.NamespaceSelector != nil {
+			kubernetes = append(kubernetes, hclgen.Attribute{Name: "namespace_selector", Value: labelSelector(k8s.NamespaceSelector)})
+		}
+		if k8s.PodSelector != nil {
+			kubernetes = append(kubernetes, hclgen.Attribute{Name: "pod_selector", Value: labelSelector(k8s.PodSelector)})
+		}
+		if len(k8s.DNSNameTemplates) > 0 {
+			kubernetes = append(kubernetes, hclgen.Attribute{Name: "dns_name_templates", Value: stringList(k8s.DNSNameTemplates)})
+		}
+		if k8s.SpiffeIDPathTemplate != "" {
+			kubernetes = append(kubernetes, hclgen.Attribute{Name: "spiffe_id_path_template", Value: hclgen.Quote(k8s.SpiffeIDPathTemplate)})
+		}
+		attributes = append(attributes, hclgen.Attribute{Name: "kubernetes", Value: hclgen.Object(kubernetes)})
+	}
+
+	if static := policy.Static; static != nil {
+		selectors := make([]string, 0, len(static.Selectors))
+		for _, selector := range static.Selectors {
+			selectors = append(selectors, hclgen.Object([]hclgen.Attribute{
+				{Name: "type", Value: hclgen.Quote(selector.Type)},
+				{Name: "value", Value: hclgen.Quote(selector.Value)},
+			}))
+		}
+
+		staticAttributes := []hclgen.Attribute{
+			{Name: "spiffe_id_path", Value: hclgen.Quote(static.SpiffeIDPath)},
+			{Name: "parent_id_path", Value: hclgen.Quote(static.ParentIDPath)},
+			{Name: "selectors", Value: hclgen.List(selectors)},
+		}
+		if len(static.DNSNames) > 0 {
+			staticAttributes = append(staticAttributes, hclgen.Attribute{Name: "dns_names", Value: stringList(static.DNSNames)})
+		}
+		attributes = append(attributes, hclgen.Attribute{Name: "static", Value: hclgen.Object(staticAttributes)})
+	}
+
+	c.resource(attestationPolicyType, label, attributes)
+}
+
+func (c *converter) apBinding(binding APBinding) error {
+	trustZone, err := c.reference(c.trustZones, trustZoneType, "trust zone", binding.TrustZone, binding.TrustZoneID)
+	if err != nil {
+		return fmt.Errorf("attestation policy binding: %w", err)
+	}
+	policy, err := c.reference(c.policies, attestationPolicyType, "attestation policy", binding.Policy, binding.PolicyID)
+	if err != nil {
+		return fmt.Errorf("attestation policy binding in trust zone %s: %w", trustZone, err)
+	}
+
+	federatesWith := binding.FederatesWith
+	for _, federation := range binding.Federations {
+		federatesWith = append(federatesWith, federation.TrustZoneID)
+	}
+
+	attributes := []hclgen.Attribute{
+		{Name: "trust_zone_id", Value: trustZone},
+		{Name: "policy_id", Value: policy},
+	}
+	if len(federatesWith) > 0 {
+		federations := make([]string, 0, len(federatesWith))
+		for _, remote := range federatesWith {
+			remoteTrustZone, err := c.reference(c.trustZones, trustZoneType, "trust zone", remote, "")
+			if err != nil {
+				return fmt.Errorf("attestation policy binding in trust zone %s: %w", trustZone, err)
+			}
+			federations = append(federations, hclgen.Object([]hclgen.Attribute{
+				{Name: "trust_zone_id", Value: remoteTrustZone},
+			}))
+		}
+		attributes = append(attributes, hclgen.Attribute{Name: "federations", Value: hclgen.List(federations)})
+	}
+
+	label := c.labels.Assign(apBindingType, labelOf(policy)+"_"+labelOf(trustZone))
+	c.resource(apBindingType, label, attributes)
+	return nil
+}
+
+func (c *converter) federation(federation Federation) error {
+	trustZone, err := c.reference(c.trustZones, trustZoneType, "trust zone", federation.From, federation.TrustZoneID)
+	if err != nil {
+		return fmt.Errorf("federation: %w", err)
+	}
+	remoteTrustZone, err := c.reference(c.trustZones, trustZoneType, "trust zone", federation.To, federation.RemoteTrustZoneID)
+	if err != nil {
+		return fmt.Errorf("federation from %s: %w", trustZone, err)
+	}
+
+	label := c.labels.Assign(federationType, labelOf(trustZone)+"_to_"+labelOf(remoteTrustZone))
+	c.resource(federationType, label, []hclgen.Attribute{
+		{Name: "trust_zone_id", Value: trustZone},
+		{Name: "remote_trust_zone_id", Value: remoteTrustZone},
+	})
+	return nil
+}
+
+// reference returns an expression referring to the ID of the resource named
+// or identified by name or id in labels.
+func (c *converter) reference(labels map[string]string, typeName, noun, name, id string) (string, error) {
+	key := name
+	if key == "" {
+		key = id
+	}
+	if key == "" {
+		return "", fmt.Errorf("no %s specified", noun)
+	}
+
+	label, ok := labels[key]
+	if !ok {
+		return "", fmt.Errorf("unknown %s %q", noun, key)
+	}
+	return fmt.Sprintf("%s.%s.id", typeName, label), nil
+}
+
+func (c *converter) resource(typeName, label string, attributes []hclgen.Attribute) {
+	c.blocks = append(c.blocks, hclgen.Block("resource", []string{typeName, label}, attributes))
+}
+
+func (c *converter) warn(format string, args ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+// labelOf returns the resource label of a reference expression.
+func labelOf(reference string) string {
+	parts := strings.Split(reference, ".")
+	return parts[len(parts)-2]
+}
+
+func labelSelector(selector *APLabelSelector) string {
+	var attributes []hclgen.Attribute
+	if len(selector.MatchLabels) > 0 {
+		labels := make([]hclgen.Attribute, 0, len(selector.MatchLabels))
+		for _, key := range hclgen.SortedKeys(selector.MatchLabels) {
+			labels = append(labels, hclgen.Attribute{Name: hclgen.ObjectKey(key), Value: hclgen.Quote(selector.MatchLabels[key])})
+		}
+		attributes = append(attributes, hclgen.Attribute{Name: "match_labels", Value: hclgen.Object(labels)})
+	}
+	if len(selector.MatchExpressions) > 0 {
+		expressions := make([]string, 0, len(selector.MatchExpressions))
+		for _, expression := range selector.MatchExpressions {
+			expressionAttributes := []hclgen.Attribute{
+				{Name: "key", Value: hclgen.Quote(expression.Key)},
+				{Name: "operator", Value: hclgen.Quote(expression.Operator)},
+			}
+			if len(expression.Values) > 0 {
+				expressionAttributes = append(expressionAttributes, hclgen.Attribute{Name: "values", Value: stringList(expression.Values)})
+			}
+			expressions = append(expressions, hclgen.Object(expressionAttributes))
+		}
+		attributes = append(attributes, hclgen.Attribute{Name: "match_expressions", Value: hclgen.List(expressions)})
+	}
+	return hclgen.Object(attributes)
+}
+
+func stringList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, hclgen.Quote(value))
+	}
+	return hclgen.List(quoted)
+}
@@ -0,0 +1,99 @@
+package cofidectl
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestConvert(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "cofide.yaml"))
+	require.NoError(t, err)
+
+	config, err := Parse(data)
+	require.NoError(t, err)
+
+	got, warnings, err := Convert(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`trust zone "tz1": bundle_endpoint_url is assigned by Cofide Connect and was not converted`,
+	}, warnings)
+
+	golden := filepath.Join("testdata", "cofide.tf")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, []byte(got), 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), got)
+}
+
+func TestConvertErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name: "cluster in unknown trust zone",
+			config: `
+clusters:
+  - name: local1
+    trust_zone: missing
+`,
+			wantErr: `cluster "local1": unknown trust zone "missing"`,
+		},
+		{
+			name: "cluster without trust zone",
+			config: `
+clusters:
+  - name: local1
+`,
+			wantErr: `cluster "local1": no trust zone specified`,
+		},
+		{
+			name: "binding of unknown policy",
+			config: `
+trust_zones:
+  - name: tz1
+    trust_domain: td1
+    attestation_policies:
+      - policy: missing
+`,
+			wantErr: `attestation policy binding in trust zone cofide_connect_trust_zone.tz1.id: unknown attestation policy "missing"`,
+		},
+		{
+			name: "federation with unknown trust zone",
+			config: `
+trust_zones:
+  - name: tz1
+    trust_domain: td1
+    federations:
+      - to: tz2
+`,
+			wantErr: `federation from cofide_connect_trust_zone.tz1.id: unknown trust zone "tz2"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse([]byte(tt.config))
+			require.NoError(t, err)
+
+			_, _, err = Convert(config)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse([]byte("trust_zones: {"))
+	assert.ErrorContains(t, err, "invalid cofidectl configuration")
+}
@@ -0,0 +1,103 @@
+# Converted from a cofidectl configuration by terraform-provider-cofide convert-config.
+
+resource "cofide_connect_trust_zone" "tz1" {
+  name         = "tz1"
+  trust_domain = "td1"
+}
+
+resource "cofide_connect_trust_zone" "tz2" {
+  name         = "tz2"
+  trust_domain = "td2"
+}
+
+resource "cofide_connect_attestation_policy" "ap1" {
+  name = "ap1"
+  kubernetes = {
+    namespace_selector = {
+      match_labels = {
+        "kubernetes.io/metadata.name" = "ns1"
+      }
+    }
+    pod_selector = {
+      match_expressions = [
+        {
+          key      = "app"
+          operator = "In"
+          values   = ["web", "api"]
+        },
+      ]
+    }
+  }
+}
+
+resource "cofide_connect_attestation_policy" "static_workload" {
+  name = "static-workload"
+  static = {
+    spiffe_id_path = "ns/default/sa/static"
+    parent_id_path = "spire/agent/local"
+    selectors = [
+      {
+        type  = "k8s"
+        value = "ns:default"
+      },
+    ]
+  }
+}
+
+resource "cofide_connect_cluster" "local1" {
+  name               = "local1"
+  trust_zone_id      = cofide_connect_trust_zone.tz1.id
+  kubernetes_context = "kind-local1"
+  profile            = "kubernetes"
+  trust_provider = {
+    kind = "kubernetes"
+  }
+  external_server = false
+  extra_helm_values = yamlencode({
+    global = {
+      spire = {
+        namespaces = {
+          create = true
+        }
+      }
+    }
+    spire-server = {
+      replicaCount = 2
+    }
+  })
+}
+
+resource "cofide_connect_cluster" "local2" {
+  name               = "local2"
+  trust_zone_id      = cofide_connect_trust_zone.tz2.id
+  kubernetes_context = "kind-local2"
+  profile            = "kubernetes"
+  trust_provider = {
+    kind = "kubernetes"
+  }
+}
+
+resource "cofide_connect_ap_binding" "ap1_tz1" {
+  trust_zone_id = cofide_connect_trust_zone.tz1.id
+  policy_id     = cofide_connect_attestation_policy.ap1.id
+  federations = [
+    {
+      trust_zone_id = cofide_connect_trust_zone.tz2.id
+    },
+  ]
+}
+
+resource "cofide_connect_ap_binding" "static_workload_tz2" {
+  trust_zone_id = cofide_connect_trust_zone.tz2.id
+  policy_id     = cofide_connect_attestation_policy.static_workload.id
+}
+
+resource "cofide_connect_federation" "tz1_to_tz2" {
+  trust_zone_id        = cofide_connect_trust_zone.tz1.id
+  remote_trust_zone_id = cofide_connect_trust_zone.tz2.id
+}
+
+resource "cofide_connect_federation" "tz2_to_tz1" {
+  trust_zone_id        = cofide_connect_trust_zone.tz2.id
+  remote_trust_zone_id = cofide_connect_trust_zone.tz1.id
+}
@@ -0,0 +1,823 @@
+package internal_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cofide/terraform-provider-cofide/internal"
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+	"github.com/cofide/terraform-provider-cofide/internal/schemacheck"
+)
+
+// The conformance tests in this file iterate over everything the provider
+// registers, so a new resource or data source is covered as soon as it is
+// added to CofideProvider.Resources, CofideProvider.DataSources or
+// CofideProvider.ListResources.
+
+// listDataSources maps each list data source to the resource whose model its
+// list attribute nests, less the attributes omitted from list elements, such
+// as dynamic attributes, which the framework does not support in lists.
+var listDataSources = map[string]struct {
+	resource  string
+	attribute string
+	omitted   []string
+}{
+	"cofide_connect_exchange_policies": {resource: "cofide_connect_exchange_policy", attribute: "exchange_policies"},
+	"cofide_connect_trust_zone_servers": {
+		resource:  "cofide_connect_trust_zone_server",
+		attribute: "trust_zone_servers",
+		omitted:   []string{"helm_values_object"},
+	},
+}
+
+// standaloneDataSources lists data sources without a corresponding resource.
+var standaloneDataSources = map[string]bool{
+	"cofide_connect_kubeconfig_cluster": true,
+	"cofide_connect_organization":       true,
+}
+
+// resourcesWithoutDataSource lists resources without a corresponding data
+// source.
+var resourcesWithoutDataSource = map[string]bool{
+	"cofide_connect_federation_mesh":              true,
+	"cofide_connect_federation_pair":              true,
+	"cofide_connect_role_binding":                 true,
+	"cofide_connect_role_bindings_exclusive":      true,
+	"cofide_connect_trust_zone_exchange_policies": true,
+	"cofide_connect_workload_identity":            true,
+}
+
+// resourcesWithoutListResource lists resources that do not correspond to a
+// single Connect object and so cannot be enumerated by `terraform query`.
+var resourcesWithoutListResource = map[string]bool{
+	"cofide_connect_federation_mesh":              true,
+	"cofide_connect_role_bindings_exclusive":      true,
+	"cofide_connect_trust_zone_exchange_policies": true,
+	"cofide_connect_workload_identity":            true,
+}
+
+// resourceOnlyAttributes lists attributes that control how Terraform manages
+// an object rather than describe it, or that the API does not return. They
+// are kept in Terraform state only, so resources add them to the model shared
+// with their data sources.
+var resourceOnlyAttributes = map[string]bool{
+	"deletion_protection":      true,
+	"cascade_delete":           true,
+	"wait_for_status":          true,
+	"adopt_existing":           true,
+	"retain_on_delete":         true,
+	"validate_helm_values":     true,
+	"extra_helm_values_layers": true,
+	"helm_values_layers":       true,
+
+	"sensitive_extra_helm_values":         true,
+	"sensitive_extra_helm_values_version": true,
+	"sensitive_helm_values":               true,
+	"sensitive_helm_values_version":       true,
+}
+
+// expectedSensitive returns whether an attribute with the given name must be
+// marked sensitive, in a data source if dataSource is true, and false for ok
+// if the name carries no expectation.
+//
+// Certificates configured here are CA certificates and Helm values are plain
+// chart configuration; hiding either would only obscure plan diffs. Secret
+// material goes in dedicated sensitive_ attributes, whose _version triggers
+// are plain numbers. Data sources cannot tell the values merged in from those
+// attributes apart from the rest, so they mark all Helm values sensitive.
+func expectedSensitive(name string, dataSource bool) (sensitive bool, ok bool) {
+	switch {
+	case strings.HasPrefix(name, "sensitive_") && strings.HasSuffix(name, "_version"):
+		return false, true
+	case strings.HasPrefix(name, "sensitive_"):
+		return true, true
+	case strings.Contains(name, "helm_values"):
+		return dataSource, true
+	case strings.HasSuffix(name, "_cert"):
+		return false, true
+	}
+	return false, false
+}
+
+// describedAttribute is implemented by attributes of both resource and data
+// source schemas.
+type describedAttribute interface {
+	GetDescription() string
+	GetMarkdownDescription() string
+	IsSensitive() bool
+}
+
+func newTestProvider() provider.Provider {
+	return internal.NewProvider("test")()
+}
+
+func providerTypeName(ctx context.Context, p provider.Provider) string {
+	resp := &provider.MetadataResponse{}
+	p.Metadata(ctx, provider.MetadataRequest{}, resp)
+	return resp.TypeName
+}
+
+// providerResources returns the provider's resources keyed by type name.
+func providerResources(t *testing.T) map[string]resource.Resource {
+	t.Helper()
+
+	ctx := context.Background()
+	p := newTestProvider()
+	typeName := providerTypeName(ctx, p)
+
+	resources := map[string]resource.Resource{}
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		resp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: typeName}, resp)
+		require.NotContains(t, resources, resp.TypeName, "resource type registered twice")
+		resources[resp.TypeName] = r
+	}
+	return resources
+}
+
+// providerDataSources returns the provider's data sources keyed by type name.
+func providerDataSources(t *testing.T) map[string]datasource.DataSource {
+	t.Helper()
+
+	ctx := context.Background()
+	p := newTestProvider()
+	typeName := providerTypeName(ctx, p)
+
+	dataSources := map[string]datasource.DataSource{}
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+		resp := &datasource.MetadataResponse{}
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: typeName}, resp)
+		require.NotContains(t, dataSources, resp.TypeName, "data source type registered twice")
+		dataSources[resp.TypeName] = d
+	}
+	return dataSources
+}
+
+// providerListResources returns the provider's list resources keyed by type
+// name.
+func providerListResources(t *testing.T) map[string]list.ListResource {
+	t.Helper()
+
+	ctx := context.Background()
+	p := newTestProvider()
+	typeName := providerTypeName(ctx, p)
+
+	withList, ok := p.(provider.ProviderWithListResources)
+	require.True(t, ok, "provider does not implement provider.ProviderWithListResources")
+
+	listResources := map[string]list.ListResource{}
+	for _, newListResource := range withList.ListResources(ctx) {
+		l := newListResource()
+		resp := &resource.MetadataResponse{}
+		l.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: typeName}, resp)
+		require.NotContains(t, listResources, resp.TypeName, "list resource type registered twice")
+		listResources[resp.TypeName] = l
+	}
+	return listResources
+}
+
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "schema diagnostics: %v", resp.Diagnostics)
+	return resp.Schema
+}
+
+func dataSourceSchema(t *testing.T, d datasource.DataSource) dsschema.Schema {
+	t.Helper()
+
+	resp := &datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "schema diagnostics: %v", resp.Diagnostics)
+	return resp.Schema
+}
+
+func listResourceSchema(t *testing.T, l list.ListResource) listschema.Schema {
+	t.Helper()
+
+	resp := &list.ListResourceSchemaResponse{}
+	l.ListResourceConfigSchema(context.Background(), list.ListResourceSchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "schema diagnostics: %v", resp.Diagnostics)
+	return resp.Schema
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TestProviderSchemaIsValid loads the provider's schemas through the provider
+// server, as Terraform does, which reports schemas the framework does not
+// support, such as dynamic attributes nested in lists, that fail every
+// request rather than just those for the offending resource or data source.
+func TestProviderSchemaIsValid(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(newTestProvider())()
+	require.NoError(t, err)
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	for _, diag := range resp.Diagnostics {
+		t.Errorf("%s: %s", diag.Summary, diag.Detail)
+	}
+}
+
+// TestSchemaShapesMatch asserts that each resource schema and the data source
+// schema(s) for the same resource describe an identical object shape.
+//
+// A resource and its data sources share a single *Model struct, so a field
+// present in one schema but not the other makes reading fail at runtime with a
+// Value Conversion Error. The two schemas differ legitimately only in
+// Required/Optional/Computed and plan modifiers, neither of which affects the
+// shape compared here, and in resourceOnlyAttributes.
+func TestSchemaShapesMatch(t *testing.T) {
+	resources := providerResources(t)
+	dataSources := providerDataSources(t)
+
+	for _, name := range sortedKeys(dataSources) {
+		t.Run(name, func(t *testing.T) {
+			dataSourceType := dataSourceSchema(t, dataSources[name]).Type()
+
+			if list, ok := listDataSources[name]; ok {
+				// List data sources nest the same model under a list
+				// attribute, so compare the element type rather than the
+				// top-level schema.
+				r, ok := resources[list.resource]
+				require.True(t, ok, "no resource %q for list data source", list.resource)
+				assertSameShape(t, resourceSchema(t, r).Type(), listElementType(t, dataSourceType, list.attribute), list.omitted...)
+				return
+			}
+
+			r, ok := resources[name]
+			if !ok {
+				assert.True(t, standaloneDataSources[name], "data source has no matching resource; add it to standaloneDataSources if that is intended")
+				return
+			}
+			assertSameShape(t, resourceSchema(t, r).Type(), dataSourceType)
+		})
+	}
+
+	for _, name := range sortedKeys(resources) {
+		if _, ok := dataSources[name]; !ok && !resourcesWithoutDataSource[name] {
+			t.Errorf("resource %s has no matching data source; add it to resourcesWithoutDataSource if that is intended", name)
+		}
+	}
+}
+
+// assertSameShape compares the shapes of a resource and data source, ignoring
+// resourceOnlyAttributes and the omitted attributes of the resource.
+func assertSameShape(t *testing.T, resourceType, dataSourceType attr.Type, omitted ...string) {
+	t.Helper()
+
+	if object, ok := resourceType.(types.ObjectType); ok {
+		attrTypes := map[string]attr.Type{}
+		for name, attrType := range object.AttrTypes {
+			if !resourceOnlyAttributes[name] && !slices.Contains(omitted, name) {
+				attrTypes[name] = attrType
+			}
+		}
+		resourceType = types.ObjectType{AttrTypes: attrTypes}
+	}
+
+	if !resourceType.Equal(dataSourceType) {
+		t.Errorf("resource and data source schemas describe different shapes\nresource:    %s\ndata source: %s",
+			resourceType, dataSourceType)
+	}
+}
+
+// listElementType returns the element type of the named list attribute of an
+// object type.
+func listElementType(t *testing.T, schemaType attr.Type, attrName string) attr.Type {
+	t.Helper()
+
+	object, ok := schemaType.(types.ObjectType)
+	require.True(t, ok, "expected an object type, got %s", schemaType)
+
+	attribute, ok := object.AttributeTypes()[attrName]
+	require.True(t, ok, "no %q attribute in %s", attrName, schemaType)
+
+	list, ok := attribute.(types.ListType)
+	require.True(t, ok, "expected %q to be a list type, got %s", attrName, attribute)
+
+	return list.ElementType()
+}
+
+func TestResourcesImplementImportState(t *testing.T) {
+	for name, r := range providerResources(t) {
+		_, ok := r.(resource.ResourceWithImportState)
+		assert.True(t, ok, "%s does not implement resource.ResourceWithImportState", name)
+	}
+}
+
+func TestResourcesImplementIdentity(t *testing.T) {
+	for name, r := range providerResources(t) {
+		_, ok := r.(resource.ResourceWithIdentity)
+		assert.True(t, ok, "%s does not implement resource.ResourceWithIdentity", name)
+	}
+}
+
+// TestListResourcesMatchResources asserts that every resource can be
+// enumerated by `terraform query`, and that each list resource returns a
+// resource type the provider manages.
+func TestListResourcesMatchResources(t *testing.T) {
+	resources := providerResources(t)
+	listResources := providerListResources(t)
+
+	for name := range resources {
+		if !resourcesWithoutListResource[name] {
+			assert.Contains(t, listResources, name, "resource %s has no list resource; add it to resourcesWithoutListResource if that is intended", name)
+		}
+	}
+	for name, l := range listResources {
+		assert.Contains(t, resources, name, "list resource %s has no matching resource", name)
+
+		_, ok := l.(list.ListResourceWithConfigure)
+		assert.True(t, ok, "%s does not implement list.ListResourceWithConfigure", name)
+	}
+}
+
+func TestSchemasAreDescribed(t *testing.T) {
+	for name, r := range providerResources(t) {
+		s := resourceSchema(t, r)
+		assert.NotEmpty(t, s.GetDescription()+s.GetMarkdownDescription(), "%s has no description", name)
+		walkResourceAttributes(s.Attributes, name, func(path string, a describedAttribute) {
+			assert.NotEmpty(t, a.GetDescription()+a.GetMarkdownDescription(), "%s has no description", path)
+		})
+	}
+	for name, d := range providerDataSources(t) {
+		s := dataSourceSchema(t, d)
+		assert.NotEmpty(t, s.GetDescription()+s.GetMarkdownDescription(), "%s has no description", name)
+		walkDataSourceAttributes(s.Attributes, name, func(path string, a describedAttribute) {
+			assert.NotEmpty(t, a.GetDescription()+a.GetMarkdownDescription(), "%s has no description", path)
+		})
+	}
+	for name, l := range providerListResources(t) {
+		s := listResourceSchema(t, l)
+		assert.NotEmpty(t, s.GetDescription()+s.GetMarkdownDescription(), "%s list resource has no description", name)
+		for attrName, a := range s.Attributes {
+			assert.NotEmpty(t, a.GetDescription()+a.GetMarkdownDescription(), "%s list resource attribute %s has no description", name, attrName)
+		}
+	}
+}
+
+func TestSensitiveAttributes(t *testing.T) {
+	check := func(dataSource bool) func(string, describedAttribute) {
+		return func(path string, a describedAttribute) {
+			name := path[strings.LastIndex(path, ".")+1:]
+			if want, ok := expectedSensitive(name, dataSource); ok {
+				assert.Equal(t, want, a.IsSensitive(), "unexpected Sensitive flag on %s", path)
+			}
+		}
+	}
+
+	for name, r := range providerResources(t) {
+		walkResourceAttributes(resourceSchema(t, r).Attributes, name, check(false))
+	}
+	for name, d := range providerDataSources(t) {
+		walkDataSourceAttributes(dataSourceSchema(t, d).Attributes, name, check(true))
+	}
+}
+
+// TestResourceIDsUseStateForUnknown asserts that the server-assigned id of
+// every resource is carried over from state during updates, so that plans do
+// not show it as known after apply.
+func TestResourceIDsUseStateForUnknown(t *testing.T) {
+	ctx := context.Background()
+	want := stringplanmodifier.UseStateForUnknown().Description(ctx)
+
+	for name, r := range providerResources(t) {
+		id, ok := resourceSchema(t, r).Attributes["id"].(schema.StringAttribute)
+		if !assert.True(t, ok, "%s has no string id attribute", name) {
+			continue
+		}
+		assert.True(t, id.Computed, "%s.id is not computed", name)
+
+		found := false
+		for _, modifier := range id.PlanModifiers {
+			if modifier.Description(ctx) == want {
+				found = true
+			}
+		}
+		assert.True(t, found, "%s.id does not use UseStateForUnknown", name)
+	}
+}
+
+func TestDocumentationAndExamples(t *testing.T) {
+	check := func(t *testing.T, kind, name, ext string) {
+		docName := strings.TrimPrefix(name, "cofide_") + ".md"
+		assert.FileExists(t, filepath.Join("..", "docs", kind, docName))
+
+		var examples []string
+		_ = filepath.WalkDir(filepath.Join("..", "examples", kind, name), func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && strings.HasSuffix(path, ext) {
+				examples = append(examples, path)
+			}
+			return nil
+		})
+		assert.NotEmpty(t, examples, "no example for %s under examples/%s", name, kind)
+	}
+
+	for name := range providerResources(t) {
+		check(t, "resources", name, ".tf")
+	}
+	for name := range providerDataSources(t) {
+		check(t, "data-sources", name, ".tf")
+	}
+	for name := range providerListResources(t) {
+		check(t, "list-resources", name, ".tfquery.hcl")
+	}
+}
+
+// walkResourceAttributes calls fn for every attribute of a resource schema,
+// including those nested in other attributes, with its dotted path.
+func walkResourceAttributes(attributes map[string]schema.Attribute, prefix string, fn func(string, describedAttribute)) {
+	for name, a := range attributes {
+		path := prefix + "." + name
+		fn(path, a)
+
+		switch a := a.(type) {
+		case schema.SingleNestedAttribute:
+			walkResourceAttributes(a.Attributes, path, fn)
+		case schema.ListNestedAttribute:
+			walkResourceAttributes(a.NestedObject.Attributes, path, fn)
+		case schema.SetNestedAttribute:
+			walkResourceAttributes(a.NestedObject.Attributes, path, fn)
+		case schema.MapNestedAttribute:
+			walkResourceAttributes(a.NestedObject.Attributes, path, fn)
+		}
+	}
+}
+
+// walkDataSourceAttributes is walkResourceAttributes for data source schemas.
+func walkDataSourceAttributes(attributes map[string]dsschema.Attribute, prefix string, fn func(string, describedAttribute)) {
+	for name, a := range attributes {
+		path := prefix + "." + name
+		fn(path, a)
+
+		switch a := a.(type) {
+		case dsschema.SingleNestedAttribute:
+			walkDataSourceAttributes(a.Attributes, path, fn)
+		case dsschema.ListNestedAttribute:
+			walkDataSourceAttributes(a.NestedObject.Attributes, path, fn)
+		case dsschema.SetNestedAttribute:
+			walkDataSourceAttributes(a.NestedObject.Attributes, path, fn)
+		case dsschema.MapNestedAttribute:
+			walkDataSourceAttributes(a.NestedObject.Attributes, path, fn)
+		}
+	}
+}
+
+// resourceFixture is an object of a resource, as Terraform state and as the
+// configuration that manages it, from which the behaviour every resource
+// shares is checked.
+type resourceFixture struct {
+	// state is the state of the object, less the attributes kept in
+	// Terraform state only.
+	state map[string]any
+	// config configures the object in state without changing it.
+	config map[string]any
+	// changes are changes to config, each planned from state.
+	changes []resourceChange
+	// stateOnly holds the values in state of the attributes kept in
+	// Terraform state only.
+	stateOnly map[string]any
+	// protectedByDefault is true if the object is protected from deletion
+	// when deletion_protection is unset, as in state from before the
+	// attribute.
+	protectedByDefault bool
+}
+
+// resourceChange is a change to the configuration of a resource fixture.
+type resourceChange struct {
+	name string
+	// config overrides the fixture's configuration. A nil value removes the
+	// attribute.
+	config map[string]any
+	// replace lists the attributes whose change forces replacement.
+	replace []string
+	// unknown lists the attributes left unknown in the plan.
+	unknown []string
+}
+
+var (
+	clusterFixtureConfig = map[string]any{
+		"name":               "cluster",
+		"trust_zone_id":      "tz-1",
+		"kubernetes_context": "kind",
+		"trust_provider":     map[string]any{"kind": "kubernetes"},
+		"profile":            "kubernetes",
+		"external_server":    false,
+	}
+	staticPolicyFixture = map[string]any{
+		"spiffe_id_path": "ns/default/sa/app",
+		"parent_id_path": "spire/agent",
+		"selectors":      []any{map[string]any{"type": "k8s", "value": "ns:default"}},
+		"store_svid":     false,
+	}
+	roleBindingFixtureResource = map[string]any{"type": "TrustZone", "id": "tz-1"}
+	exchangePolicyFixture      = map[string]any{"id": "ep-1", "org_id": "org-1", "trust_zone_id": "tz-1", "name": "deny-all", "action": "DENY", "outbound_scopes": []any{}}
+)
+
+// resourceFixtures holds a fixture for every resource.
+var resourceFixtures = map[string]resourceFixture{
+	"cofide_connect_ap_binding": {
+		state:  map[string]any{"id": "apb-1", "org_id": "org-1", "trust_zone_id": "tz-1", "policy_id": "ap-1"},
+		config: map[string]any{"trust_zone_id": "tz-1", "policy_id": "ap-1"},
+		changes: []resourceChange{
+			{name: "federations changed", config: map[string]any{"federations": []any{map[string]any{"trust_zone_id": "tz-2"}}}},
+			{name: "policy_id changed", config: map[string]any{"policy_id": "ap-2"}, replace: []string{"policy_id"}},
+			{name: "trust_zone_id changed", config: map[string]any{"trust_zone_id": "tz-2"}, replace: []string{"trust_zone_id"}},
+		},
+	},
+	"cofide_connect_attestation_policy": {
+		state:  map[string]any{"id": "ap-1", "name": "ap", "org_id": "org-1", "static": staticPolicyFixture},
+		config: map[string]any{"name": "ap", "static": staticPolicyFixture},
+		changes: []resourceChange{
+			{name: "renamed", config: map[string]any{"name": "renamed"}},
+			{name: "org_id changed", config: map[string]any{"org_id": "org-2"}, replace: []string{"org_id"}},
+		},
+		stateOnly: map[string]any{"adopt_existing": false},
+	},
+	"cofide_connect_cluster": {
+		state:  withOverrides(clusterFixtureConfig, map[string]any{"id": "c-1", "org_id": "org-1"}),
+		config: clusterFixtureConfig,
+		changes: []resourceChange{
+			{name: "renamed", config: map[string]any{"name": "renamed"}},
+			{name: "trust_zone_id changed", config: map[string]any{"trust_zone_id": "tz-2"}, replace: []string{"trust_zone_id"}},
+		},
+		stateOnly: map[string]any{"deletion_protection": false, "adopt_existing": false, "retain_on_delete": false, "validate_helm_values": true},
+	},
+	"cofide_connect_exchange_policy": {
+		state:  exchangePolicyFixture,
+		config: map[string]any{"trust_zone_id": "tz-1", "name": "deny-all", "action": "DENY"},
+		changes: []resourceChange{
+			{name: "action changed", config: map[string]any{"action": "ALLOW"}},
+			{name: "trust_zone_id changed", config: map[string]any{"trust_zone_id": "tz-2"}, replace: []string{"trust_zone_id"}},
+		},
+		stateOnly: map[string]any{"adopt_existing": false},
+	},
+	"cofide_connect_federation": {
+		state:  map[string]any{"id": "fed-1", "org_id": "org-1", "trust_zone_id": "tz-1", "remote_trust_zone_id": "tz-2"},
+		config: map[string]any{"trust_zone_id": "tz-1", "remote_trust_zone_id": "tz-2"},
+		changes: []resourceChange{
+			{name: "trust_zone_id changed", config: map[string]any{"trust_zone_id": "tz-3"}, replace: []string{"trust_zone_id"}},
+			{name: "remote_trust_zone_id changed", config: map[string]any{"remote_trust_zone_id": "tz-3"}, replace: []string{"remote_trust_zone_id"}},
+		},
+	},
+	"cofide_connect_federation_mesh": {
+		state: map[string]any{
+			"id":             "tz-1,tz-2",
+			"trust_zone_ids": []any{"tz-1", "tz-2"},
+			"federations": []any{
+				map[string]any{"id": "fed-1", "trust_zone_id": "tz-1", "remote_trust_zone_id": "tz-2"},
+				map[string]any{"id": "fed-2", "trust_zone_id": "tz-2", "remote_trust_zone_id": "tz-1"},
+			},
+			"unmanaged_federations": []any{},
+		},
+		config: map[string]any{"trust_zone_ids": []any{"tz-2", "tz-1"}},
+		changes: []resourceChange{
+			{name: "trust zone added", config: map[string]any{"trust_zone_ids": []any{"tz-1", "tz-2", "tz-3"}}, unknown: []string{"federations"}},
+		},
+	},
+	"cofide_connect_federation_pair": {
+		state: map[string]any{
+			"id":                    "fed-1",
+			"org_id":                "org-1",
+			"trust_zone_id":         "tz-1",
+			"remote_trust_zone_id":  "tz-2",
+			"federation_id":         "fed-1",
+			"reverse_federation_id": "fed-2",
+		},
+		config: map[string]any{"trust_zone_id": "tz-1", "remote_trust_zone_id": "tz-2"},
+		changes: []resourceChange{
+			{name: "trust_zone_id changed", config: map[string]any{"trust_zone_id": "tz-3"}, replace: []string{"trust_zone_id"}},
+			{name: "remote_trust_zone_id changed", config: map[string]any{"remote_trust_zone_id": "tz-3"}, replace: []string{"remote_trust_zone_id"}},
+		},
+	},
+	"cofide_connect_role_binding": {
+		state:  map[string]any{"id": "rb-1", "role_id": "viewer", "user": map[string]any{"subject": "alice"}, "resource": roleBindingFixtureResource},
+		config: map[string]any{"role_id": "viewer", "user": map[string]any{"subject": "alice"}, "resource": roleBindingFixtureResource},
+		changes: []resourceChange{
+			{name: "role changed", config: map[string]any{"role_id": "editor"}},
+			{name: "principal changed", config: map[string]any{"user": nil, "group": map[string]any{"claim_value": "admins"}}, replace: []string{"group", "user"}},
+			{name: "resource changed", config: map[string]any{"resource": map[string]any{"type": "TrustZone", "id": "tz-2"}}, replace: []string{"resource"}},
+		},
+	},
+	"cofide_connect_role_bindings_exclusive": {
+		state:  map[string]any{"id": "TrustZone/tz-1/viewer", "role_id": "viewer", "resource": roleBindingFixtureResource, "users": []any{"alice"}, "groups": []any{}},
+		config: map[string]any{"role_id": "viewer", "resource": roleBindingFixtureResource, "users": []any{"alice"}, "groups": []any{}},
+		changes: []resourceChange{
+			{name: "principals changed", config: map[string]any{"users": []any{"bob"}, "groups": []any{"admins"}}},
+			{name: "role changed", config: map[string]any{"role_id": "editor"}, replace: []string{"role_id"}},
+			{name: "resource changed", config: map[string]any{"resource": map[string]any{"type": "TrustZone", "id": "tz-2"}}, replace: []string{"resource"}},
+		},
+	},
+	"cofide_connect_trust_zone": {
+		state:  map[string]any{"id": "tz-1", "name": "tz", "org_id": "org-1", "trust_domain": "td", "is_management_zone": false},
+		config: map[string]any{"name": "tz", "trust_domain": "td"},
+		changes: []resourceChange{
+			{name: "renamed", config: map[string]any{"name": "renamed"}},
+			{name: "is_management_zone changed", config: map[string]any{"is_management_zone": true}, replace: []string{"is_management_zone"}},
+			{name: "org_id changed", config: map[string]any{"org_id": "org-2"}, replace: []string{"org_id"}},
+		},
+		stateOnly:          map[string]any{"deletion_protection": true, "cascade_delete": false, "adopt_existing": false, "retain_on_delete": false},
+		protectedByDefault: true,
+	},
+	"cofide_connect_trust_zone_exchange_policies": {
+		state:  map[string]any{"id": "tz-1", "trust_zone_id": "tz-1", "exchange_policies": []any{exchangePolicyFixture}},
+		config: map[string]any{"trust_zone_id": "tz-1", "exchange_policies": []any{map[string]any{"name": "deny-all", "action": "DENY"}}},
+		changes: []resourceChange{
+			{name: "trust_zone_id changed", config: map[string]any{"trust_zone_id": "tz-2"}, replace: []string{"trust_zone_id"}},
+		},
+	},
+	"cofide_connect_trust_zone_server": {
+		state:  map[string]any{"id": "tzs-1", "trust_zone_id": "tz-1", "cluster_id": "c-1", "kubernetes_namespace": "cofide", "kubernetes_service_account": "spire-server", "org_id": "org-1"},
+		config: map[string]any{"trust_zone_id": "tz-1", "cluster_id": "c-1"},
+		changes: []resourceChange{
+			{name: "helm_values changed", config: map[string]any{"helm_values": "replicaCount: 2"}, unknown: []string{"status"}},
+			{name: "trust_zone_id changed", config: map[string]any{"trust_zone_id": "tz-2"}, replace: []string{"trust_zone_id"}, unknown: []string{"status"}},
+			{name: "cluster_id changed", config: map[string]any{"cluster_id": "c-2"}, replace: []string{"cluster_id"}, unknown: []string{"status"}},
+			{name: "kubernetes_namespace changed", config: map[string]any{"kubernetes_namespace": "spire"}, replace: []string{"kubernetes_namespace"}, unknown: []string{"status"}},
+			{name: "kubernetes_service_account changed", config: map[string]any{"kubernetes_service_account": "server"}, replace: []string{"kubernetes_service_account"}, unknown: []string{"status"}},
+		},
+		stateOnly:          map[string]any{"deletion_protection": true, "retain_on_delete": false, "validate_helm_values": true},
+		protectedByDefault: true,
+	},
+	"cofide_connect_workload_identity": {
+		state: map[string]any{
+			"id":       "ap-1",
+			"name":     "app",
+			"org_id":   "org-1",
+			"static":   staticPolicyFixture,
+			"bindings": []any{map[string]any{"id": "apb-1", "trust_zone_id": "tz-1"}},
+		},
+		config: map[string]any{"name": "app", "static": staticPolicyFixture, "bindings": []any{map[string]any{"trust_zone_id": "tz-1"}}},
+		changes: []resourceChange{
+			{name: "renamed", config: map[string]any{"name": "renamed"}},
+			{name: "org_id changed", config: map[string]any{"org_id": "org-2"}, replace: []string{"org_id"}},
+		},
+	},
+}
+
+// withOverrides returns a copy of attributes overridden by overrides, where a
+// nil value removes the attribute.
+func withOverrides(attributes, overrides map[string]any) map[string]any {
+	result := make(map[string]any, len(attributes)+len(overrides))
+	for name, value := range attributes {
+		result[name] = value
+	}
+	for name, value := range overrides {
+		if value == nil {
+			delete(result, name)
+			continue
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// TestResourceFixtures asserts that every resource has a fixture, and that
+// the changes of each fixture cover every configurable attribute whose
+// change forces replacement.
+func TestResourceFixtures(t *testing.T) {
+	snapshot, err := schemacheck.Take(context.Background(), newTestProvider())
+	require.NoError(t, err)
+
+	for name, s := range snapshot.Resources {
+		fixture, ok := resourceFixtures[name]
+		if !assert.True(t, ok, "resource %s has no fixture in resourceFixtures", name) {
+			continue
+		}
+
+		covered := map[string]bool{}
+		for _, change := range fixture.changes {
+			for _, attribute := range change.replace {
+				covered[attribute] = true
+			}
+		}
+		for attribute, a := range s.Attributes {
+			if a.RequiresReplace && (a.Required || a.Optional) && !strings.Contains(attribute, ".") {
+				assert.True(t, covered[attribute], "no change to %s.%s forces replacement in resourceFixtures", name, attribute)
+			}
+		}
+	}
+}
+
+// TestPlanResourceChanges plans the changes of every resource fixture, and
+// checks that planning the unchanged configuration changes nothing.
+func TestPlanResourceChanges(t *testing.T) {
+	for _, name := range sortedKeys(resourceFixtures) {
+		fixture := resourceFixtures[name]
+		state := withOverrides(fixture.state, fixture.stateOnly)
+		tests := []providertest.PlanTest{{Name: "unchanged", State: state, Config: fixture.config}}
+		for _, change := range fixture.changes {
+			tests = append(tests, providertest.PlanTest{
+				Name:        change.name,
+				State:       state,
+				Config:      withOverrides(fixture.config, change.config),
+				WantReplace: change.replace,
+				WantUnknown: change.unknown,
+			})
+		}
+		t.Run(name, func(t *testing.T) {
+			providertest.RunPlanTests(t, name, tests)
+		})
+	}
+}
+
+// TestStateOnlyUpdates checks that changing each attribute of every resource
+// kept in Terraform state only updates the state and identity without
+// calling Cofide Connect.
+func TestStateOnlyUpdates(t *testing.T) {
+	for _, name := range sortedKeys(resourceFixtures) {
+		fixture := resourceFixtures[name]
+		for _, attribute := range sortedKeys(fixture.stateOnly) {
+			value, ok := fixture.stateOnly[attribute].(bool)
+			if !ok {
+				continue
+			}
+			t.Run(name+"/"+attribute, func(t *testing.T) {
+				state := withOverrides(fixture.state, fixture.stateOnly)
+				config := withOverrides(fixture.config, map[string]any{attribute: !value})
+				providertest.AssertStateOnlyUpdate(t, name, state, config)
+			})
+		}
+	}
+}
+
+// TestDeletionProtection checks that destroying a protected object fails
+// for every resource with deletion protection, including when state
+// predates the attribute for resources protected by default.
+func TestDeletionProtection(t *testing.T) {
+	for name, r := range providerResources(t) {
+		if _, ok := resourceSchema(t, r).Attributes["deletion_protection"]; !ok {
+			continue
+		}
+		fixture := resourceFixtures[name]
+		t.Run(name, func(t *testing.T) {
+			providertest.AssertDeletionProtected(t, name, withOverrides(fixture.state, map[string]any{"deletion_protection": true}))
+			if fixture.protectedByDefault {
+				providertest.AssertDeletionProtected(t, name, fixture.state)
+			}
+		})
+	}
+}
+
+// TestRetainOnDelete checks that destroying an object with retain_on_delete
+// only removes it from state, even while it is protected, for every resource
+// that can retain objects.
+func TestRetainOnDelete(t *testing.T) {
+	for name, r := range providerResources(t) {
+		attributes := resourceSchema(t, r).Attributes
+		if _, ok := attributes["retain_on_delete"]; !ok {
+			continue
+		}
+		state := withOverrides(resourceFixtures[name].state, map[string]any{"retain_on_delete": true})
+		if _, ok := attributes["deletion_protection"]; ok {
+			state["deletion_protection"] = true
+		}
+		t.Run(name, func(t *testing.T) {
+			providertest.AssertRetainedOnDelete(t, name, state)
+		})
+	}
+}
+
+// TestUpgradeStateFromReleasedVersion checks that state written by the
+// released schema version of every versioned resource is carried over
+// unchanged.
+func TestUpgradeStateFromReleasedVersion(t *testing.T) {
+	for name, r := range providerResources(t) {
+		if resourceSchema(t, r).Version == 0 {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			providertest.AssertUpgradedUnchanged(t, name, resourceFixtures[name].state)
+		})
+	}
+}
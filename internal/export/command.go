@@ -0,0 +1,124 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+
+	"github.com/cofide/terraform-provider-cofide/internal/client"
+)
+
+const usage = `Usage: terraform-provider-cofide export --org <name> [--trust-zone <name>]... [--out <file>]
+
+Writes Terraform configuration and import blocks for the Cofide Connect objects
+in an organization. The API token and Connect URL are read from the same
+environment variables and credentials file as the provider.
+
+Options:
+`
+
+// stringList is a flag.Value collecting each use of a repeatable flag.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// Main runs the export subcommand with the given command-line arguments,
+// which exclude the subcommand name. newProvider returns the provider whose
+// list resources are used to read objects.
+func Main(ctx context.Context, args []string, newProvider func() provider.Provider, version string, stdout, stderr io.Writer) error {
+	var (
+		opts               Options
+		out                string
+		connectURL         string
+		insecureSkipVerify bool
+	)
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&opts.Org, "org", "", "name of the organization to export (required)")
+	flags.Var((*stringList)(&opts.TrustZones), "trust-zone", "name of a trust zone to export; may be repeated (default: all trust zones)")
+	flags.StringVar(&out, "out", "", "file to write the configuration to (default: standard output)")
+	flags.StringVar(&connectURL, "connect-url", "", "Cofide Connect service URL (default: $COFIDE_CONNECT_URL)")
+	flags.BoolVar(&insecureSkipVerify, "insecure-skip-verify", false, "skip TLS certificate verification (default: $COFIDE_INSECURE_SKIP_VERIFY)")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	if opts.Org == "" {
+		flags.Usage()
+		return errors.New("--org is required")
+	}
+
+	var skipVerify *bool
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "insecure-skip-verify" {
+			skipVerify = &insecureSkipVerify
+		}
+	})
+
+	settings, err := client.ResolveSettings("", connectURL, skipVerify)
+	if err != nil {
+		fmt.Fprintf(stderr, "Warning: failed to read credentials file: %s\n", err)
+	}
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:   "cofide",
+		Output: stderr,
+		Level:  hclog.Warn,
+	})
+	clientSet, err := client.NewTLSClient(settings.ConnectURL, settings.APIToken, settings.InsecureSkipVerify, logger, version)
+	if err != nil {
+		return fmt.Errorf("failed to create TLS client: %w", err)
+	}
+
+	p, ok := newProvider().(provider.ProviderWithListResources)
+	if !ok {
+		return errors.New("provider does not support list resources")
+	}
+	exporter, err := New(ctx, p, clientSet)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		return exporter.Export(ctx, opts, stdout)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := exporter.Export(ctx, opts, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,343 @@
+// Package export generates Terraform configuration for the Cofide Connect
+// objects in an existing organization, so that they can be brought under
+// Terraform management with a single apply.
+//
+// Objects are read through the provider's list resources, so the generated
+// resource blocks use exactly the values a subsequent plan will read. IDs of
+// other exported objects are replaced with references to them, and each
+// resource block is preceded by a matching import block.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/cofide/terraform-provider-cofide/internal/hclgen"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
+)
+
+const (
+	organizationType      = "cofide_connect_organization"
+	trustZoneType         = "cofide_connect_trust_zone"
+	clusterType           = "cofide_connect_cluster"
+	trustZoneServerType   = "cofide_connect_trust_zone_server"
+	attestationPolicyType = "cofide_connect_attestation_policy"
+	apBindingType         = "cofide_connect_ap_binding"
+	federationType        = "cofide_connect_federation"
+	exchangePolicyType    = "cofide_connect_exchange_policy"
+	roleBindingType       = "cofide_connect_role_binding"
+)
+
+// Options configures an export.
+type Options struct {
+	// Org is the name of the organization to export.
+	Org string
+	// TrustZones limits the export to the trust zones with these names, and
+	// the objects within them. If empty, all trust zones are exported.
+	TrustZones []string
+}
+
+// Exporter exports Cofide Connect objects as Terraform configuration.
+type Exporter struct {
+	client        sdkclient.ClientSet
+	listResources map[string]list.ListResource
+}
+
+// object is an exported Cofide Connect object.
+type object struct {
+	typeName string
+	id       string
+	label    string
+	schema   schema.Schema
+	value    basetypes.ObjectValue
+}
+
+// New returns an Exporter that reads objects through the list resources of p
+// using client.
+func New(ctx context.Context, p provider.ProviderWithListResources, client sdkclient.ClientSet) (*Exporter, error) {
+	metadata := &provider.MetadataResponse{}
+	p.Metadata(ctx, provider.MetadataRequest{}, metadata)
+
+	e := &Exporter{
+		client:        client,
+		listResources: map[string]list.ListResource{},
+	}
+	for _, newListResource := range p.ListResources(ctx) {
+		l := newListResource()
+
+		resp := &resource.MetadataResponse{}
+		l.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: metadata.TypeName}, resp)
+
+		if c, ok := l.(list.ListResourceWithConfigure); ok {
+			configureResp := &resource.ConfigureResponse{}
+			c.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, configureResp)
+			if err := diagnosticsError(configureResp.Diagnostics); err != nil {
+				return nil, err
+			}
+		}
+		e.listResources[resp.TypeName] = l
+	}
+	return e, nil
+}
+
+// Export writes configuration for the objects selected by opts to w.
+func (e *Exporter) Export(ctx context.Context, opts Options, w io.Writer) error {
+	orgID, err := importid.OrganizationID(ctx, e.client, opts.Org)
+	if err != nil {
+		return err
+	}
+
+	trustZones, err := e.list(ctx, trustZoneType, map[string]string{"org_id": orgID})
+	if err != nil {
+		return err
+	}
+	if len(opts.TrustZones) > 0 {
+		trustZones, err = selectTrustZones(trustZones, opts.TrustZones, opts.Org)
+		if err != nil {
+			return err
+		}
+	}
+
+	var clusters, servers, federations, exchangePolicies, bindings []*object
+	for _, trustZone := range trustZones {
+		filter := map[string]string{"trust_zone_id": trustZone.id}
+		for _, l := range []struct {
+			typeName string
+			objects  *[]*object
+		}{
+			{clusterType, &clusters},
+			{trustZoneServerType, &servers},
+			{federationType, &federations},
+			{exchangePolicyType, &exchangePolicies},
+			{apBindingType, &bindings},
+		} {
+			objects, err := e.list(ctx, l.typeName, filter)
+			if err != nil {
+				return err
+			}
+			*l.objects = append(*l.objects, objects...)
+		}
+	}
+
+	policies, err := e.list(ctx, attestationPolicyType, map[string]string{"org_id": orgID})
+	if err != nil {
+		return err
+	}
+	if len(opts.TrustZones) > 0 {
+		// Only export the policies bound in the selected trust zones.
+		bound := map[string]bool{}
+		for _, binding := range bindings {
+			bound[binding.str("policy_id")] = true
+		}
+		policies = slices.DeleteFunc(policies, func(policy *object) bool {
+			return !bound[policy.id]
+		})
+	}
+
+	exported := map[string]bool{orgID: true}
+	for _, objects := range [][]*object{trustZones, clusters, servers, policies} {
+		for _, o := range objects {
+			exported[o.id] = true
+		}
+	}
+
+	roleBindings, err := e.list(ctx, roleBindingType, nil)
+	if err != nil {
+		return err
+	}
+	roleBindings = slices.DeleteFunc(roleBindings, func(binding *object) bool {
+		return !exported[binding.str("resource", "id")]
+	})
+
+	// Assign labels in dependency order, since the labels of some objects
+	// are derived from those of the objects they refer to.
+	labels := hclgen.NewLabeler()
+	orgLabel := labels.Assign(organizationType, opts.Org)
+	r := &renderer{references: map[string]string{
+		orgID: fmt.Sprintf("data.%s.%s.id", organizationType, orgLabel),
+	}}
+	labelsByID := map[string]string{orgID: orgLabel}
+	labelOf := func(id string) string {
+		if label, ok := labelsByID[id]; ok {
+			return label
+		}
+		return id
+	}
+
+	var objects []*object
+	add := func(items []*object, base func(*object) string) {
+		for _, o := range items {
+			o.label = labels.Assign(o.typeName, base(o))
+			labelsByID[o.id] = o.label
+			r.references[o.id] = fmt.Sprintf("%s.%s.id", o.typeName, o.label)
+			objects = append(objects, o)
+		}
+	}
+	add(trustZones, func(o *object) string { return o.str("name") })
+	add(clusters, func(o *object) string { return o.str("name") })
+	add(servers, func(o *object) string { return labelOf(o.str("cluster_id")) })
+	add(policies, func(o *object) string { return o.str("name") })
+	add(bindings, func(o *object) string {
+		return labelOf(o.str("policy_id")) + "_" + labelOf(o.str("trust_zone_id"))
+	})
+	add(federations, func(o *object) string {
+		return labelOf(o.str("trust_zone_id")) + "_to_" + labelOf(o.str("remote_trust_zone_id"))
+	})
+	add(exchangePolicies, func(o *object) string { return o.str("name") })
+	add(roleBindings, func(o *object) string {
+		principal := o.str("user", "subject")
+		if principal == "" {
+			principal = o.str("group", "claim_value")
+		}
+		return principal + "_" + labelOf(o.str("resource", "id"))
+	})
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "# Generated by terraform-provider-cofide export for organization %q.\n", opts.Org)
+	buf.WriteString("\n" + hclgen.Block("data", []string{organizationType, orgLabel}, []hclgen.Attribute{
+		{Name: "name", Value: hclgen.Quote(opts.Org)},
+	}))
+
+	for _, o := range objects {
+		buf.WriteString("\n" + hclgen.Block("import", nil, []hclgen.Attribute{
+			{Name: "to", Value: o.typeName + "." + o.label},
+			{Name: "id", Value: hclgen.Quote(o.id)},
+		}))
+		buf.WriteString("\n" + hclgen.Block("resource", []string{o.typeName, o.label}, r.body(o.value, o.schema.Attributes)))
+	}
+
+	_, err = io.WriteString(w, buf.String())
+	return err
+}
+
+// list returns the objects of a resource type that match filters, which are
+// the list resource's config attributes.
+func (e *Exporter) list(ctx context.Context, typeName string, filters map[string]string) ([]*object, error) {
+	l, ok := e.listResources[typeName]
+	if !ok {
+		return nil, fmt.Errorf("provider has no list resource for %s", typeName)
+	}
+	r, ok := l.(resource.ResourceWithIdentity)
+	if !ok {
+		return nil, fmt.Errorf("list resource %s is not a resource with identity", typeName)
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+	listSchemaResp := &list.ListResourceSchemaResponse{}
+	l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, listSchemaResp)
+
+	var diags diag.Diagnostics
+	diags.Append(schemaResp.Diagnostics...)
+	diags.Append(identityResp.Diagnostics...)
+	diags.Append(listSchemaResp.Diagnostics...)
+	if err := diagnosticsError(diags); err != nil {
+		return nil, err
+	}
+
+	configType := listSchemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	configValues := map[string]tftypes.Value{}
+	for name, attributeType := range configType.AttributeTypes {
+		if value, ok := filters[name]; ok {
+			configValues[name] = tftypes.NewValue(attributeType, value)
+		} else {
+			configValues[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	req := list.ListRequest{
+		Config: tfsdk.Config{
+			Schema: listSchemaResp.Schema,
+			Raw:    tftypes.NewValue(configType, configValues),
+		},
+		IncludeResource:        true,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}
+	stream := &list.ListResultsStream{}
+	l.List(ctx, req, stream)
+	if stream.Results == nil {
+		return nil, nil
+	}
+
+	var objects []*object
+	for result := range stream.Results {
+		if err := diagnosticsError(result.Diagnostics); err != nil {
+			return nil, err
+		}
+
+		value, err := schemaResp.Schema.Type().ValueFromTerraform(ctx, result.Resource.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s: %w", typeName, err)
+		}
+		o := &object{
+			typeName: typeName,
+			schema:   schemaResp.Schema,
+			value:    value.(basetypes.ObjectValue),
+		}
+		o.id = o.str("id")
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+// str returns the string attribute at path, or "" if it is null or missing.
+func (o *object) str(path ...string) string {
+	var value attr.Value = o.value
+	for _, name := range path {
+		obj, ok := value.(basetypes.ObjectValue)
+		if !ok {
+			return ""
+		}
+		value = obj.Attributes()[name]
+	}
+
+	s, ok := value.(basetypes.StringValue)
+	if !ok {
+		return ""
+	}
+	return s.ValueString()
+}
+
+// selectTrustZones returns the trust zones with the given names, in the
+// order given.
+func selectTrustZones(trustZones []*object, names []string, org string) ([]*object, error) {
+	var selected []*object
+	for _, name := range names {
+		i := slices.IndexFunc(trustZones, func(o *object) bool { return o.str("name") == name })
+		if i < 0 {
+			return nil, fmt.Errorf("no trust zone named %q found in organization %q", name, org)
+		}
+		if !slices.Contains(selected, trustZones[i]) {
+			selected = append(selected, trustZones[i])
+		}
+	}
+	return selected, nil
+}
+
+// diagnosticsError returns the error diagnostics as an error, or nil if there
+// are none.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+package export
+
+import (
+	"context"
+	"testing"
+
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/cofide/terraform-provider-cofide/internal"
+	"github.com/cofide/terraform-provider-cofide/internal/hclgen"
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+)
+
+// TestExportWithholdsHelmValues checks that Helm values, which may include
+// values set through write-only sensitive attributes, are not exported.
+func TestExportWithholdsHelmValues(t *testing.T) {
+	const secret = "hunter2"
+	values, err := structpb.NewStruct(map[string]any{"database": map[string]any{"password": secret}})
+	require.NoError(t, err)
+	client := &providertest.Client{
+		Clusters: []*clusterpb.Cluster{{
+			Id:              proto.String("c-1"),
+			Name:            proto.String("cluster"),
+			TrustZoneId:     proto.String("tz-1"),
+			ExtraHelmValues: values,
+		}},
+		TrustZoneServers: []*trustzoneserverpb.TrustZoneServer{{
+			Id:          "tzs-1",
+			TrustZoneId: "tz-1",
+			ClusterId:   "c-1",
+			HelmValues:  values,
+		}},
+	}
+
+	ctx := context.Background()
+	p, ok := internal.NewProvider("test")().(provider.ProviderWithListResources)
+	require.True(t, ok)
+	e, err := New(ctx, p, client)
+	require.NoError(t, err)
+
+	r := &renderer{references: map[string]string{}}
+	for _, typeName := range []string{clusterType, trustZoneServerType} {
+		objects, err := e.list(ctx, typeName, map[string]string{"trust_zone_id": "tz-1"})
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+
+		body := hclgen.FormatBody(r.body(objects[0].value, objects[0].schema.Attributes), "  ")
+		assert.NotContains(t, body, secret, "%s exports a sensitive value", typeName)
+		assert.NotRegexp(t, `(?m)^\s*(extra_)?helm_values`, body, "%s exports Helm values", typeName)
+	}
+}
@@ -0,0 +1,164 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/cofide/terraform-provider-cofide/internal/hclgen"
+)
+
+// renderer renders resource values as HCL expressions.
+type renderer struct {
+	// references maps object IDs to the HCL expressions that refer to them.
+	references map[string]string
+}
+
+// body renders the configurable attributes of an object value. Attributes
+// that are null, computed-only, sensitive or write-only are omitted.
+func (r *renderer) body(value basetypes.ObjectValue, attributes map[string]schema.Attribute) []hclgen.Attribute {
+	values := value.Attributes()
+
+	var result []hclgen.Attribute
+	for _, name := range hclgen.SortedKeys(values) {
+		var nested map[string]schema.Attribute
+		if attributes != nil {
+			a, ok := attributes[name]
+			if !ok || !isConfigurable(a) {
+				continue
+			}
+			nested = nestedAttributes(a)
+		}
+
+		rendered, ok := r.value(name, values[name], nested)
+		if !ok {
+			continue
+		}
+		result = append(result, hclgen.Attribute{Name: name, Value: rendered})
+	}
+	return result
+}
+
+// value renders a single value as an HCL expression. name is the name of the
+// attribute holding the value, and nested the schema of its nested
+// attributes, if any. The second return value is false for null and unknown
+// values, which should be omitted.
+func (r *renderer) value(name string, value attr.Value, nested map[string]schema.Attribute) (string, bool) {
+	if value == nil || value.IsNull() || value.IsUnknown() {
+		return "", false
+	}
+
+	// Render custom string types, such as Helm values, as plain strings.
+	if v, ok := value.(basetypes.StringValuable); ok {
+		if s, diags := v.ToStringValue(context.Background()); !diags.HasError() {
+			value = s
+		}
+	}
+
+	switch v := value.(type) {
+	case basetypes.StringValue:
+		s := v.ValueString()
+		if ref, ok := r.references[s]; ok && isIDAttribute(name) {
+			return ref, true
+		}
+		if strings.Contains(name, "helm_values") {
+			if encoded, ok := yamlencode(s); ok {
+				return encoded, true
+			}
+		}
+		return hclgen.Quote(s), true
+	case basetypes.BoolValue:
+		return strconv.FormatBool(v.ValueBool()), true
+	case basetypes.Int64Value:
+		return strconv.FormatInt(v.ValueInt64(), 10), true
+	case basetypes.Int32Value:
+		return strconv.FormatInt(int64(v.ValueInt32()), 10), true
+	case basetypes.Float64Value:
+		return strconv.FormatFloat(v.ValueFloat64(), 'g', -1, 64), true
+	case basetypes.NumberValue:
+		return v.ValueBigFloat().Text('g', -1), true
+	case basetypes.ListValue:
+		return r.sequence(name, v.Elements(), nested), true
+	case basetypes.SetValue:
+		return r.sequence(name, v.Elements(), nested), true
+	case basetypes.TupleValue:
+		return r.sequence(name, v.Elements(), nested), true
+	case basetypes.MapValue:
+		elements := v.Elements()
+		var attributes []hclgen.Attribute
+		for _, key := range hclgen.SortedKeys(elements) {
+			if rendered, ok := r.value(name, elements[key], nested); ok {
+				attributes = append(attributes, hclgen.Attribute{Name: hclgen.ObjectKey(key), Value: rendered})
+			}
+		}
+		return hclgen.Object(attributes), true
+	case basetypes.ObjectValue:
+		return hclgen.Object(r.body(v, nested)), true
+	default:
+		return hclgen.Quote(value.String()), true
+	}
+}
+
+// sequence renders a list, set or tuple, inline if it is short enough.
+func (r *renderer) sequence(name string, elements []attr.Value, nested map[string]schema.Attribute) string {
+	rendered := make([]string, 0, len(elements))
+	for _, element := range elements {
+		value, ok := r.value(name, element, nested)
+		if !ok {
+			value = "null"
+		}
+		rendered = append(rendered, value)
+	}
+	return hclgen.List(rendered)
+}
+
+// yamlencode renders Helm values, which the API returns as a JSON object, as
+// a yamlencode call, as the Helm values attributes recommend. It returns
+// false if s is not a JSON object.
+func yamlencode(s string) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewBufferString(s))
+	decoder.UseNumber()
+
+	var value map[string]any
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+	return "yamlencode(" + hclgen.Value(value) + ")", true
+}
+
+// isConfigurable returns true if an attribute can be set in configuration
+// and its value is returned by the API.
+func isConfigurable(a schema.Attribute) bool {
+	if !a.IsRequired() && !a.IsOptional() {
+		return false
+	}
+	return !a.IsSensitive() && !a.IsWriteOnly()
+}
+
+// isIDAttribute returns true if an attribute holds the ID of another object,
+// and so should reference it rather than repeat the ID.
+func isIDAttribute(name string) bool {
+	return name == "id" || strings.HasSuffix(name, "_id")
+}
+
+// nestedAttributes returns the schema of the attributes nested in a, or nil
+// if a is not a nested attribute.
+func nestedAttributes(a schema.Attribute) map[string]schema.Attribute {
+	switch a := a.(type) {
+	case schema.SingleNestedAttribute:
+		return a.Attributes
+	case schema.ListNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.MapNestedAttribute:
+		return a.NestedObject.Attributes
+	}
+	return nil
+}
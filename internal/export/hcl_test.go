@@ -0,0 +1,81 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cofide/terraform-provider-cofide/internal/hclgen"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+)
+
+func TestRendererBody(t *testing.T) {
+	attributes := map[string]schema.Attribute{
+		"id":            schema.StringAttribute{Computed: true},
+		"name":          schema.StringAttribute{Required: true},
+		"trust_zone_id": schema.StringAttribute{Required: true},
+		"remote_id":     schema.StringAttribute{Optional: true},
+		"token":         schema.StringAttribute{Optional: true, Sensitive: true},
+		"helm_values":   schema.StringAttribute{Optional: true, CustomType: util.HelmValuesType{}},
+		"labels":        schema.ListAttribute{Optional: true, ElementType: types.StringType},
+		"settings": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"enabled": schema.BoolAttribute{Optional: true},
+				"status":  schema.StringAttribute{Computed: true},
+			},
+		},
+	}
+
+	settingsType := map[string]attr.Type{"enabled": types.BoolType, "status": types.StringType}
+	value := types.ObjectValueMust(
+		map[string]attr.Type{
+			"id":            types.StringType,
+			"name":          types.StringType,
+			"trust_zone_id": types.StringType,
+			"remote_id":     types.StringType,
+			"token":         types.StringType,
+			"helm_values":   util.HelmValuesType{},
+			"labels":        types.ListType{ElemType: types.StringType},
+			"settings":      types.ObjectType{AttrTypes: settingsType},
+		},
+		map[string]attr.Value{
+			"id":            types.StringValue("cluster-id"),
+			"name":          types.StringValue("a"),
+			"trust_zone_id": types.StringValue("tz-id"),
+			"remote_id":     types.StringValue("unknown-id"),
+			"token":         types.StringValue("secret"),
+			"helm_values":   util.NewHelmValuesValue(`{"spire-server":{"replicas":2},"global":{"enabled":true}}`),
+			"labels":        types.ListValueMust(types.StringType, []attr.Value{types.StringValue("x"), types.StringValue("y")}),
+			"settings": types.ObjectValueMust(settingsType, map[string]attr.Value{
+				"enabled": types.BoolValue(true),
+				"status":  types.StringValue("ready"),
+			}),
+		},
+	)
+
+	r := &renderer{references: map[string]string{
+		"tz-id": "cofide_connect_trust_zone.a.id",
+	}}
+
+	want := `  helm_values = yamlencode({
+    global = {
+      enabled = true
+    }
+    spire-server = {
+      replicas = 2
+    }
+  })
+  labels    = ["x", "y"]
+  name      = "a"
+  remote_id = "unknown-id"
+  settings = {
+    enabled = true
+  }
+  trust_zone_id = cofide_connect_trust_zone.a.id
+`
+	assert.Equal(t, want, hclgen.FormatBody(r.body(value, attributes), "  "))
+}
@@ -0,0 +1,185 @@
+// Package hclgen generates Terraform configuration formatted as `terraform
+// fmt` would format it.
+package hclgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxInlineListLength is the length above which lists are written one
+// element per line.
+const maxInlineListLength = 80
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// Attribute is a single `name = value` line of an HCL body. The value is an
+// HCL expression and may span several lines, in which case lines after the
+// first are indented relative to the attribute.
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+// Block renders a block such as `resource "type" "label" { ... }`.
+func Block(blockType string, labels []string, attributes []Attribute) string {
+	header := blockType
+	for _, label := range labels {
+		header += " " + Quote(label)
+	}
+	return header + " {\n" + FormatBody(attributes, "  ") + "}\n"
+}
+
+// FormatBody formats attributes as an HCL body indented by indent, aligning
+// the equals signs of consecutive single-line attributes as `terraform fmt`
+// does. An attribute with a multi-line value is never aligned with its
+// neighbours.
+func FormatBody(attributes []Attribute, indent string) string {
+	var buf strings.Builder
+
+	for start := 0; start < len(attributes); {
+		end := start
+		if !isMultiline(attributes[start]) {
+			for end < len(attributes)-1 && !isMultiline(attributes[end+1]) {
+				end++
+			}
+		}
+
+		width := 0
+		for _, a := range attributes[start : end+1] {
+			width = max(width, len(a.Name))
+		}
+		for _, a := range attributes[start : end+1] {
+			value := strings.ReplaceAll(a.Value, "\n", "\n"+indent)
+			fmt.Fprintf(&buf, "%s%-*s = %s\n", indent, width, a.Name, value)
+		}
+
+		start = end + 1
+	}
+
+	return buf.String()
+}
+
+func isMultiline(a Attribute) bool {
+	return strings.Contains(a.Value, "\n")
+}
+
+// Object renders an object constructor expression.
+func Object(attributes []Attribute) string {
+	if len(attributes) == 0 {
+		return "{}"
+	}
+	return "{\n" + FormatBody(attributes, "  ") + "}"
+}
+
+// List renders a tuple constructor expression from rendered elements, on one
+// line if it is short enough.
+func List(elements []string) string {
+	multiline := false
+	for _, element := range elements {
+		multiline = multiline || strings.Contains(element, "\n")
+	}
+
+	inline := "[" + strings.Join(elements, ", ") + "]"
+	if !multiline && len(inline) <= maxInlineListLength {
+		return inline
+	}
+
+	var buf strings.Builder
+	buf.WriteString("[\n")
+	for _, element := range elements {
+		buf.WriteString("  " + strings.ReplaceAll(element, "\n", "\n  ") + ",\n")
+	}
+	buf.WriteString("]")
+	return buf.String()
+}
+
+// Value renders a Go value decoded from JSON or YAML as an HCL expression.
+// Maps become objects with sorted keys.
+func Value(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		var attributes []Attribute
+		for _, key := range SortedKeys(v) {
+			attributes = append(attributes, Attribute{Name: ObjectKey(key), Value: Value(v[key])})
+		}
+		return Object(attributes)
+	case []any:
+		elements := make([]string, 0, len(v))
+		for _, element := range v {
+			elements = append(elements, Value(element))
+		}
+		return List(elements)
+	case string:
+		return Quote(v)
+	case json.Number:
+		return v.String()
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return "null"
+	default:
+		return Quote(fmt.Sprint(v))
+	}
+}
+
+// Quote returns s as an HCL quoted string literal. Besides the usual escapes,
+// template sequences are escaped so that they are not interpolated.
+func Quote(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for i, c := range s {
+		switch {
+		case c == '"':
+			buf.WriteString(`\"`)
+		case c == '\\':
+			buf.WriteString(`\\`)
+		case c == '\n':
+			buf.WriteString(`\n`)
+		case c == '\r':
+			buf.WriteString(`\r`)
+		case c == '\t':
+			buf.WriteString(`\t`)
+		case (c == '$' || c == '%') && strings.HasPrefix(s[i+1:], "{"):
+			buf.WriteRune(c)
+			buf.WriteRune(c)
+		case c < 0x20:
+			fmt.Fprintf(&buf, `\u%04x`, c)
+		default:
+			buf.WriteRune(c)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// ObjectKey returns key as an object constructor key, quoting it unless it
+// is a valid identifier.
+func ObjectKey(key string) string {
+	if identifierPattern.MatchString(key) {
+		return key
+	}
+	return Quote(key)
+}
+
+// SortedKeys returns the keys of m in sorted order.
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,93 @@
+package hclgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "example", want: `"example"`},
+		{name: "quotes and backslashes", in: `a "b" \c`, want: `"a \"b\" \\c"`},
+		{name: "newline and tab", in: "a\nb\tc", want: `"a\nb\tc"`},
+		{name: "interpolation", in: "${var.x}", want: `"$${var.x}"`},
+		{name: "template directive", in: "%{if}", want: `"%%{if}"`},
+		{name: "lone dollar", in: "$5 and 100%", want: `"$5 and 100%"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Quote(tt.in))
+		})
+	}
+}
+
+func TestFormatBody(t *testing.T) {
+	got := FormatBody([]Attribute{
+		{Name: "name", Value: `"a"`},
+		{Name: "trust_zone_id", Value: "cofide_connect_trust_zone.a.id"},
+		{Name: "trust_provider", Value: "{\n  kind = \"kubernetes\"\n}"},
+		{Name: "profile", Value: `"kubernetes"`},
+	}, "  ")
+
+	want := `  name          = "a"
+  trust_zone_id = cofide_connect_trust_zone.a.id
+  trust_provider = {
+    kind = "kubernetes"
+  }
+  profile = "kubernetes"
+`
+	assert.Equal(t, want, got)
+}
+
+func TestValue(t *testing.T) {
+	var values map[string]any
+	err := yaml.Unmarshal([]byte(`
+global:
+  spire:
+    clusterName: local1
+    trustDomain: td1
+spire-server:
+  replicaCount: 2
+  ratio: 0.5
+  enabled: true
+  caKeyType: null
+  federation:
+    ingress:
+      hosts: [a.example.com, b.example.com]
+`), &values)
+	assert.NoError(t, err)
+
+	want := `{
+  global = {
+    spire = {
+      clusterName = "local1"
+      trustDomain = "td1"
+    }
+  }
+  spire-server = {
+    caKeyType = null
+    enabled   = true
+    federation = {
+      ingress = {
+        hosts = ["a.example.com", "b.example.com"]
+      }
+    }
+    ratio        = 0.5
+    replicaCount = 2
+  }
+}`
+	assert.Equal(t, want, Value(values))
+}
+
+func TestList(t *testing.T) {
+	assert.Equal(t, "[]", List(nil))
+	assert.Equal(t, `["a", "b"]`, List([]string{`"a"`, `"b"`}))
+	assert.Equal(t, "[\n  {\n    a = 1\n  },\n]", List([]string{"{\n  a = 1\n}"}))
+}
@@ -0,0 +1,57 @@
+package hclgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Labeler assigns unique Terraform resource labels per resource type.
+type Labeler struct {
+	used map[string]map[string]bool
+}
+
+func NewLabeler() *Labeler {
+	return &Labeler{used: map[string]map[string]bool{}}
+}
+
+// Assign returns a label for an object of the given resource type derived
+// from base, adding a numeric suffix if the label is already in use.
+func (l *Labeler) Assign(typeName, base string) string {
+	if l.used[typeName] == nil {
+		l.used[typeName] = map[string]bool{}
+	}
+
+	label := SanitizeLabel(base)
+	candidate := label
+	for i := 2; l.used[typeName][candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", label, i)
+	}
+	l.used[typeName][candidate] = true
+	return candidate
+}
+
+// SanitizeLabel converts s to a valid, lower-case Terraform identifier,
+// replacing runs of other characters with an underscore.
+func SanitizeLabel(s string) string {
+	var buf strings.Builder
+	lastUnderscore := false
+	for _, c := range strings.ToLower(s) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			buf.WriteRune(c)
+			lastUnderscore = false
+		case !lastUnderscore:
+			buf.WriteByte('_')
+			lastUnderscore = true
+		}
+	}
+
+	label := strings.Trim(buf.String(), "_")
+	if label == "" {
+		return "this"
+	}
+	if label[0] >= '0' && label[0] <= '9' {
+		label = "_" + label
+	}
+	return label
+}
@@ -0,0 +1,37 @@
+package hclgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeLabel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "production", want: "production"},
+		{in: "Production-EU", want: "production_eu"},
+		{in: "user@example.com_tz", want: "user_example_com_tz"},
+		{in: "  spaced  out  ", want: "spaced_out"},
+		{in: "1st-zone", want: "_1st_zone"},
+		{in: "---", want: "this"},
+		{in: "", want: "this"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, SanitizeLabel(tt.in))
+		})
+	}
+}
+
+func TestLabelerAssign(t *testing.T) {
+	l := NewLabeler()
+
+	assert.Equal(t, "prod", l.Assign("cofide_connect_trust_zone", "prod"))
+	assert.Equal(t, "prod_2", l.Assign("cofide_connect_trust_zone", "Prod"))
+	assert.Equal(t, "prod_3", l.Assign("cofide_connect_trust_zone", "prod"))
+	assert.Equal(t, "prod", l.Assign("cofide_connect_cluster", "prod"), "labels are unique per resource type")
+}
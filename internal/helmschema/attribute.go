@@ -0,0 +1,111 @@
+package helmschema
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ValidateHelmValuesAttribute returns the validate_helm_values attribute,
+// which opts a resource out of validating its Helm values. The attribute is
+// kept in Terraform state only.
+func ValidateHelmValuesAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether Helm values are checked against the values schema of the supported chart versions when planning, so that unknown keys and values of the wrong type are reported. Set it to false for values the provider's copy of the schema does not yet describe. Changing it does not change anything in Cofide Connect. Defaults to true.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(true),
+	}
+}
+
+// Enabled returns whether validate_helm_values enables validation, which it
+// does unless configured as false. Validation waits until it is known.
+func Enabled(validateHelmValues types.Bool) bool {
+	if validateHelmValues.IsUnknown() {
+		return false
+	}
+	return validateHelmValues.IsNull() || validateHelmValues.ValueBool()
+}
+
+// Attributes are the paths of the attributes Helm values may be configured
+// in.
+type Attributes struct {
+	Document path.Path
+	Object   path.Path
+	Layers   path.Path
+}
+
+// ValidateForms reports Helm values configured in forms that chart does not
+// accept, against the attribute they are configured in. Values that are not
+// yet known are validated once they are.
+func ValidateForms(chart Chart, forms util.HelmValuesForms, attributes Attributes) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !forms.Document.IsNull() && !forms.Document.IsUnknown() {
+		values, err := util.ParseHelmValues(forms.Document.ValueString())
+		if err != nil {
+			diags.AddAttributeError(attributes.Document, "Invalid Helm values", err.Error())
+		} else {
+			diags.Append(validateValues(chart, values, attributes.Document, false)...)
+		}
+	}
+
+	if !forms.Object.IsNull() {
+		// Objects that cannot be converted are reported by the attribute's
+		// type.
+		if values, err := util.HelmValuesObjectToProto(forms.Object); err == nil {
+			diags.Append(validateValues(chart, values, attributes.Object, true)...)
+		}
+	}
+
+	if !forms.Layers.IsNull() {
+		values, err := util.HelmValuesLayersToProto(forms.Layers)
+		switch {
+		case errors.Is(err, util.ErrUnknownHelmValue):
+		case err != nil:
+			diags.AddAttributeError(attributes.Layers, "Invalid Helm values", err.Error())
+		default:
+			diags.Append(validateValues(chart, values, attributes.Layers, false)...)
+		}
+	}
+	return diags
+}
+
+// validateValues reports each violation against attribute. If nested is
+// true, the attribute holds the values as a Terraform object, and each
+// violation is reported against the value it concerns.
+func validateValues(chart Chart, values *structpb.Struct, attribute path.Path, nested bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	violations, err := Validate(chart, values.AsMap())
+	if err != nil {
+		diags.AddError("Error validating Helm values", err.Error())
+		return diags
+	}
+
+	for _, violation := range violations {
+		valuePath := attribute
+		if nested {
+			for _, step := range violation.Path {
+				if step.IsIndex {
+					valuePath = valuePath.AtListIndex(step.Index)
+				} else {
+					valuePath = valuePath.AtName(step.Key)
+				}
+			}
+		}
+		diags.AddAttributeError(
+			valuePath,
+			"Invalid Helm value",
+			fmt.Sprintf("%s. The values schema of the %s chart does not accept this value; set validate_helm_values to false if the chart version in use does.", violation, chart),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,316 @@
+// Package helmschema validates Helm values against the values schemas of the
+// chart versions supported by the provider, so that typos in values keys are
+// caught when planning rather than ignored by the chart.
+//
+// The schemas are embedded from schemas/<chart>/<chart version>.json, which
+// tools/helmschemas extracts from the values.schema.json of each chart
+// archive. schemas/sources.json records the archive and digest of each file.
+// Only type, properties, additionalProperties, items and enum are checked;
+// other JSON Schema keywords are ignored.
+package helmschema
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:embed schemas
+var schemaFiles embed.FS
+
+// Chart is a Helm chart whose values can be validated.
+type Chart string
+
+const (
+	// CofideSPIREChart is the chart installed on clusters.
+	CofideSPIREChart Chart = "cofide-spire"
+	// SPIREServerChart is the chart installed for trust zone servers.
+	SPIREServerChart Chart = "spire-server"
+)
+
+// ChartVersions lists the versions of each chart whose values schemas are
+// embedded, newest first.
+var ChartVersions = map[Chart][]string{
+	CofideSPIREChart: {"0.5"},
+	SPIREServerChart: {"0.5"},
+}
+
+// SchemaFile returns the name of the file holding the values schema of a
+// chart version, relative to the schemas directory.
+func SchemaFile(chart Chart, version string) string {
+	return fmt.Sprintf("%s/%s.json", chart, version)
+}
+
+// SourcesFile is the name of the file recording the source of each schema,
+// relative to the schemas directory.
+const SourcesFile = "sources.json"
+
+// SchemaSource records where the values schema of a chart version was taken
+// from.
+type SchemaSource struct {
+	Chart        Chart  `json:"chart"`
+	ChartVersion string `json:"chart_version"`
+	// Source is the chart archive the schema was extracted from.
+	Source string `json:"source"`
+	// SHA256 is the hex-encoded SHA-256 digest of the schema file.
+	SHA256 string `json:"sha256"`
+}
+
+// Digest returns the hex-encoded SHA-256 digest of a schema file.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// SchemaSources returns the recorded sources of the embedded schemas, keyed
+// by schema file.
+func SchemaSources() (map[string]SchemaSource, error) {
+	data, err := schemaFiles.ReadFile("schemas/" + SourcesFile)
+	if err != nil {
+		return nil, err
+	}
+	var sources map[string]SchemaSource
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", SourcesFile, err)
+	}
+	return sources, nil
+}
+
+// Schema is a JSON Schema describing Helm values.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Additional        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+}
+
+// Additional is the additionalProperties keyword of a schema, which is either
+// a boolean or a schema for the values of keys not listed in properties.
+type Additional struct {
+	Allowed bool
+	Schema  *Schema
+}
+
+func (a *Additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Allowed); err == nil {
+		return nil
+	}
+	a.Allowed = true
+	return json.Unmarshal(data, &a.Schema)
+}
+
+// loadSchemas parses the embedded schemas, keyed by chart and then version.
+var loadSchemas = sync.OnceValues(func() (map[Chart]map[string]*Schema, error) {
+	schemas := make(map[Chart]map[string]*Schema, len(ChartVersions))
+	for chart, versions := range ChartVersions {
+		schemas[chart] = make(map[string]*Schema, len(versions))
+		for _, version := range versions {
+			data, err := schemaFiles.ReadFile("schemas/" + SchemaFile(chart, version))
+			if err != nil {
+				return nil, err
+			}
+			var schema Schema
+			if err := json.Unmarshal(data, &schema); err != nil {
+				return nil, fmt.Errorf("invalid values schema for %s chart version %s: %w", chart, version, err)
+			}
+			schemas[chart][version] = &schema
+		}
+	}
+	return schemas, nil
+})
+
+// PathStep is a step in the path to a Helm value: a key for a map, or an
+// index for a list.
+type PathStep struct {
+	Key   string
+	Index int
+	// IsIndex is true if the step is Index into a list rather than Key.
+	IsIndex bool
+}
+
+// Violation is a Helm value the chart does not accept.
+type Violation struct {
+	Path    []PathStep
+	Message string
+}
+
+// PathString returns the path to the value as it would be written in YAML
+// paths, such as `spire-server.tolerations[0]`.
+func (v Violation) PathString() string {
+	var b strings.Builder
+	for _, step := range v.Path {
+		if step.IsIndex {
+			fmt.Fprintf(&b, "[%d]", step.Index)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(step.Key)
+	}
+	return b.String()
+}
+
+func (v Violation) Error() string {
+	if len(v.Path) == 0 {
+		return v.Message
+	}
+	return fmt.Sprintf("%s: %s", v.PathString(), v.Message)
+}
+
+// Validate returns the values chart does not accept. Values accepted by any
+// supported version of the chart are valid; otherwise the violations against
+// the newest version are returned.
+func Validate(chart Chart, values map[string]any) ([]Violation, error) {
+	schemas, err := loadSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	var newest []Violation
+	for i, version := range ChartVersions[chart] {
+		violations := schemas[chart][version].validate(nil, values)
+		if len(violations) == 0 {
+			return nil, nil
+		}
+		if i == 0 {
+			newest = violations
+		}
+	}
+	return newest, nil
+}
+
+// validate returns the violations of value, found at path, against s. A null
+// value is always valid, as Helm reads it as removing the chart's default.
+func (s *Schema) validate(path []PathStep, value any) []Violation {
+	if value == nil {
+		return nil
+	}
+
+	if s.Type != "" && !hasType(value, s.Type) {
+		return []Violation{{Path: path, Message: fmt.Sprintf("expected %s, got %s", s.Type, typeName(value))}}
+	}
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, value) {
+		return []Violation{{Path: path, Message: fmt.Sprintf("must be one of %s", enumString(s.Enum))}}
+	}
+
+	var violations []Violation
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			keyPath := append(slices.Clone(path), PathStep{Key: key})
+			if property, ok := s.Properties[key]; ok {
+				violations = append(violations, property.validate(keyPath, v[key])...)
+				continue
+			}
+			switch {
+			case s.AdditionalProperties == nil:
+			case s.AdditionalProperties.Schema != nil:
+				violations = append(violations, s.AdditionalProperties.Schema.validate(keyPath, v[key])...)
+			case !s.AdditionalProperties.Allowed:
+				violations = append(violations, Violation{Path: keyPath, Message: "unknown key" + suggestion(key, s.Properties)})
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				violations = append(violations, s.Items.validate(append(slices.Clone(path), PathStep{Index: i, IsIndex: true}), item)...)
+			}
+		}
+	}
+	return violations
+}
+
+// hasType returns whether value, as decoded from YAML or JSON, is of the JSON
+// Schema type t.
+func hasType(value any, t string) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		return t == "object"
+	case []any:
+		return t == "array"
+	case string:
+		return t == "string"
+	case bool:
+		return t == "boolean"
+	case float64:
+		return t == "number" || (t == "integer" && v == math.Trunc(v))
+	default:
+		return false
+	}
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func enumString(enum []any) string {
+	values := make([]string, 0, len(enum))
+	for _, value := range enum {
+		values = append(values, fmt.Sprintf("%q", fmt.Sprint(value)))
+	}
+	return strings.Join(values, ", ")
+}
+
+// suggestion returns a hint naming the known key closest to key, if it is
+// close enough to be a likely typo.
+func suggestion(key string, properties map[string]*Schema) string {
+	best, bestDistance := "", 3
+	for property := range properties {
+		if d := distance(strings.ToLower(key), strings.ToLower(property)); d < bestDistance || (d == bestDistance && best != "" && property < best) {
+			best, bestDistance = property, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
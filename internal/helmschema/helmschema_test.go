@@ -0,0 +1,154 @@
+package helmschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemasLoad(t *testing.T) {
+	schemas, err := loadSchemas()
+	require.NoError(t, err)
+	for chart, versions := range ChartVersions {
+		for _, version := range versions {
+			assert.Contains(t, schemas[chart], version)
+		}
+	}
+}
+
+// TestSchemaSources checks that the source of every embedded schema is
+// recorded, and that the schema is the file extracted from it.
+func TestSchemaSources(t *testing.T) {
+	sources, err := SchemaSources()
+	require.NoError(t, err)
+
+	for chart, versions := range ChartVersions {
+		for _, version := range versions {
+			file := SchemaFile(chart, version)
+			source, ok := sources[file]
+			if !assert.True(t, ok, "no source recorded for %s in %s", file, SourcesFile) {
+				continue
+			}
+			assert.Equal(t, chart, source.Chart)
+			assert.Equal(t, version, source.ChartVersion)
+			assert.NotEmpty(t, source.Source)
+
+			data, err := schemaFiles.ReadFile("schemas/" + file)
+			require.NoError(t, err)
+			assert.Equal(t, source.SHA256, Digest(data), "%s differs from the file extracted from %s; import it again with tools/helmschemas", file, source.Source)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		chart  Chart
+		values map[string]any
+		want   []string
+	}{
+		{
+			name:   "empty",
+			values: map[string]any{},
+		},
+		{
+			name: "valid",
+			values: map[string]any{
+				"global":       map[string]any{"spire": map[string]any{"trustDomain": "example.org"}},
+				"spire-server": map[string]any{"replicaCount": float64(3), "logLevel": "debug", "tolerations": []any{map[string]any{"key": "a"}}},
+				"spire-agent":  map[string]any{"someNewValue": true},
+			},
+		},
+		{
+			name:   "null removes a default",
+			values: map[string]any{"spire-server": map[string]any{"nodeSelector": nil}},
+		},
+		{
+			name:   "unknown top-level key",
+			values: map[string]any{"spire-sever": map[string]any{}},
+			want:   []string{`spire-sever: unknown key (did you mean "spire-server"?)`},
+		},
+		{
+			name:   "unknown key without a close match",
+			values: map[string]any{"spire-server": map[string]any{"banana": true}},
+			want:   []string{"spire-server.banana: unknown key"},
+		},
+		{
+			name: "wrong types",
+			values: map[string]any{
+				"spire-server": map[string]any{"replicaCount": 1.5, "tolerations": "none"},
+				"global":       "shared",
+			},
+			want: []string{
+				"global: expected object, got string",
+				"spire-server.replicaCount: expected integer, got number",
+				"spire-server.tolerations: expected array, got string",
+			},
+		},
+		{
+			name:   "spire-server values at the root",
+			chart:  SPIREServerChart,
+			values: map[string]any{"replicaCount": float64(2), "logLevel": "info", "dataStore": map[string]any{}},
+		},
+		{
+			name:   "spire-server unknown key",
+			chart:  SPIREServerChart,
+			values: map[string]any{"replicaCont": float64(2)},
+			want:   []string{`replicaCont: unknown key (did you mean "replicaCount"?)`},
+		},
+		{
+			name:   "not in enum",
+			values: map[string]any{"spire-server": map[string]any{"image": map[string]any{"pullPolicy": "Sometimes"}}},
+			want:   []string{`spire-server.image.pullPolicy: must be one of "Always", "IfNotPresent", "Never"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := tt.chart
+			if chart == "" {
+				chart = CofideSPIREChart
+			}
+			violations, err := Validate(chart, tt.values)
+			require.NoError(t, err)
+
+			var got []string
+			for _, violation := range violations {
+				got = append(got, violation.Error())
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"items": {Type: "array", Items: &Schema{Type: "string"}},
+		},
+		AdditionalProperties: &Additional{Allowed: true, Schema: &Schema{Type: "boolean"}},
+	}
+
+	violations := schema.validate(nil, map[string]any{
+		"items": []any{"a", float64(1)},
+		"other": "yes",
+	})
+	var got []string
+	for _, violation := range violations {
+		got = append(got, violation.Error())
+	}
+	assert.Equal(t, []string{"items[1]: expected string, got number", "other: expected boolean, got string"}, got)
+}
+
+func TestAdditionalUnmarshalJSON(t *testing.T) {
+	var a Additional
+	require.NoError(t, a.UnmarshalJSON([]byte("false")))
+	assert.Equal(t, Additional{Allowed: false}, a)
+
+	a = Additional{}
+	require.NoError(t, a.UnmarshalJSON([]byte(`{"type": "string"}`)))
+	assert.True(t, a.Allowed)
+	assert.Equal(t, &Schema{Type: "string"}, a.Schema)
+}
@@ -0,0 +1,383 @@
+// Package importid resolves the import IDs accepted by the provider's
+// resources to Cofide Connect object IDs.
+//
+// Besides a raw object ID, each resource accepts a natural key built from
+// object names, which is resolved through the List RPCs:
+//
+//	cofide_connect_trust_zone          org/<org_name>/trust_zone/<name>
+//	cofide_connect_cluster             <trust_zone_name>/<cluster_name>
+//	cofide_connect_trust_zone_server   <trust_zone_name>/<cluster_name>
+//	cofide_connect_exchange_policy     <trust_zone_name>/exchange_policy/<name>
+//	cofide_connect_trust_zone_exchange_policies  org/<org_name>/trust_zone/<name>
+//	cofide_connect_federation          <trust_zone_name>/federation/<remote_trust_zone_name>
+//	cofide_connect_federation_pair     <trust_zone_name>/federation/<remote_trust_zone_name>
+//	cofide_connect_attestation_policy  org/<org_name>/attestation_policy/<name>
+//	cofide_connect_workload_identity   org/<org_name>/attestation_policy/<name>
+//	cofide_connect_ap_binding          <policy_name>@<trust_zone_name>
+//
+// Object IDs never contain "/" or "@", so any import ID containing either is
+// treated as a natural key.
+package importid
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apbindingsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/ap_binding_service/v1alpha1"
+	attestationpolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/attestation_policy_service/v1alpha1"
+	clustersvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/cluster_service/v1alpha1"
+	exchangepolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/exchange_policy_service/v1alpha1"
+	federationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/federation_service/v1alpha1"
+	organizationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/organization_service/v1alpha1"
+	trustzoneserversvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_server_service/v1alpha1"
+	trustzonesvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_service/v1alpha1"
+	trustzonepb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone/v1alpha1"
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+)
+
+// Formats of the natural keys, used in error messages.
+const (
+	TrustZoneFormat         = "org/<org_name>/trust_zone/<name>"
+	ClusterFormat           = "<trust_zone_name>/<cluster_name>"
+	ExchangePolicyFormat    = "<trust_zone_name>/exchange_policy/<name>"
+	FederationFormat        = "<trust_zone_name>/federation/<remote_trust_zone_name>"
+	AttestationPolicyFormat = "org/<org_name>/attestation_policy/<name>"
+	APBindingFormat         = "<policy_name>@<trust_zone_name>"
+)
+
+// IsNaturalKey returns true if id is a natural key rather than an object ID.
+func IsNaturalKey(id string) bool {
+	return strings.ContainsAny(id, "/@")
+}
+
+// TrustZone resolves a trust zone import ID.
+func TrustZone(ctx context.Context, client sdkclient.ClientSet, id string) (string, error) {
+	if !IsNaturalKey(id) {
+		return id, nil
+	}
+
+	names, err := parse(id, "/", "org", "", "trust_zone", "")
+	if err != nil {
+		return "", invalidError(id, "trust zone", TrustZoneFormat)
+	}
+
+	orgID, err := OrganizationID(ctx, client, names[0])
+	if err != nil {
+		return "", err
+	}
+
+	trustZone, err := trustZoneByName(ctx, client, names[1], &orgID)
+	if err != nil {
+		return "", err
+	}
+	return trustZone.GetId(), nil
+}
+
+// Cluster resolves a cluster import ID.
+func Cluster(ctx context.Context, client sdkclient.ClientSet, id string) (string, error) {
+	if !IsNaturalKey(id) {
+		return id, nil
+	}
+
+	names, err := parse(id, "/", "", "")
+	if err != nil {
+		return "", invalidError(id, "cluster", ClusterFormat)
+	}
+
+	trustZone, err := trustZoneByName(ctx, client, names[0], nil)
+	if err != nil {
+		return "", err
+	}
+
+	clusterID, err := clusterID(ctx, client, trustZone, names[1])
+	if err != nil {
+		return "", err
+	}
+	return clusterID, nil
+}
+
+// TrustZoneServer resolves a trust zone server import ID. A trust zone server
+// has no name of its own, so its natural key is that of the cluster it runs
+// in.
+func TrustZoneServer(ctx context.Context, client sdkclient.ClientSet, id string) (string, error) {
+	if !IsNaturalKey(id) {
+		return id, nil
+	}
+
+	names, err := parse(id, "/", "", "")
+	if err != nil {
+		return "", invalidError(id, "trust zone server", ClusterFormat)
+	}
+
+	trustZone, err := trustZoneByName(ctx, client, names[0], nil)
+	if err != nil {
+		return "", err
+	}
+
+	clusterID, err := clusterID(ctx, client, trustZone, names[1])
+	if err != nil {
+		return "", err
+	}
+
+	servers, err := client.TrustZoneServerV1Alpha1().ListTrustZoneServers(ctx, &trustzoneserversvcpb.ListTrustZoneServersRequest_Filter{
+		TrustZoneId: trustZone.GetId(),
+		ClusterId:   clusterID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not list trust zone servers: %w", err)
+	}
+
+	description := fmt.Sprintf("for cluster %q in trust zone %q", names[1], names[0])
+	return exactlyOne("trust zone server", description, ids(servers))
+}
+
+// ExchangePolicy resolves an exchange policy import ID.
+func ExchangePolicy(ctx context.Context, client sdkclient.ClientSet, id string) (string, error) {
+	if !IsNaturalKey(id) {
+		return id, nil
+	}
+
+	names, err := parse(id, "/", "", "exchange_policy", "")
+	if err != nil {
+		return "", invalidError(id, "exchange policy", ExchangePolicyFormat)
+	}
+
+	trustZone, err := trustZoneByName(ctx, client, names[0], nil)
+	if err != nil {
+		return "", err
+	}
+
+	policies, err := client.ExchangePolicyV1Alpha1().ListExchangePolicies(ctx, &exchangepolicysvcpb.ListExchangePoliciesRequest_Filter{
+		TrustZoneId: trustZone.GetId(),
+		Name:        names[1],
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not list exchange policies: %w", err)
+	}
+
+	var matches []string
+	for _, policy := range policies {
+		if policy.GetName() == names[1] {
+			matches = append(matches, policy.GetId())
+		}
+	}
+
+	description := fmt.Sprintf("named %q in trust zone %q", names[1], names[0])
+	return exactlyOne("exchange policy", description, matches)
+}
+
+// Federation resolves a federation import ID.
+func Federation(ctx context.Context, client sdkclient.ClientSet, id string) (string, error) {
+	if !IsNaturalKey(id) {
+		return id, nil
+	}
+
+	names, err := parse(id, "/", "", "federation", "")
+	if err != nil {
+		return "", invalidError(id, "federation", FederationFormat)
+	}
+
+	trustZone, err := trustZoneByName(ctx, client, names[0], nil)
+	if err != nil {
+		return "", err
+	}
+
+	remoteTrustZone, err := trustZoneByName(ctx, client, names[1], nil)
+	if err != nil {
+		return "", err
+	}
+
+	trustZoneID := trustZone.GetId()
+	remoteTrustZoneID := remoteTrustZone.GetId()
+	federations, err := client.FederationV1Alpha1().ListFederations(ctx, &federationsvcpb.ListFederationsRequest_Filter{
+		TrustZoneId:       &trustZoneID,
+		RemoteTrustZoneId: &remoteTrustZoneID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not list federations: %w", err)
+	}
+
+	description := fmt.Sprintf("from trust zone %q to %q", names[0], names[1])
+	return exactlyOne("federation", description, ids(federations))
+}
+
+// AttestationPolicy resolves an attestation policy import ID.
+func AttestationPolicy(ctx context.Context, client sdkclient.ClientSet, id string) (string, error) {
+	if !IsNaturalKey(id) {
+		return id, nil
+	}
+
+	names, err := parse(id, "/", "org", "", "attestation_policy", "")
+	if err != nil {
+		return "", invalidError(id, "attestation policy", AttestationPolicyFormat)
+	}
+
+	orgID, err := OrganizationID(ctx, client, names[0])
+	if err != nil {
+		return "", err
+	}
+
+	return attestationPolicyID(ctx, client, orgID, names[1])
+}
+
+// APBinding resolves an attestation policy binding import ID.
+func APBinding(ctx context.Context, client sdkclient.ClientSet, id string) (string, error) {
+	if !IsNaturalKey(id) {
+		return id, nil
+	}
+
+	names, err := parse(id, "@", "", "")
+	if err != nil {
+		return "", invalidError(id, "attestation policy binding", APBindingFormat)
+	}
+
+	trustZone, err := trustZoneByName(ctx, client, names[1], nil)
+	if err != nil {
+		return "", err
+	}
+
+	policyID, err := attestationPolicyID(ctx, client, trustZone.GetOrgId(), names[0])
+	if err != nil {
+		return "", err
+	}
+
+	trustZoneID := trustZone.GetId()
+	bindings, err := client.APBindingV1Alpha1().ListAPBindings(ctx, &apbindingsvcpb.ListAPBindingsRequest_Filter{
+		TrustZoneId: &trustZoneID,
+		PolicyId:    &policyID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not list attestation policy bindings: %w", err)
+	}
+
+	description := fmt.Sprintf("of policy %q to trust zone %q", names[0], names[1])
+	return exactlyOne("attestation policy binding", description, ids(bindings))
+}
+
+// parse splits id on sep and matches the segments against pattern, in which
+// an empty string stands for a name and anything else for a literal keyword.
+// It returns the names in order.
+func parse(id, sep string, pattern ...string) ([]string, error) {
+	segments := strings.Split(id, sep)
+	if len(segments) != len(pattern) {
+		return nil, fmt.Errorf("expected %d segments, got %d", len(pattern), len(segments))
+	}
+
+	var names []string
+	for i, segment := range segments {
+		switch {
+		case pattern[i] == "" && segment == "":
+			return nil, fmt.Errorf("segment %d is empty", i+1)
+		case pattern[i] == "":
+			names = append(names, segment)
+		case segment != pattern[i]:
+			return nil, fmt.Errorf("expected %q, got %q", pattern[i], segment)
+		}
+	}
+	return names, nil
+}
+
+func invalidError(id, kind, format string) error {
+	return fmt.Errorf("invalid import ID %q: expected the %s ID or a natural key of the form %s", id, kind, format)
+}
+
+// exactlyOne returns the single ID in matches, or an error naming the object
+// if there are none or several.
+func exactlyOne(kind, description string, matches []string) (string, error) {
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s %s found", kind, description)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%s %s is ambiguous: found %d matches (IDs: %s); import by ID instead", kind, description, len(matches), strings.Join(matches, ", "))
+	}
+}
+
+func ids[T interface{ GetId() string }](objects []T) []string {
+	ids := make([]string, 0, len(objects))
+	for _, object := range objects {
+		ids = append(ids, object.GetId())
+	}
+	return ids
+}
+
+// OrganizationID resolves an organization name to its ID.
+func OrganizationID(ctx context.Context, client sdkclient.ClientSet, name string) (string, error) {
+	orgs, err := client.OrganizationV1Alpha1().ListOrganizations(ctx, &organizationsvcpb.ListOrganizationsRequest_Filter{
+		Name: &name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not list organizations: %w", err)
+	}
+
+	var matches []string
+	for _, org := range orgs {
+		if org.GetName() == name {
+			matches = append(matches, org.GetId())
+		}
+	}
+	return exactlyOne("organization", fmt.Sprintf("named %q", name), matches)
+}
+
+// trustZoneByName looks up a trust zone by name, optionally within an
+// organization.
+func trustZoneByName(ctx context.Context, client sdkclient.ClientSet, name string, orgID *string) (*trustzonepb.TrustZone, error) {
+	trustZones, err := client.TrustZoneV1Alpha1().ListTrustZones(ctx, &trustzonesvcpb.ListTrustZonesRequest_Filter{
+		Name:  &name,
+		OrgId: orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list trust zones: %w", err)
+	}
+
+	var matches []*trustzonepb.TrustZone
+	for _, trustZone := range trustZones {
+		if trustZone.GetName() == name {
+			matches = append(matches, trustZone)
+		}
+	}
+
+	if _, err := exactlyOne("trust zone", fmt.Sprintf("named %q", name), ids(matches)); err != nil {
+		return nil, err
+	}
+	return matches[0], nil
+}
+
+func clusterID(ctx context.Context, client sdkclient.ClientSet, trustZone *trustzonepb.TrustZone, name string) (string, error) {
+	trustZoneID := trustZone.GetId()
+	clusters, err := client.ClusterV1Alpha1().ListClusters(ctx, &clustersvcpb.ListClustersRequest_Filter{
+		Name:        &name,
+		TrustZoneId: &trustZoneID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not list clusters: %w", err)
+	}
+
+	var matches []string
+	for _, cluster := range clusters {
+		if cluster.GetName() == name {
+			matches = append(matches, cluster.GetId())
+		}
+	}
+	return exactlyOne("cluster", fmt.Sprintf("named %q in trust zone %q", name, trustZone.GetName()), matches)
+}
+
+func attestationPolicyID(ctx context.Context, client sdkclient.ClientSet, orgID, name string) (string, error) {
+	policies, err := client.AttestationPolicyV1Alpha1().ListAttestationPolicies(ctx, &attestationpolicysvcpb.ListAttestationPoliciesRequest_Filter{
+		Name:  &name,
+		OrgId: &orgID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not list attestation policies: %w", err)
+	}
+
+	var matches []string
+	for _, policy := range policies {
+		if policy.GetName() == name {
+			matches = append(matches, policy.GetId())
+		}
+	}
+	return exactlyOne("attestation policy", fmt.Sprintf("named %q", name), matches)
+}
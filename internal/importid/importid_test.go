@@ -0,0 +1,140 @@
+package importid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		sep     string
+		pattern []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "trust zone",
+			id:      "org/acme/trust_zone/production",
+			sep:     "/",
+			pattern: []string{"org", "", "trust_zone", ""},
+			want:    []string{"acme", "production"},
+		},
+		{
+			name:    "wrong keyword",
+			id:      "org/acme/cluster/production",
+			sep:     "/",
+			pattern: []string{"org", "", "trust_zone", ""},
+			wantErr: true,
+		},
+		{
+			name:    "too few segments",
+			id:      "production/exchange_policy",
+			sep:     "/",
+			pattern: []string{"", "exchange_policy", ""},
+			wantErr: true,
+		},
+		{
+			name:    "empty name",
+			id:      "production/",
+			sep:     "/",
+			pattern: []string{"", ""},
+			wantErr: true,
+		},
+		{
+			name:    "ap binding",
+			id:      "my-policy@production",
+			sep:     "@",
+			pattern: []string{"", ""},
+			want:    []string{"my-policy", "production"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parse(tt.id, tt.sep, tt.pattern...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExactlyOne(t *testing.T) {
+	id, err := exactlyOne("cluster", `named "a"`, []string{"id-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", id)
+
+	_, err = exactlyOne("cluster", `named "a"`, nil)
+	assert.EqualError(t, err, `no cluster named "a" found`)
+
+	_, err = exactlyOne("cluster", `named "a"`, []string{"id-1", "id-2"})
+	assert.EqualError(t, err, `cluster named "a" is ambiguous: found 2 matches (IDs: id-1, id-2); import by ID instead`)
+}
+
+// Object IDs and malformed natural keys are handled without calling the API,
+// so a nil client suffices.
+func TestResolveWithoutLookup(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		resolve func(string) (string, error)
+		id      string
+		wantErr string
+	}{
+		{
+			name:    "trust zone ID",
+			resolve: func(id string) (string, error) { return TrustZone(ctx, nil, id) },
+			id:      "9b2a4c1e-5f7d-4a3b-8c6e-1d2f3a4b5c6d",
+		},
+		{
+			name:    "cluster ID",
+			resolve: func(id string) (string, error) { return Cluster(ctx, nil, id) },
+			id:      "9b2a4c1e-5f7d-4a3b-8c6e-1d2f3a4b5c6d",
+		},
+		{
+			name:    "invalid trust zone key",
+			resolve: func(id string) (string, error) { return TrustZone(ctx, nil, id) },
+			id:      "acme/production",
+			wantErr: `invalid import ID "acme/production": expected the trust zone ID or a natural key of the form org/<org_name>/trust_zone/<name>`,
+		},
+		{
+			name:    "invalid cluster key",
+			resolve: func(id string) (string, error) { return Cluster(ctx, nil, id) },
+			id:      "production/clusters/a",
+			wantErr: `invalid import ID "production/clusters/a": expected the cluster ID or a natural key of the form <trust_zone_name>/<cluster_name>`,
+		},
+		{
+			name:    "invalid exchange policy key",
+			resolve: func(id string) (string, error) { return ExchangePolicy(ctx, nil, id) },
+			id:      "production/policy/a",
+			wantErr: `invalid import ID "production/policy/a": expected the exchange policy ID or a natural key of the form <trust_zone_name>/exchange_policy/<name>`,
+		},
+		{
+			name:    "invalid ap binding key",
+			resolve: func(id string) (string, error) { return APBinding(ctx, nil, id) },
+			id:      "policy@zone@extra",
+			wantErr: `invalid import ID "policy@zone@extra": expected the attestation policy binding ID or a natural key of the form <policy_name>@<trust_zone_name>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.resolve(tt.id)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.id, got)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package kubeconfig reads the connection settings of Kubernetes clusters
+// from kubeconfig files, as written by kubectl:
+//
+//	current-context: kind-local1
+//	contexts:
+//	  - name: kind-local1
+//	    context:
+//	      cluster: kind-local1
+//	clusters:
+//	  - name: kind-local1
+//	    cluster:
+//	      server: https://127.0.0.1:6443
+//	      certificate-authority-data: LS0tLS1CRUdJTi...
+//	      tls-server-name: kubernetes
+//	      proxy-url: http://proxy:3128
+//
+// Only the parts needed to connect to a cluster's API server are read;
+// users and credentials are ignored.
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvVar is the environment variable listing the kubeconfig files to merge,
+// as kubectl reads it.
+const EnvVar = "KUBECONFIG"
+
+// Config is the merge of one or more kubeconfig files.
+type Config struct {
+	CurrentContext string
+	Contexts       map[string]Context
+	Clusters       map[string]Cluster
+}
+
+// Context names the cluster a context connects to.
+type Context struct {
+	Cluster string
+}
+
+// Cluster holds the connection settings of a cluster. CertificateAuthority
+// is the PEM-encoded certificate authority data, read from the file named in
+// the kubeconfig if it is not inline.
+type Cluster struct {
+	Server               string
+	CertificateAuthority []byte
+	TLSServerName        string
+	ProxyURL             string
+}
+
+// file is the part of a kubeconfig file that is read.
+type file struct {
+	CurrentContext string `yaml:"current-context"`
+	Contexts       []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			TLSServerName            string `yaml:"tls-server-name"`
+			ProxyURL                 string `yaml:"proxy-url"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+}
+
+// DefaultPaths returns the kubeconfig files kubectl reads by default: those
+// listed in $KUBECONFIG, or else ~/.kube/config.
+func DefaultPaths() ([]string, error) {
+	if env := os.Getenv(EnvVar); env != "" {
+		var paths []string
+		for _, path := range filepath.SplitList(env) {
+			if path != "" {
+				paths = append(paths, path)
+			}
+		}
+		return paths, nil
+	}
+
+	path, err := expandHome(filepath.Join("~", ".kube", "config"))
+	if err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
+
+// expandHome expands a leading ~ in path to the home directory.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find home directory: %w", err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
+
+// Load reads and merges the kubeconfig files at paths the way kubectl does:
+// the first file to set the current context or to define a context or
+// cluster of a given name wins. Files listed in $KUBECONFIG that do not exist
+// are skipped, as by kubectl, but at least one file must exist. A leading ~
+// in any of the paths is expanded to the home directory.
+func Load(paths []string) (*Config, error) {
+	config := &Config{
+		Contexts: map[string]Context{},
+		Clusters: map[string]Cluster{},
+	}
+
+	found := false
+	for _, path := range paths {
+		path, err := expandHome(path)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) && len(paths) > 1 {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read kubeconfig: %w", err)
+		}
+		found = true
+
+		if err := config.merge(path, data); err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig %s: %w", path, err)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("none of the kubeconfig files %s exist", strings.Join(paths, ", "))
+	}
+	return config, nil
+}
+
+// merge adds the kubeconfig file at path, holding data, to c.
+func (c *Config) merge(path string, data []byte) error {
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return err
+	}
+
+	if c.CurrentContext == "" {
+		c.CurrentContext = f.CurrentContext
+	}
+	for _, context := range f.Contexts {
+		if _, ok := c.Contexts[context.Name]; !ok {
+			c.Contexts[context.Name] = Context{Cluster: context.Context.Cluster}
+		}
+	}
+	for _, cluster := range f.Clusters {
+		if _, ok := c.Clusters[cluster.Name]; ok {
+			continue
+		}
+
+		settings := cluster.Cluster
+		var certificateAuthority []byte
+		switch {
+		case settings.CertificateAuthorityData != "":
+			decoded, err := base64.StdEncoding.DecodeString(settings.CertificateAuthorityData)
+			if err != nil {
+				return fmt.Errorf("cluster %q: invalid certificate-authority-data: %w", cluster.Name, err)
+			}
+			certificateAuthority = decoded
+		case settings.CertificateAuthority != "":
+			// Relative paths are relative to the kubeconfig file.
+			caPath := settings.CertificateAuthority
+			if !filepath.IsAbs(caPath) {
+				caPath = filepath.Join(filepath.Dir(path), caPath)
+			}
+			read, err := os.ReadFile(caPath)
+			if err != nil {
+				return fmt.Errorf("cluster %q: could not read certificate-authority: %w", cluster.Name, err)
+			}
+			certificateAuthority = read
+		}
+
+		c.Clusters[cluster.Name] = Cluster{
+			Server:               settings.Server,
+			CertificateAuthority: certificateAuthority,
+			TLSServerName:        settings.TLSServerName,
+			ProxyURL:             settings.ProxyURL,
+		}
+	}
+	return nil
+}
+
+// Cluster returns the name of the cluster that context connects to, and its
+// settings. The current context is used if context is empty.
+func (c *Config) Cluster(context string) (string, Cluster, error) {
+	if context == "" {
+		context = c.CurrentContext
+		if context == "" {
+			return "", Cluster{}, errors.New("no context given and the kubeconfig has no current-context")
+		}
+	}
+
+	ctx, ok := c.Contexts[context]
+	if !ok {
+		return "", Cluster{}, fmt.Errorf("context %q not found in kubeconfig", context)
+	}
+	cluster, ok := c.Clusters[ctx.Cluster]
+	if !ok {
+		return "", Cluster{}, fmt.Errorf("cluster %q of context %q not found in kubeconfig", ctx.Cluster, context)
+	}
+	return ctx.Cluster, cluster, nil
+}
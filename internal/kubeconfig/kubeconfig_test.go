@@ -0,0 +1,175 @@
+package kubeconfig
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCA = "-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n"
+
+func writeFile(t *testing.T, path, data string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "certs", "ca.crt"), testCA)
+	first := writeFile(t, filepath.Join(dir, "config"), `
+current-context: kind-local1
+contexts:
+  - name: kind-local1
+    context:
+      cluster: kind-local1
+      user: kind-local1
+  - name: remote
+    context:
+      cluster: remote
+clusters:
+  - name: kind-local1
+    cluster:
+      server: https://127.0.0.1:6443
+      certificate-authority-data: `+base64.StdEncoding.EncodeToString([]byte(testCA))+`
+  - name: remote
+    cluster:
+      server: https://remote.example.com
+      certificate-authority: certs/ca.crt
+      tls-server-name: kubernetes
+      proxy-url: http://proxy:3128
+users:
+  - name: kind-local1
+    user:
+      token: secret
+`)
+	second := writeFile(t, filepath.Join(dir, "other"), `
+current-context: other
+contexts:
+  - name: other
+    context:
+      cluster: other
+  - name: remote
+    context:
+      cluster: other
+clusters:
+  - name: other
+    cluster:
+      server: https://other.example.com
+`)
+
+	config, err := Load([]string{first, filepath.Join(dir, "missing"), second})
+	require.NoError(t, err)
+
+	name, cluster, err := config.Cluster("")
+	require.NoError(t, err)
+	assert.Equal(t, "kind-local1", name, "the current context of the first file should win")
+	assert.Equal(t, Cluster{Server: "https://127.0.0.1:6443", CertificateAuthority: []byte(testCA)}, cluster)
+
+	name, cluster, err = config.Cluster("remote")
+	require.NoError(t, err)
+	assert.Equal(t, "remote", name, "contexts defined by the first file should win")
+	assert.Equal(t, Cluster{
+		Server:               "https://remote.example.com",
+		CertificateAuthority: []byte(testCA),
+		TLSServerName:        "kubernetes",
+		ProxyURL:             "http://proxy:3128",
+	}, cluster, "certificate-authority should be read relative to the kubeconfig")
+
+	name, cluster, err = config.Cluster("other")
+	require.NoError(t, err)
+	assert.Equal(t, "other", name)
+	assert.Equal(t, "https://other.example.com", cluster.Server)
+
+	_, _, err = config.Cluster("absent")
+	assert.EqualError(t, err, `context "absent" not found in kubeconfig`)
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load([]string{filepath.Join(dir, "missing")})
+	assert.ErrorContains(t, err, "could not read kubeconfig")
+
+	_, err = Load([]string{filepath.Join(dir, "a"), filepath.Join(dir, "b")})
+	assert.ErrorContains(t, err, "none of the kubeconfig files")
+
+	invalidCA := writeFile(t, filepath.Join(dir, "invalid-ca"), `
+clusters:
+  - name: c
+    cluster:
+      certificate-authority-data: "!!!"
+`)
+	_, err = Load([]string{invalidCA})
+	assert.ErrorContains(t, err, `cluster "c": invalid certificate-authority-data`)
+
+	missingCA := writeFile(t, filepath.Join(dir, "missing-ca"), `
+clusters:
+  - name: c
+    cluster:
+      certificate-authority: ca.crt
+`)
+	_, err = Load([]string{missingCA})
+	assert.ErrorContains(t, err, `cluster "c": could not read certificate-authority`)
+
+	noCurrent := writeFile(t, filepath.Join(dir, "no-current"), `
+contexts:
+  - name: ctx
+    context:
+      cluster: absent
+`)
+	config, err := Load([]string{noCurrent})
+	require.NoError(t, err)
+	_, _, err = config.Cluster("")
+	assert.ErrorContains(t, err, "no current-context")
+	_, _, err = config.Cluster("ctx")
+	assert.EqualError(t, err, `cluster "absent" of context "ctx" not found in kubeconfig`)
+}
+
+func TestDefaultPaths(t *testing.T) {
+	t.Setenv(EnvVar, "/a/config"+string(filepath.ListSeparator)+string(filepath.ListSeparator)+"/b/config")
+	paths, err := DefaultPaths()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/a/config", "/b/config"}, paths)
+
+	t.Setenv(EnvVar, "")
+	t.Setenv("HOME", "/home/user")
+	paths, err = DefaultPaths()
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("/home/user", ".kube", "config")}, paths)
+}
+
+func TestLoadExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeFile(t, filepath.Join(home, ".kube", "local"), `
+current-context: kind-local1
+contexts:
+  - name: kind-local1
+    context:
+      cluster: kind-local1
+clusters:
+  - name: kind-local1
+    cluster:
+      server: https://127.0.0.1:6443
+`)
+
+	config, err := Load([]string{"~/.kube/local"})
+	require.NoError(t, err)
+	assert.Equal(t, "kind-local1", config.CurrentContext)
+
+	t.Setenv(EnvVar, "~/.kube/missing"+string(filepath.ListSeparator)+"~/.kube/local")
+	paths, err := DefaultPaths()
+	require.NoError(t, err)
+	config, err = Load(paths)
+	require.NoError(t, err)
+	assert.Equal(t, "kind-local1", config.CurrentContext)
+
+	_, err = Load([]string{"~/.kube/missing"})
+	assert.ErrorContains(t, err, filepath.Join(home, ".kube", "missing"))
+}
@@ -0,0 +1,55 @@
+package planmodifiers
+
+import (
+	"context"
+
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// CACertificateInfoModifier plans a computed attribute describing the CA
+// certificates configured in the sibling attribute named Certificate, so that
+// a change of certificate shows its new fingerprint, expiry or subject. If
+// Certificate is Computed, an unconfigured certificate keeps its prior value,
+// which is unknown on create.
+type CACertificateInfoModifier struct {
+	Certificate string
+	Computed    bool
+	Field       util.CACertificateField
+}
+
+var _ planmodifier.String = CACertificateInfoModifier{}
+
+func (m CACertificateInfoModifier) Description(_ context.Context) string {
+	return "Plans a description of the configured CA certificates."
+}
+
+func (m CACertificateInfoModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m CACertificateInfoModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	certificatePath := req.Path.ParentPath().AtName(m.Certificate)
+	var certificate util.CACertificate
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, certificatePath, &certificate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if certificate.IsNull() && m.Computed {
+		if req.State.Raw.IsNull() {
+			resp.PlanValue = util.NewCACertificateInfoUnknown().Field(m.Field)
+			return
+		}
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, certificatePath, &certificate)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.PlanValue = util.CACertificateInfoFromValue(certificate).Field(m.Field)
+}
@@ -0,0 +1,68 @@
+package planmodifiers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// HelmValuesMergedModifier plans a computed attribute holding the Helm values
+// sent to Cofide Connect, as a JSON document, from whichever of the Document,
+// Object and Layers attributes is configured. The value is unknown until all
+// of them are known.
+type HelmValuesMergedModifier struct {
+	Document path.Path
+	Object   path.Path
+	Layers   path.Path
+}
+
+var _ planmodifier.String = HelmValuesMergedModifier{}
+
+func (m HelmValuesMergedModifier) Description(_ context.Context) string {
+	return "Plans the Helm values sent to Cofide Connect from the configured Helm values."
+}
+
+func (m HelmValuesMergedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m HelmValuesMergedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var forms util.HelmValuesForms
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, m.Document, &forms.Document)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, m.Object, &forms.Object)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, m.Layers, &forms.Layers)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if forms.Document.IsUnknown() || forms.Object.IsUnknown() || forms.Object.IsUnderlyingValueUnknown() || forms.Layers.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	values, err := forms.ToProto()
+	if errors.Is(err, util.ErrUnknownHelmValue) {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Helm values", err.Error())
+		return
+	}
+
+	merged, err := util.HelmValuesJSON(values)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Helm values", err.Error())
+		return
+	}
+	resp.PlanValue = merged
+}
@@ -2,12 +2,12 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"strconv"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,19 +17,25 @@ import (
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
 	"github.com/cofide/terraform-provider-cofide/internal/client"
 	"github.com/cofide/terraform-provider-cofide/internal/consts"
-	"github.com/cofide/terraform-provider-cofide/internal/credentials"
 	"github.com/cofide/terraform-provider-cofide/internal/services/apbinding"
 	"github.com/cofide/terraform-provider-cofide/internal/services/attestationpolicy"
 	"github.com/cofide/terraform-provider-cofide/internal/services/cluster"
 	"github.com/cofide/terraform-provider-cofide/internal/services/exchangepolicy"
 	"github.com/cofide/terraform-provider-cofide/internal/services/federation"
+	"github.com/cofide/terraform-provider-cofide/internal/services/federationmesh"
+	"github.com/cofide/terraform-provider-cofide/internal/services/federationpair"
+	"github.com/cofide/terraform-provider-cofide/internal/services/kubeconfigcluster"
 	"github.com/cofide/terraform-provider-cofide/internal/services/organization"
 	"github.com/cofide/terraform-provider-cofide/internal/services/rolebinding"
+	"github.com/cofide/terraform-provider-cofide/internal/services/rolebindingsexclusive"
 	"github.com/cofide/terraform-provider-cofide/internal/services/trustzone"
 	"github.com/cofide/terraform-provider-cofide/internal/services/trustzoneserver"
 )
 
-var _ provider.Provider = &CofideProvider{}
+var (
+	_ provider.Provider                  = &CofideProvider{}
+	_ provider.ProviderWithListResources = &CofideProvider{}
+)
 
 func NewProvider(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -93,46 +99,22 @@ func (p *CofideProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	// Attempts to get configuration from environment variables if not provided in the provider block.
-	apiToken := config.APIToken.ValueString()
-	if apiToken == "" {
-		apiToken = os.Getenv(consts.APITokenEnvVarKey)
-	}
-	if apiToken == "" {
-		token, err := credentials.LoadFromFile()
-		if err != nil {
-			tflog.Warn(ctx, "Failed to read credentials file", map[string]interface{}{"error": err.Error()})
-		} else {
-			apiToken = token
-		}
+	var insecureSkipVerify *bool
+	if !config.InsecureSkipVerify.IsNull() && !config.InsecureSkipVerify.IsUnknown() {
+		insecureSkipVerify = config.InsecureSkipVerify.ValueBoolPointer()
 	}
 
-	connectURL := config.ConnectURL.ValueString()
-	if connectURL == "" {
-		connectURL = os.Getenv(consts.ConnectURLEnvVarKey)
+	settings, err := client.ResolveSettings(config.APIToken.ValueString(), config.ConnectURL.ValueString(), insecureSkipVerify)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to read credentials file", map[string]interface{}{"error": err.Error()})
 	}
 
-	insecureSkipVerify := config.InsecureSkipVerify.ValueBool()
-	if config.InsecureSkipVerify.IsNull() || config.InsecureSkipVerify.IsUnknown() {
-		if envVal, ok := os.LookupEnv(consts.InsecureSkipVerifyEnvVar); ok {
-			if parsed, err := strconv.ParseBool(envVal); err == nil {
-				insecureSkipVerify = parsed
-			}
+	if err := settings.Validate(); err != nil {
+		summary := "Missing Connect URL Configuration"
+		if errors.Is(err, client.ErrMissingAPIToken) {
+			summary = "Missing API Token Configuration"
 		}
-	}
-
-	if apiToken == "" {
-		resp.Diagnostics.AddError(
-			"Missing API Token Configuration",
-			"API token must be specified in provider configuration, via the COFIDE_API_TOKEN environment variable, or via the credentials file at ~/.cofide/credentials.",
-		)
-		return
-	}
-
-	if connectURL == "" {
-		resp.Diagnostics.AddError(
-			"Missing Connect URL Configuration",
-			"Connect URL must be specified in provider configuration or via the COFIDE_CONNECT_URL environment variable",
-		)
+		resp.Diagnostics.AddError(summary, err.Error())
 		return
 	}
 
@@ -140,7 +122,7 @@ func (p *CofideProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		Name: "cofide",
 	})
 
-	client, err := client.NewTLSClient(connectURL, apiToken, insecureSkipVerify, log, p.version)
+	client, err := client.NewTLSClient(settings.ConnectURL, settings.APIToken, settings.InsecureSkipVerify, log, p.version)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create TLS client", err.Error())
 		return
@@ -150,6 +132,7 @@ func (p *CofideProvider) Configure(ctx context.Context, req provider.ConfigureRe
 
 	resp.DataSourceData = p.Client
 	resp.ResourceData = p.Client
+	resp.ListResourceData = p.Client
 
 	tflog.Debug(ctx, "Configure method completed successfully")
 }
@@ -157,11 +140,16 @@ func (p *CofideProvider) Configure(ctx context.Context, req provider.ConfigureRe
 func (p *CofideProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		attestationpolicy.NewResource,
+		attestationpolicy.NewWorkloadIdentityResource,
 		apbinding.NewResource,
 		cluster.NewResource,
 		exchangepolicy.NewResource,
+		exchangepolicy.NewTrustZonePoliciesResource,
 		federation.NewResource,
+		federationpair.NewResource,
+		federationmesh.NewResource,
 		rolebinding.NewResource,
+		rolebindingsexclusive.NewResource,
 		trustzone.NewResource,
 		trustzoneserver.NewResource,
 	}
@@ -176,8 +164,23 @@ func (p *CofideProvider) DataSources(ctx context.Context) []func() datasource.Da
 		exchangepolicy.NewListDataSource,
 		federation.NewDataSource,
 		trustzone.NewDataSource,
+		kubeconfigcluster.NewDataSource,
 		organization.NewDataSource,
 		trustzoneserver.NewDataSource,
 		trustzoneserver.NewListDataSource,
 	}
 }
+
+func (p *CofideProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		attestationpolicy.NewListResource,
+		apbinding.NewListResource,
+		cluster.NewListResource,
+		exchangepolicy.NewListResource,
+		federation.NewListResource,
+		federationpair.NewListResource,
+		rolebinding.NewListResource,
+		trustzone.NewListResource,
+		trustzoneserver.NewListResource,
+	}
+}
@@ -0,0 +1,65 @@
+package providertest
+
+import (
+	"context"
+
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	clustersvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/cluster_service/v1alpha1"
+	trustzoneserversvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_server_service/v1alpha1"
+	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Client is a client that reads the clusters and trust zone servers it
+// holds. Lists are not filtered, and its other clients are nil.
+type Client struct {
+	sdkclient.ClientSet
+	Clusters         []*clusterpb.Cluster
+	TrustZoneServers []*trustzoneserverpb.TrustZoneServer
+}
+
+func (c *Client) ClusterV1Alpha1() sdkclient.ClusterClient {
+	return clusterClient{clusters: c.Clusters}
+}
+
+func (c *Client) TrustZoneServerV1Alpha1() sdkclient.TrustZoneServerClient {
+	return trustZoneServerClient{servers: c.TrustZoneServers}
+}
+
+type clusterClient struct {
+	sdkclient.ClusterClient
+	clusters []*clusterpb.Cluster
+}
+
+func (c clusterClient) GetCluster(_ context.Context, id string) (*clusterpb.Cluster, error) {
+	for _, cluster := range c.clusters {
+		if cluster.GetId() == id {
+			return cluster, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "cluster %q not found", id)
+}
+
+func (c clusterClient) ListClusters(_ context.Context, _ *clustersvcpb.ListClustersRequest_Filter) ([]*clusterpb.Cluster, error) {
+	return c.clusters, nil
+}
+
+type trustZoneServerClient struct {
+	sdkclient.TrustZoneServerClient
+	servers []*trustzoneserverpb.TrustZoneServer
+}
+
+func (c trustZoneServerClient) GetTrustZoneServer(_ context.Context, id string) (*trustzoneserverpb.TrustZoneServer, error) {
+	for _, server := range c.servers {
+		if server.GetId() == id {
+			return server, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "trust zone server %q not found", id)
+}
+
+func (c trustZoneServerClient) ListTrustZoneServers(_ context.Context, _ *trustzoneserversvcpb.ListTrustZoneServersRequest_Filter) ([]*trustzoneserverpb.TrustZoneServer, error) {
+	return c.servers, nil
+}
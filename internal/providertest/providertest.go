@@ -0,0 +1,438 @@
+// Package providertest drives the provider through its protocol server, as
+// Terraform does, for the tests of each service. Values are built from Go
+// strings, bools, numbers, slices and maps, or tftypes values for dynamic
+// attributes, with object attributes missing from a map left null.
+package providertest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cofide/terraform-provider-cofide/internal"
+)
+
+// Server is the provider's protocol server, with its schemas.
+type Server struct {
+	tfprotov6.ProviderServer
+	schemas *tfprotov6.GetProviderSchemaResponse
+}
+
+// NewServer returns the protocol server of a provider without a client.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+
+	return newServer(t, internal.NewProvider("test")())
+}
+
+func newServer(t *testing.T, p provider.Provider) *Server {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(p)()
+	require.NoError(t, err)
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	require.Empty(t, schemas.Diagnostics)
+	return &Server{ProviderServer: server, schemas: schemas}
+}
+
+// ResourceSchema returns the schema of the resource of type typeName.
+func (s *Server) ResourceSchema(t *testing.T, typeName string) *tfprotov6.Schema {
+	t.Helper()
+
+	schema, ok := s.schemas.ResourceSchemas[typeName]
+	require.True(t, ok, "unknown resource type %s", typeName)
+	return schema
+}
+
+// DataSourceSchema returns the schema of the data source of type typeName.
+func (s *Server) DataSourceSchema(t *testing.T, typeName string) *tfprotov6.Schema {
+	t.Helper()
+
+	schema, ok := s.schemas.DataSourceSchemas[typeName]
+	require.True(t, ok, "unknown data source type %s", typeName)
+	return schema
+}
+
+// PlanTest is a planned update of a resource.
+type PlanTest struct {
+	Name   string
+	State  map[string]any
+	Config map[string]any
+	// WantReplace lists the attributes whose change must force replacement.
+	WantReplace []string
+	// WantUnknown lists the attributes that must be unknown in the plan.
+	WantUnknown []string
+}
+
+// RunPlanTests plans each update of a resource of type typeName and checks
+// which attributes force replacement and which are left unknown.
+func RunPlanTests(t *testing.T, typeName string, tests []PlanTest) {
+	t.Helper()
+
+	ctx := context.Background()
+	server := NewServer(t)
+	s := server.ResourceSchema(t, typeName)
+	typ := s.ValueType()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			prior := NewValue(t, typ, tt.State)
+			config := NewValue(t, typ, tt.Config)
+
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         typeName,
+				PriorState:       DynamicValue(t, typ, prior),
+				ProposedNewState: DynamicValue(t, typ, ProposedNewState(t, s.Block, prior, config)),
+				Config:           DynamicValue(t, typ, config),
+			})
+			require.NoError(t, err)
+			AssertNoErrors(t, resp.Diagnostics)
+
+			var gotReplace []string
+			for _, p := range resp.RequiresReplace {
+				gotReplace = append(gotReplace, p.String())
+			}
+			var wantReplace []string
+			for _, name := range tt.WantReplace {
+				wantReplace = append(wantReplace, tftypes.NewAttributePath().WithAttributeName(name).String())
+			}
+			assert.ElementsMatch(t, wantReplace, gotReplace)
+
+			var gotUnknown []string
+			for name, v := range Attributes(t, resp.PlannedState, typ) {
+				if !v.IsKnown() {
+					gotUnknown = append(gotUnknown, name)
+				}
+			}
+			assert.ElementsMatch(t, tt.WantUnknown, gotUnknown)
+		})
+	}
+}
+
+// PlanCreate plans the creation of a resource of type typeName from config,
+// which Terraform also proposes as the new state, and returns the planned
+// state.
+func PlanCreate(t *testing.T, server *Server, typeName string, config map[string]any) tftypes.Value {
+	t.Helper()
+
+	typ := server.ResourceSchema(t, typeName).ValueType()
+	configValue := NewValue(t, typ, config)
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       DynamicValue(t, typ, tftypes.NewValue(typ, nil)),
+		ProposedNewState: DynamicValue(t, typ, configValue),
+		Config:           DynamicValue(t, typ, configValue),
+	})
+	require.NoError(t, err)
+	AssertNoErrors(t, resp.Diagnostics)
+
+	planned, err := resp.PlannedState.Unmarshal(typ)
+	require.NoError(t, err)
+	return planned
+}
+
+// PlanUpdate plans an update of a resource of type typeName from state to
+// config.
+func PlanUpdate(t *testing.T, server *Server, typeName string, state, config map[string]any) *tfprotov6.PlanResourceChangeResponse {
+	t.Helper()
+
+	s := server.ResourceSchema(t, typeName)
+	typ := s.ValueType()
+	prior := NewValue(t, typ, state)
+	configValue := NewValue(t, typ, config)
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       DynamicValue(t, typ, prior),
+		ProposedNewState: DynamicValue(t, typ, ProposedNewState(t, s.Block, prior, configValue)),
+		Config:           DynamicValue(t, typ, configValue),
+	})
+	require.NoError(t, err)
+	AssertNoErrors(t, resp.Diagnostics)
+	return resp
+}
+
+// AssertStateOnlyUpdate checks that an update of a resource of type typeName
+// from state to config, which changes only attributes kept in Terraform
+// state, is applied without a client, and sets the resource identity even
+// when the state has none, as when written before resources had identities.
+func AssertStateOnlyUpdate(t *testing.T, typeName string, state, config map[string]any) {
+	t.Helper()
+
+	server := NewServer(t)
+	typ := server.ResourceSchema(t, typeName).ValueType()
+	planned := PlanUpdate(t, server, typeName, state, config)
+	require.Empty(t, planned.RequiresReplace)
+
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:        typeName,
+		PriorState:      DynamicValue(t, typ, NewValue(t, typ, state)),
+		PlannedState:    planned.PlannedState,
+		Config:          DynamicValue(t, typ, NewValue(t, typ, config)),
+		PlannedPrivate:  planned.PlannedPrivate,
+		PlannedIdentity: planned.PlannedIdentity,
+	})
+	require.NoError(t, err)
+	AssertNoErrors(t, resp.Diagnostics)
+	require.NotNil(t, resp.NewIdentity, "the resource identity should be set")
+}
+
+// ValidateTest is the validation of a resource configuration.
+type ValidateTest struct {
+	Name   string
+	Config map[string]any
+	// WantSummary is the summary of the first diagnostic, or empty if the
+	// configuration is valid.
+	WantSummary string
+	// WantDetail is contained in the detail of the first diagnostic.
+	WantDetail string
+}
+
+// RunValidateTests validates each configuration of a resource of type
+// typeName, allowing write-only attributes.
+func RunValidateTests(t *testing.T, typeName string, tests []ValidateTest) {
+	t.Helper()
+
+	ctx := context.Background()
+	server := NewServer(t)
+	typ := server.ResourceSchema(t, typeName).ValueType()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: typeName,
+				Config:   DynamicValue(t, typ, NewValue(t, typ, tt.Config)),
+				ClientCapabilities: &tfprotov6.ValidateResourceConfigClientCapabilities{
+					WriteOnlyAttributesAllowed: true,
+				},
+			})
+			require.NoError(t, err)
+			if tt.WantSummary == "" {
+				assert.Empty(t, resp.Diagnostics)
+				return
+			}
+			require.NotEmpty(t, resp.Diagnostics)
+			assert.Equal(t, tt.WantSummary, resp.Diagnostics[0].Summary)
+			assert.Contains(t, resp.Diagnostics[0].Detail, tt.WantDetail)
+		})
+	}
+}
+
+// Destroy destroys a resource of type typeName in state, without a client,
+// and returns the diagnostics and whether the resource remains in state.
+func Destroy(t *testing.T, typeName string, state map[string]any) ([]*tfprotov6.Diagnostic, bool) {
+	t.Helper()
+
+	server := NewServer(t)
+	typ := server.ResourceSchema(t, typeName).ValueType()
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     typeName,
+		PriorState:   DynamicValue(t, typ, NewValue(t, typ, state)),
+		PlannedState: DynamicValue(t, typ, tftypes.NewValue(typ, nil)),
+		Config:       DynamicValue(t, typ, tftypes.NewValue(typ, nil)),
+	})
+	require.NoError(t, err)
+
+	newState, err := resp.NewState.Unmarshal(typ)
+	require.NoError(t, err)
+	return resp.Diagnostics, !newState.IsNull()
+}
+
+// AssertDeletionProtected checks that destroying a resource of type typeName
+// in state fails, leaving it in state.
+func AssertDeletionProtected(t *testing.T, typeName string, state map[string]any) {
+	t.Helper()
+
+	diags, remains := Destroy(t, typeName, state)
+	require.Len(t, diags, 1)
+	assert.Equal(t, tfprotov6.DiagnosticSeverityError, diags[0].Severity)
+	assert.Contains(t, diags[0].Summary, "Cannot delete protected")
+	assert.True(t, remains, "the resource should remain in state")
+}
+
+// AssertRetainedOnDelete checks that destroying a resource of type typeName
+// in state removes it from state with a warning, without calling Cofide
+// Connect.
+func AssertRetainedOnDelete(t *testing.T, typeName string, state map[string]any) {
+	t.Helper()
+
+	diags, remains := Destroy(t, typeName, state)
+	require.Len(t, diags, 1)
+	assert.Equal(t, tfprotov6.DiagnosticSeverityWarning, diags[0].Severity)
+	assert.Contains(t, diags[0].Summary, "Retained")
+	assert.False(t, remains, "the resource should be removed from state")
+}
+
+// UpgradeState upgrades state of a resource of type typeName written at
+// version, given as JSON, and returns the upgraded state.
+func UpgradeState(t *testing.T, typeName string, version int64, rawState []byte) tftypes.Value {
+	t.Helper()
+
+	server := NewServer(t)
+	typ := server.ResourceSchema(t, typeName).ValueType()
+	resp, err := server.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: rawState},
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+
+	upgraded, err := resp.UpgradedState.Unmarshal(typ)
+	require.NoError(t, err)
+	return upgraded
+}
+
+// AssertUpgradedUnchanged checks that state of a resource of type typeName
+// written at version 0 is carried over unchanged.
+func AssertUpgradedUnchanged(t *testing.T, typeName string, state map[string]any) {
+	t.Helper()
+
+	rawState, err := json.Marshal(state)
+	require.NoError(t, err)
+	got := UpgradeState(t, typeName, 0, rawState)
+	want := NewValue(t, NewServer(t).ResourceSchema(t, typeName).ValueType(), state)
+	assert.True(t, want.Equal(got), "got %s, want %s", got, want)
+}
+
+// AssertNoErrors fails t for each error in diags.
+func AssertNoErrors(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	for _, diag := range diags {
+		assert.NotEqual(t, tfprotov6.DiagnosticSeverityError, diag.Severity, "%s: %s", diag.Summary, diag.Detail)
+	}
+}
+
+// Attributes returns the attributes of the object in v, of type typ.
+func Attributes(t *testing.T, v *tfprotov6.DynamicValue, typ tftypes.Type) map[string]tftypes.Value {
+	t.Helper()
+
+	value, err := v.Unmarshal(typ)
+	require.NoError(t, err)
+	var attributes map[string]tftypes.Value
+	require.NoError(t, value.As(&attributes))
+	return attributes
+}
+
+// String returns the string at the nested attribute path steps of v.
+func String(t *testing.T, v tftypes.Value, steps ...string) string {
+	t.Helper()
+
+	p := tftypes.NewAttributePath()
+	for _, step := range steps {
+		p = p.WithAttributeName(step)
+	}
+	value, _, err := tftypes.WalkAttributePath(v, p)
+	require.NoError(t, err)
+	var got string
+	require.NoError(t, value.(tftypes.Value).As(&got))
+	return got
+}
+
+// NewValue builds a value of type typ from v.
+func NewValue(t *testing.T, typ tftypes.Type, v any) tftypes.Value {
+	t.Helper()
+
+	if v == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	if value, ok := v.(tftypes.Value); ok {
+		return value
+	}
+
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		m, ok := v.(map[string]any)
+		require.True(t, ok, "object value must be a map, got %T", v)
+		for name := range m {
+			require.Contains(t, typ.AttributeTypes, name, "unknown attribute")
+		}
+		attributes := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for name, attributeType := range typ.AttributeTypes {
+			attributes[name] = NewValue(t, attributeType, m[name])
+		}
+		return tftypes.NewValue(typ, attributes)
+	case tftypes.List:
+		return tftypes.NewValue(typ, newElements(t, typ.ElementType, v))
+	case tftypes.Set:
+		return tftypes.NewValue(typ, newElements(t, typ.ElementType, v))
+	default:
+		return tftypes.NewValue(typ, v)
+	}
+}
+
+func newElements(t *testing.T, elementType tftypes.Type, v any) []tftypes.Value {
+	t.Helper()
+
+	s, ok := v.([]any)
+	require.True(t, ok, "list or set value must be a slice, got %T", v)
+	elements := make([]tftypes.Value, 0, len(s))
+	for _, element := range s {
+		elements = append(elements, NewValue(t, elementType, element))
+	}
+	return elements
+}
+
+// ProposedNewState approximates the proposed new state Terraform sends when
+// planning an update: configured attributes take their configured value and
+// unconfigured computed attributes keep their prior value.
+func ProposedNewState(t *testing.T, block *tfprotov6.SchemaBlock, prior, config tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	var priorAttributes, configAttributes map[string]tftypes.Value
+	require.NoError(t, prior.As(&priorAttributes))
+	require.NoError(t, config.As(&configAttributes))
+
+	attributes := make(map[string]tftypes.Value, len(configAttributes))
+	for _, a := range block.Attributes {
+		attributes[a.Name] = configAttributes[a.Name]
+		if configAttributes[a.Name].IsNull() && a.Computed {
+			attributes[a.Name] = priorAttributes[a.Name]
+		}
+	}
+	return tftypes.NewValue(config.Type(), attributes)
+}
+
+// DynamicValue encodes v, of type typ, for a request.
+func DynamicValue(t *testing.T, typ tftypes.Type, v tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	dv, err := tfprotov6.NewDynamicValue(typ, v)
+	require.NoError(t, err)
+	return &dv
+}
+
+// NewCertificate returns a self-signed PEM-encoded certificate.
+func NewCertificate(t *testing.T, commonName string, isCA bool, notAfter time.Time) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notAfter.Add(-48 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
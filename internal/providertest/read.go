@@ -0,0 +1,178 @@
+package providertest
+
+import (
+	"context"
+	"testing"
+
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cofide/terraform-provider-cofide/internal"
+)
+
+// clientProvider is the provider, configured with a client given to it
+// rather than one it connects to Cofide Connect.
+type clientProvider struct {
+	provider.ProviderWithListResources
+	client sdkclient.ClientSet
+}
+
+func (p clientProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.DataSourceData = p.client
+	resp.ResourceData = p.client
+	resp.ListResourceData = p.client
+}
+
+// NewServerWithClient returns the protocol server of a provider configured
+// with client.
+func NewServerWithClient(t *testing.T, client sdkclient.ClientSet) *Server {
+	t.Helper()
+
+	p, ok := internal.NewProvider("test")().(provider.ProviderWithListResources)
+	require.True(t, ok, "the provider has no list resources")
+	server := newServer(t, clientProvider{ProviderWithListResources: p, client: client})
+
+	typ := server.schemas.Provider.ValueType()
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		Config: DynamicValue(t, typ, NewValue(t, typ, map[string]any{})),
+	})
+	require.NoError(t, err)
+	AssertNoErrors(t, resp.Diagnostics)
+	return server
+}
+
+// ImportAndRead imports the resource of type typeName with import ID id and
+// reads it, as Terraform does, and returns the state read.
+func ImportAndRead(t *testing.T, server *Server, typeName, id string) tftypes.Value {
+	t.Helper()
+
+	ctx := context.Background()
+	importResp, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		ID:       id,
+	})
+	require.NoError(t, err)
+	AssertNoErrors(t, importResp.Diagnostics)
+	require.Len(t, importResp.ImportedResources, 1)
+	imported := importResp.ImportedResources[0]
+
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:        typeName,
+		CurrentState:    imported.State,
+		CurrentIdentity: imported.Identity,
+		Private:         imported.Private,
+	})
+	require.NoError(t, err)
+	AssertNoErrors(t, readResp.Diagnostics)
+
+	state, err := readResp.NewState.Unmarshal(server.ResourceSchema(t, typeName).ValueType())
+	require.NoError(t, err)
+	return state
+}
+
+// List lists the resources of type typeName matching config, as `terraform
+// query` does, and returns them.
+func List(t *testing.T, server *Server, typeName string, config map[string]any) []tftypes.Value {
+	t.Helper()
+
+	listSchema, ok := server.schemas.ListResourceSchemas[typeName]
+	require.True(t, ok, "unknown list resource type %s", typeName)
+	listServer, ok := server.ProviderServer.(tfprotov6.ListResourceServer)
+	require.True(t, ok, "the protocol server does not serve list resources")
+	configType := listSchema.ValueType()
+	resp, err := listServer.ListResource(context.Background(), &tfprotov6.ListResourceRequest{
+		TypeName:        typeName,
+		Config:          DynamicValue(t, configType, NewValue(t, configType, config)),
+		IncludeResource: true,
+		Limit:           100,
+	})
+	require.NoError(t, err)
+
+	typ := server.ResourceSchema(t, typeName).ValueType()
+	var resources []tftypes.Value
+	for result := range resp.Results {
+		AssertNoErrors(t, result.Diagnostics)
+		resource, err := result.Resource.Unmarshal(typ)
+		require.NoError(t, err)
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// ReadDataSource reads the data source of type typeName with config and
+// returns its state.
+func ReadDataSource(t *testing.T, server *Server, typeName string, config map[string]any) tftypes.Value {
+	t.Helper()
+
+	typ := server.DataSourceSchema(t, typeName).ValueType()
+	resp, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   DynamicValue(t, typ, NewValue(t, typ, config)),
+	})
+	require.NoError(t, err)
+	AssertNoErrors(t, resp.Diagnostics)
+
+	state, err := resp.State.Unmarshal(typ)
+	require.NoError(t, err)
+	return state
+}
+
+// AssertNotExposed checks that secret appears in no attribute of value, of
+// schema block, other than those marked sensitive, which Terraform redacts.
+func AssertNotExposed(t *testing.T, block *tfprotov6.SchemaBlock, value tftypes.Value, secret string) {
+	t.Helper()
+
+	assertNotExposed(t, block.Attributes, value, secret, "")
+}
+
+func assertNotExposed(t *testing.T, attributes []*tfprotov6.SchemaAttribute, value tftypes.Value, secret, prefix string) {
+	t.Helper()
+
+	if value.IsNull() {
+		return
+	}
+	var values map[string]tftypes.Value
+	require.NoError(t, value.As(&values))
+
+	for _, a := range attributes {
+		path := prefix + a.Name
+		switch {
+		case a.Sensitive:
+		case a.NestedType != nil:
+			for _, object := range nestedObjects(t, values[a.Name]) {
+				assertNotExposed(t, a.NestedType.Attributes, object, secret, path+".")
+			}
+		default:
+			assert.NotContains(t, values[a.Name].String(), secret, "%s exposes a sensitive value", path)
+		}
+	}
+}
+
+// nestedObjects returns the objects of a nested attribute's value.
+func nestedObjects(t *testing.T, v tftypes.Value) []tftypes.Value {
+	t.Helper()
+
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	switch v.Type().(type) {
+	case tftypes.Object:
+		return []tftypes.Value{v}
+	case tftypes.Map:
+		var elements map[string]tftypes.Value
+		require.NoError(t, v.As(&elements))
+		objects := make([]tftypes.Value, 0, len(elements))
+		for _, element := range elements {
+			objects = append(objects, element)
+		}
+		return objects
+	default:
+		var elements []tftypes.Value
+		require.NoError(t, v.As(&elements))
+		return elements
+	}
+}
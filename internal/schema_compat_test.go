@@ -0,0 +1,64 @@
+package internal_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cofide/terraform-provider-cofide/internal/schemacheck"
+)
+
+// releasedSchemaPath is the schema snapshot of the last released version of
+// the provider. Regenerate it when cutting a release with `just schema-snapshot`.
+var releasedSchemaPath = filepath.Join("testdata", "released_schema.json")
+
+// TestSchemaCompatibility fails on changes since the last release that could
+// break existing state or configuration. A breaking change to a resource is
+// accepted only when the resource's schema version has been bumped and a
+// state upgrader is registered for the released version.
+func TestSchemaCompatibility(t *testing.T) {
+	ctx := context.Background()
+
+	released, err := schemacheck.Load(releasedSchemaPath)
+	require.NoError(t, err)
+
+	current, err := schemacheck.Take(ctx, newTestProvider())
+	require.NoError(t, err)
+
+	resources := providerResources(t)
+
+	for _, change := range schemacheck.Diff(released, current) {
+		if !change.Breaking {
+			t.Logf("additive: %s", change)
+			continue
+		}
+
+		if change.Kind == "resource" {
+			if r, ok := resources[change.Name]; ok && hasStateUpgrader(ctx, r, released.Resources[change.Name].Version, current.Resources[change.Name].Version) {
+				t.Logf("breaking, with state upgrader: %s", change)
+				continue
+			}
+		}
+
+		t.Errorf("breaking: %s", change)
+	}
+}
+
+// hasStateUpgrader reports whether r upgrades state from the released schema
+// version to a newer current version.
+func hasStateUpgrader(ctx context.Context, r resource.Resource, releasedVersion, currentVersion int64) bool {
+	if currentVersion <= releasedVersion {
+		return false
+	}
+
+	upgrader, ok := r.(resource.ResourceWithUpgradeState)
+	if !ok {
+		return false
+	}
+
+	_, ok = upgrader.UpgradeState(ctx)[releasedVersion]
+	return ok
+}
@@ -0,0 +1,174 @@
+package schemacheck
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Change is a single difference between two snapshots.
+type Change struct {
+	// Breaking is true if the change can break existing state or
+	// configuration.
+	Breaking bool
+	// Kind is "resource", "data source" or "function".
+	Kind string
+	// Name is the type name of the resource or data source, or the name of
+	// the function.
+	Name string
+	// Path is the dotted attribute path, empty for changes to the whole
+	// resource, data source or function.
+	Path    string
+	Message string
+}
+
+func (c Change) String() string {
+	s := c.Kind + " " + c.Name
+	if c.Path != "" {
+		s += ": " + c.Path
+	}
+	return s + ": " + c.Message
+}
+
+// Diff returns the changes between the old and new snapshots, sorted by kind,
+// name and path.
+//
+// Removing a resource, data source, function or attribute, changing an
+// attribute's type, making an attribute required and adding RequiresReplace
+// to an attribute are breaking. Everything else is additive.
+func Diff(old, new *Snapshot) []Change {
+	var changes []Change
+
+	changes = append(changes, diffSchemas("resource", old.Resources, new.Resources)...)
+	changes = append(changes, diffSchemas("data source", old.DataSources, new.DataSources)...)
+
+	for name, oldFunction := range old.Functions {
+		newFunction, ok := new.Functions[name]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Breaking: true, Kind: "function", Name: name, Message: "function removed"})
+		case !slices.Equal(oldFunction.Parameters, newFunction.Parameters),
+			oldFunction.VariadicParameter != newFunction.VariadicParameter,
+			oldFunction.Return != newFunction.Return:
+			changes = append(changes, Change{Breaking: true, Kind: "function", Name: name, Message: "signature changed"})
+		}
+	}
+	for name := range new.Functions {
+		if _, ok := old.Functions[name]; !ok {
+			changes = append(changes, Change{Kind: "function", Name: name, Message: "function added"})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func diffSchemas(kind string, old, new map[string]Schema) []Change {
+	var changes []Change
+
+	for name, oldSchema := range old {
+		newSchema, ok := new[name]
+		if !ok {
+			changes = append(changes, Change{Breaking: true, Kind: kind, Name: name, Message: kind + " removed"})
+			continue
+		}
+		changes = append(changes, diffAttributes(kind, name, oldSchema.Attributes, newSchema.Attributes)...)
+	}
+
+	for name := range new {
+		if _, ok := old[name]; !ok {
+			changes = append(changes, Change{Kind: kind, Name: name, Message: kind + " added"})
+		}
+	}
+
+	return changes
+}
+
+func diffAttributes(kind, name string, old, new map[string]Attribute) []Change {
+	var changes []Change
+	change := func(breaking bool, path, format string, args ...any) {
+		changes = append(changes, Change{
+			Breaking: breaking,
+			Kind:     kind,
+			Name:     name,
+			Path:     path,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for path, oldAttribute := range old {
+		newAttribute, ok := new[path]
+		if !ok {
+			// Report only the outermost removed attribute.
+			if parent, nested := parentPath(path); !nested || hasAttribute(new, parent) {
+				change(true, path, "attribute removed")
+			}
+			continue
+		}
+
+		if oldAttribute.Type != newAttribute.Type || oldAttribute.Nesting != newAttribute.Nesting {
+			change(true, path, "type changed from %s to %s", describeType(oldAttribute), describeType(newAttribute))
+		}
+		if !oldAttribute.Required && newAttribute.Required {
+			change(true, path, "attribute is now required")
+		}
+		if !oldAttribute.RequiresReplace && newAttribute.RequiresReplace {
+			change(true, path, "attribute now requires replacement")
+		}
+		if oldAttribute.Required && !newAttribute.Required {
+			change(false, path, "attribute is no longer required")
+		}
+		if oldAttribute.RequiresReplace && !newAttribute.RequiresReplace {
+			change(false, path, "attribute no longer requires replacement")
+		}
+		if oldAttribute.Optional != newAttribute.Optional || oldAttribute.Computed != newAttribute.Computed {
+			change(false, path, "optional/computed changed")
+		}
+		if oldAttribute.Sensitive != newAttribute.Sensitive {
+			change(false, path, "sensitive changed to %t", newAttribute.Sensitive)
+		}
+	}
+
+	for path, newAttribute := range new {
+		if _, ok := old[path]; ok {
+			continue
+		}
+		// A new required attribute breaks existing configuration, unless
+		// it is nested in an attribute that is itself new.
+		parent, nested := parentPath(path)
+		if !nested || hasAttribute(old, parent) {
+			change(newAttribute.Required, path, "attribute added")
+		}
+	}
+
+	return changes
+}
+
+func parentPath(path string) (string, bool) {
+	i := strings.LastIndex(path, ".")
+	if i < 0 {
+		return "", false
+	}
+	return path[:i], true
+}
+
+func hasAttribute(attributes map[string]Attribute, path string) bool {
+	_, ok := attributes[path]
+	return ok
+}
+
+func describeType(a Attribute) string {
+	if a.Nesting != "" {
+		return a.Nesting + " nested"
+	}
+	return a.Type
+}
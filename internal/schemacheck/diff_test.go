@@ -0,0 +1,163 @@
+package schemacheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	str := `"string"`
+	base := func() *Snapshot {
+		return &Snapshot{
+			Resources: map[string]Schema{
+				"cofide_connect_thing": {Attributes: map[string]Attribute{
+					"id":          {Type: str, Computed: true},
+					"name":        {Type: str, Required: true},
+					"description": {Type: str, Optional: true},
+					"config":      {Nesting: "single", Optional: true},
+					"config.key":  {Type: str, Required: true},
+				}},
+			},
+			DataSources: map[string]Schema{
+				"cofide_connect_thing": {Attributes: map[string]Attribute{
+					"id": {Type: str, Required: true},
+				}},
+			},
+			Functions: map[string]Function{
+				"parse": {Parameters: []string{str}, Return: str},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *Snapshot)
+		want   []Change
+	}{
+		{
+			name:   "unchanged",
+			modify: func(s *Snapshot) {},
+			want:   nil,
+		},
+		{
+			name: "attribute removed",
+			modify: func(s *Snapshot) {
+				delete(s.Resources["cofide_connect_thing"].Attributes, "description")
+			},
+			want: []Change{
+				{Breaking: true, Kind: "resource", Name: "cofide_connect_thing", Path: "description", Message: "attribute removed"},
+			},
+		},
+		{
+			name: "nested attribute removed with parent",
+			modify: func(s *Snapshot) {
+				delete(s.Resources["cofide_connect_thing"].Attributes, "config")
+				delete(s.Resources["cofide_connect_thing"].Attributes, "config.key")
+			},
+			want: []Change{
+				{Breaking: true, Kind: "resource", Name: "cofide_connect_thing", Path: "config", Message: "attribute removed"},
+			},
+		},
+		{
+			name: "optional to required",
+			modify: func(s *Snapshot) {
+				s.Resources["cofide_connect_thing"].Attributes["description"] = Attribute{Type: str, Required: true}
+			},
+			want: []Change{
+				{Breaking: true, Kind: "resource", Name: "cofide_connect_thing", Path: "description", Message: "attribute is now required"},
+				{Kind: "resource", Name: "cofide_connect_thing", Path: "description", Message: "optional/computed changed"},
+			},
+		},
+		{
+			name: "type changed",
+			modify: func(s *Snapshot) {
+				s.Resources["cofide_connect_thing"].Attributes["description"] = Attribute{Type: `"number"`, Optional: true}
+			},
+			want: []Change{
+				{Breaking: true, Kind: "resource", Name: "cofide_connect_thing", Path: "description", Message: `type changed from "string" to "number"`},
+			},
+		},
+		{
+			name: "nesting changed",
+			modify: func(s *Snapshot) {
+				s.Resources["cofide_connect_thing"].Attributes["config"] = Attribute{Nesting: "list", Optional: true}
+			},
+			want: []Change{
+				{Breaking: true, Kind: "resource", Name: "cofide_connect_thing", Path: "config", Message: "type changed from single nested to list nested"},
+			},
+		},
+		{
+			name: "requires replace added",
+			modify: func(s *Snapshot) {
+				s.Resources["cofide_connect_thing"].Attributes["name"] = Attribute{Type: str, Required: true, RequiresReplace: true}
+			},
+			want: []Change{
+				{Breaking: true, Kind: "resource", Name: "cofide_connect_thing", Path: "name", Message: "attribute now requires replacement"},
+			},
+		},
+		{
+			name: "optional attribute added",
+			modify: func(s *Snapshot) {
+				s.Resources["cofide_connect_thing"].Attributes["labels"] = Attribute{Type: `["map","string"]`, Optional: true}
+			},
+			want: []Change{
+				{Kind: "resource", Name: "cofide_connect_thing", Path: "labels", Message: "attribute added"},
+			},
+		},
+		{
+			name: "required attribute added",
+			modify: func(s *Snapshot) {
+				s.Resources["cofide_connect_thing"].Attributes["config.other"] = Attribute{Type: str, Required: true}
+			},
+			want: []Change{
+				{Breaking: true, Kind: "resource", Name: "cofide_connect_thing", Path: "config.other", Message: "attribute added"},
+			},
+		},
+		{
+			name: "required attribute added within new attribute",
+			modify: func(s *Snapshot) {
+				s.Resources["cofide_connect_thing"].Attributes["extra"] = Attribute{Nesting: "single", Optional: true}
+				s.Resources["cofide_connect_thing"].Attributes["extra.key"] = Attribute{Type: str, Required: true}
+			},
+			want: []Change{
+				{Kind: "resource", Name: "cofide_connect_thing", Path: "extra", Message: "attribute added"},
+			},
+		},
+		{
+			name: "data source removed",
+			modify: func(s *Snapshot) {
+				delete(s.DataSources, "cofide_connect_thing")
+			},
+			want: []Change{
+				{Breaking: true, Kind: "data source", Name: "cofide_connect_thing", Message: "data source removed"},
+			},
+		},
+		{
+			name: "resource added",
+			modify: func(s *Snapshot) {
+				s.Resources["cofide_connect_other"] = Schema{Attributes: map[string]Attribute{}}
+			},
+			want: []Change{
+				{Kind: "resource", Name: "cofide_connect_other", Message: "resource added"},
+			},
+		},
+		{
+			name: "function signature changed",
+			modify: func(s *Snapshot) {
+				s.Functions["parse"] = Function{Parameters: []string{str, str}, Return: str}
+			},
+			want: []Change{
+				{Breaking: true, Kind: "function", Name: "parse", Message: "signature changed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			new := base()
+			tt.modify(new)
+			assert.Equal(t, tt.want, Diff(base(), new))
+		})
+	}
+}
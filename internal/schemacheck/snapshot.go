@@ -0,0 +1,309 @@
+// Package schemacheck serialises the provider schema to a JSON snapshot and
+// classifies the differences between two snapshots, so that changes which
+// would break existing state or configuration are caught before release.
+package schemacheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Snapshot is the serialisable form of a provider schema.
+type Snapshot struct {
+	Resources   map[string]Schema   `json:"resources"`
+	DataSources map[string]Schema   `json:"data_sources"`
+	Functions   map[string]Function `json:"functions"`
+}
+
+// Schema describes a single resource or data source. Nested attributes are
+// flattened into Attributes using dotted paths (e.g. `trust_provider.kind`).
+type Schema struct {
+	Version    int64                `json:"version,omitempty"`
+	Attributes map[string]Attribute `json:"attributes"`
+}
+
+// Attribute records the properties of an attribute that affect compatibility.
+// Type is the JSON form of the Terraform type for leaf attributes; nested
+// attributes record their Nesting mode instead and their children are listed
+// separately.
+type Attribute struct {
+	Type            string `json:"type,omitempty"`
+	Nesting         string `json:"nesting,omitempty"`
+	Required        bool   `json:"required,omitempty"`
+	Optional        bool   `json:"optional,omitempty"`
+	Computed        bool   `json:"computed,omitempty"`
+	Sensitive       bool   `json:"sensitive,omitempty"`
+	RequiresReplace bool   `json:"requires_replace,omitempty"`
+}
+
+// Function describes the signature of a provider-defined function.
+type Function struct {
+	Parameters        []string `json:"parameters"`
+	VariadicParameter string   `json:"variadic_parameter,omitempty"`
+	Return            string   `json:"return"`
+}
+
+// schemaAttribute is implemented by attributes of both resource and data
+// source schemas.
+type schemaAttribute interface {
+	GetType() attr.Type
+	IsRequired() bool
+	IsOptional() bool
+	IsComputed() bool
+	IsSensitive() bool
+}
+
+// Take builds a snapshot of every resource, data source and function
+// registered by the provider.
+func Take(ctx context.Context, p provider.Provider) (*Snapshot, error) {
+	metadata := &provider.MetadataResponse{}
+	p.Metadata(ctx, provider.MetadataRequest{}, metadata)
+
+	snapshot := &Snapshot{
+		Resources:   map[string]Schema{},
+		DataSources: map[string]Schema{},
+		Functions:   map[string]Function{},
+	}
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		metadataResp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: metadata.TypeName}, metadataResp)
+
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+		if schemaResp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("resource %s: %v", metadataResp.TypeName, schemaResp.Diagnostics)
+		}
+
+		attributes := map[string]Attribute{}
+		if err := addResourceAttributes(ctx, attributes, "", schemaResp.Schema.Attributes); err != nil {
+			return nil, fmt.Errorf("resource %s: %w", metadataResp.TypeName, err)
+		}
+		snapshot.Resources[metadataResp.TypeName] = Schema{
+			Version:    schemaResp.Schema.Version,
+			Attributes: attributes,
+		}
+	}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+		metadataResp := &datasource.MetadataResponse{}
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: metadata.TypeName}, metadataResp)
+
+		schemaResp := &datasource.SchemaResponse{}
+		d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+		if schemaResp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("data source %s: %v", metadataResp.TypeName, schemaResp.Diagnostics)
+		}
+
+		attributes := map[string]Attribute{}
+		if err := addDataSourceAttributes(ctx, attributes, "", schemaResp.Schema.Attributes); err != nil {
+			return nil, fmt.Errorf("data source %s: %w", metadataResp.TypeName, err)
+		}
+		snapshot.DataSources[metadataResp.TypeName] = Schema{Attributes: attributes}
+	}
+
+	if p, ok := p.(provider.ProviderWithFunctions); ok {
+		for _, newFunction := range p.Functions(ctx) {
+			f := newFunction()
+			metadataResp := &function.MetadataResponse{}
+			f.Metadata(ctx, function.MetadataRequest{}, metadataResp)
+
+			definitionResp := &function.DefinitionResponse{}
+			f.Definition(ctx, function.DefinitionRequest{}, definitionResp)
+
+			fn, err := functionFromDefinition(ctx, definitionResp.Definition)
+			if err != nil {
+				return nil, fmt.Errorf("function %s: %w", metadataResp.Name, err)
+			}
+			snapshot.Functions[metadataResp.Name] = fn
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Load reads a snapshot previously written by Write.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// Marshal returns the indented JSON form of the snapshot. Map keys are
+// sorted, so the output is stable and diffs cleanly under version control.
+func (s *Snapshot) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func addResourceAttributes(ctx context.Context, attributes map[string]Attribute, prefix string, schemaAttributes map[string]schema.Attribute) error {
+	for name, a := range schemaAttributes {
+		path := prefix + name
+
+		var (
+			nesting  string
+			children map[string]schema.Attribute
+		)
+		switch a := a.(type) {
+		case schema.SingleNestedAttribute:
+			nesting, children = "single", a.Attributes
+		case schema.ListNestedAttribute:
+			nesting, children = "list", a.NestedObject.Attributes
+		case schema.SetNestedAttribute:
+			nesting, children = "set", a.NestedObject.Attributes
+		case schema.MapNestedAttribute:
+			nesting, children = "map", a.NestedObject.Attributes
+		}
+
+		snapshotAttribute, err := newAttribute(ctx, a, nesting)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		snapshotAttribute.RequiresReplace = requiresReplace(ctx, a)
+		attributes[path] = snapshotAttribute
+
+		if children != nil {
+			if err := addResourceAttributes(ctx, attributes, path+".", children); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func addDataSourceAttributes(ctx context.Context, attributes map[string]Attribute, prefix string, schemaAttributes map[string]dsschema.Attribute) error {
+	for name, a := range schemaAttributes {
+		path := prefix + name
+
+		var (
+			nesting  string
+			children map[string]dsschema.Attribute
+		)
+		switch a := a.(type) {
+		case dsschema.SingleNestedAttribute:
+			nesting, children = "single", a.Attributes
+		case dsschema.ListNestedAttribute:
+			nesting, children = "list", a.NestedObject.Attributes
+		case dsschema.SetNestedAttribute:
+			nesting, children = "set", a.NestedObject.Attributes
+		case dsschema.MapNestedAttribute:
+			nesting, children = "map", a.NestedObject.Attributes
+		}
+
+		snapshotAttribute, err := newAttribute(ctx, a, nesting)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		attributes[path] = snapshotAttribute
+
+		if children != nil {
+			if err := addDataSourceAttributes(ctx, attributes, path+".", children); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newAttribute(ctx context.Context, a schemaAttribute, nesting string) (Attribute, error) {
+	snapshotAttribute := Attribute{
+		Nesting:   nesting,
+		Required:  a.IsRequired(),
+		Optional:  a.IsOptional(),
+		Computed:  a.IsComputed(),
+		Sensitive: a.IsSensitive(),
+	}
+	if nesting == "" {
+		t, err := typeString(ctx, a.GetType())
+		if err != nil {
+			return Attribute{}, err
+		}
+		snapshotAttribute.Type = t
+	}
+	return snapshotAttribute, nil
+}
+
+// requiresReplace reports whether any of the attribute's plan modifiers
+// forces replacement. The framework's RequiresReplace modifiers are
+// unexported types, so they are recognised by their description, which is
+// shared across all attribute types.
+func requiresReplace(ctx context.Context, a schema.Attribute) bool {
+	modifiers := reflect.ValueOf(a).FieldByName("PlanModifiers")
+	if !modifiers.IsValid() {
+		return false
+	}
+
+	for i := range modifiers.Len() {
+		modifier, ok := modifiers.Index(i).Interface().(interface {
+			Description(context.Context) string
+		})
+		if ok && strings.Contains(modifier.Description(ctx), "destroy and recreate the resource") {
+			return true
+		}
+	}
+	return false
+}
+
+func functionFromDefinition(ctx context.Context, definition function.Definition) (Function, error) {
+	fn := Function{Parameters: []string{}}
+
+	for _, parameter := range definition.Parameters {
+		t, err := typeString(ctx, parameter.GetType())
+		if err != nil {
+			return Function{}, err
+		}
+		fn.Parameters = append(fn.Parameters, t)
+	}
+
+	if definition.VariadicParameter != nil {
+		t, err := typeString(ctx, definition.VariadicParameter.GetType())
+		if err != nil {
+			return Function{}, err
+		}
+		fn.VariadicParameter = t
+	}
+
+	if definition.Return != nil {
+		t, err := typeString(ctx, definition.Return.GetType())
+		if err != nil {
+			return Function{}, err
+		}
+		fn.Return = t
+	}
+
+	return fn, nil
+}
+
+// typeString returns the JSON type signature Terraform uses for t, e.g.
+// `"string"` or `["list","string"]`.
+func typeString(ctx context.Context, t attr.Type) (string, error) {
+	data, err := t.TerraformType(ctx).MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,27 @@
+package apbinding
+
+import (
+	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func protoToModel(binding *apbindingpb.APBinding) APBindingModel {
+	model := APBindingModel{
+		ID:          tftypes.StringValue(binding.GetId()),
+		OrgID:       tftypes.StringValue(binding.GetOrgId()),
+		TrustZoneID: tftypes.StringValue(binding.GetTrustZoneId()),
+		PolicyID:    tftypes.StringValue(binding.GetPolicyId()),
+	}
+
+	if binding.GetFederations() != nil {
+		federations := make([]APBindingFederationModel, 0, len(binding.GetFederations()))
+		for _, federation := range binding.GetFederations() {
+			federations = append(federations, APBindingFederationModel{
+				TrustZoneID: tftypes.StringValue(federation.GetTrustZoneId()),
+			})
+		}
+		model.Federations = federations
+	}
+
+	return model
+}
@@ -0,0 +1,82 @@
+package apbinding
+
+import (
+	"context"
+	"fmt"
+
+	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
+	apbindinginsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/ap_binding_service/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+)
+
+var (
+	_ list.ListResource              = &APBindingResource{}
+	_ list.ListResourceWithConfigure = &APBindingResource{}
+)
+
+// NewListResource returns the list resource for attestation policy bindings, which lets
+// `terraform query` enumerate existing attestation policy bindings for import.
+func NewListResource() list.ListResource {
+	return &APBindingResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists Cofide Connect attestation policy bindings.",
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: "Filter by organization ID.",
+				Optional:    true,
+			},
+			"trust_zone_id": listschema.StringAttribute{
+				Description: "Filter by trust zone ID.",
+				Optional:    true,
+			},
+			"policy_id": listschema.StringAttribute{
+				Description: "Filter by attestation policy ID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *APBindingResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (a *APBindingResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config APBindingListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &apbindinginsvcpb.ListAPBindingsRequest_Filter{
+		OrgId:       config.OrgID.ValueStringPointer(),
+		TrustZoneId: config.TrustZoneID.ValueStringPointer(),
+		PolicyId:    config.PolicyID.ValueStringPointer(),
+	}
+	bindings, err := a.client.APBindingV1Alpha1().ListAPBindings(ctx, filter)
+	if err != nil {
+		diags.AddError(
+			"Error listing attestation policy bindings",
+			fmt.Sprintf("Could not list attestation policy bindings: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.ListResults(ctx, req, bindings, func(binding *apbindingpb.APBinding) (util.ListResult, diag.Diagnostics) {
+		model := protoToModel(binding)
+		return util.ListResult{
+			DisplayName: binding.GetId(),
+			Resource:    model,
+			Identity:    newIdentityModel(model),
+		}, nil
+	})
+}
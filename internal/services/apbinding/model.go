@@ -13,3 +13,10 @@ type APBindingModel struct {
 type APBindingFederationModel struct {
 	TrustZoneID types.String `tfsdk:"trust_zone_id"`
 }
+
+// APBindingListModel is the configuration of the attestation policy binding list resource.
+type APBindingListModel struct {
+	OrgID       types.String `tfsdk:"org_id"`
+	TrustZoneID types.String `tfsdk:"trust_zone_id"`
+	PolicyID    types.String `tfsdk:"policy_id"`
+}
@@ -7,6 +7,7 @@ import (
 	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
 	apbindinginsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/ap_binding_service/v1alpha1"
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
@@ -96,6 +97,7 @@ func (a *APBindingResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state))...)
 }
 
 func (a *APBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -136,24 +138,10 @@ func (a *APBindingResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	newState := APBindingModel{
-		ID:          tftypes.StringValue(foundBinding.GetId()),
-		OrgID:       tftypes.StringValue(foundBinding.GetOrgId()),
-		TrustZoneID: tftypes.StringValue(foundBinding.GetTrustZoneId()),
-		PolicyID:    tftypes.StringValue(foundBinding.GetPolicyId()),
-	}
-
-	if foundBinding.GetFederations() != nil {
-		federations := make([]APBindingFederationModel, 0, len(foundBinding.GetFederations()))
-		for _, federation := range foundBinding.GetFederations() {
-			federations = append(federations, APBindingFederationModel{
-				TrustZoneID: tftypes.StringValue(federation.GetTrustZoneId()),
-			})
-		}
-		newState.Federations = federations
-	}
+	newState := protoToModel(foundBinding)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
 }
 
 func (a *APBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -168,7 +156,6 @@ func (a *APBindingResource) Update(ctx context.Context, req resource.UpdateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	bindingID := state.ID.ValueString()
 
 	federations := make([]*apbindingpb.APBindingFederation, 0)
 	for _, federation := range plan.Federations {
@@ -178,13 +165,10 @@ func (a *APBindingResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	binding := &apbindingpb.APBinding{
-		Id:          &bindingID,
-		TrustZoneId: plan.TrustZoneID.ValueStringPointer(),
-		PolicyId:    plan.PolicyID.ValueStringPointer(),
 		Federations: federations,
 	}
 
-	updateResp, err := a.client.APBindingV1Alpha1().UpdateAPBinding(ctx, binding)
+	updateResp, err := updateAPBinding(ctx, a.client.APBindingV1Alpha1(), binding, state)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating AP binding", err.Error())
 		return
@@ -208,6 +192,7 @@ func (a *APBindingResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
 }
 
 func (a *APBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -232,7 +217,16 @@ func (a *APBindingResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (a *APBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		id, err := importid.APBinding(ctx, a.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing AP binding", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func (a *APBindingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
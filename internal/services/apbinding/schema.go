@@ -3,6 +3,7 @@ package apbinding
 import (
 	"context"
 
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -11,10 +12,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ resource.ResourceWithConfigValidators = (*APBindingResource)(nil)
+var (
+	_ resource.ResourceWithConfigValidators = (*APBindingResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*APBindingResource)(nil)
+)
 
 func ResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a Cofide Connect attestation policy binding. Binds an attestation policy to a trust zone, controlling which workloads receive SPIFFE IDs in that zone.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -32,12 +37,18 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 				},
 			},
 			"trust_zone_id": schema.StringAttribute{
-				Description: "The ID of the trust zone.",
+				Description: "The ID of the trust zone. Cannot be changed after creation.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"policy_id": schema.StringAttribute{
-				Description: "The ID of the attestation policy.",
+				Description: "The ID of the attestation policy. Cannot be changed after creation.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"federations": schema.ListAttribute{
 				Description: "The federated trust zones which will be visible to workloads matching the policy in this binding. Each entry specifies the `trust_zone_id` of a federated trust zone.",
@@ -56,6 +67,13 @@ func (a *APBindingResource) Schema(ctx context.Context, req resource.SchemaReque
 	resp.Schema = ResourceSchema(ctx)
 }
 
+func (a *APBindingResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 1 made trust_zone_id and policy_id force replacement.
+		0: util.UnchangedStateUpgrader(ResourceSchema(ctx)),
+	}
+}
+
 func (a *APBindingResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{}
 }
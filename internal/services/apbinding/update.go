@@ -0,0 +1,23 @@
+package apbinding
+
+import (
+	"context"
+
+	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
+)
+
+// apBindingClient is the part of the AP binding API used to update an AP
+// binding.
+type apBindingClient interface {
+	UpdateAPBinding(ctx context.Context, binding *apbindingpb.APBinding) (*apbindingpb.APBinding, error)
+}
+
+// updateAPBinding updates the AP binding in state to binding. The trust zone
+// and policy cannot be changed after creation and are sent as they are in
+// state, as a change to them replaces the binding rather than updating it.
+func updateAPBinding(ctx context.Context, client apBindingClient, binding *apbindingpb.APBinding, state APBindingModel) (*apbindingpb.APBinding, error) {
+	binding.Id = state.ID.ValueStringPointer()
+	binding.TrustZoneId = state.TrustZoneID.ValueStringPointer()
+	binding.PolicyId = state.PolicyID.ValueStringPointer()
+	return client.UpdateAPBinding(ctx, binding)
+}
@@ -0,0 +1,45 @@
+package apbinding
+
+import (
+	"context"
+	"testing"
+
+	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPBindingClient records the updates made.
+type fakeAPBindingClient struct {
+	updated []*apbindingpb.APBinding
+}
+
+func (c *fakeAPBindingClient) UpdateAPBinding(_ context.Context, binding *apbindingpb.APBinding) (*apbindingpb.APBinding, error) {
+	c.updated = append(c.updated, binding)
+	return binding, nil
+}
+
+func TestUpdateAPBindingKeepsTrustZoneAndPolicy(t *testing.T) {
+	client := &fakeAPBindingClient{}
+	state := APBindingModel{
+		ID:          tftypes.StringValue("apb-1"),
+		TrustZoneID: tftypes.StringValue("tz-1"),
+		PolicyID:    tftypes.StringValue("ap-1"),
+	}
+	federation := "tz-3"
+
+	_, err := updateAPBinding(context.Background(), client, &apbindingpb.APBinding{
+		TrustZoneId: &federation,
+		Federations: []*apbindingpb.APBindingFederation{{TrustZoneId: &federation}},
+	}, state)
+	require.NoError(t, err)
+
+	require.Len(t, client.updated, 1)
+	updated := client.updated[0]
+	assert.Equal(t, "apb-1", updated.GetId())
+	assert.Equal(t, "tz-1", updated.GetTrustZoneId())
+	assert.Equal(t, "ap-1", updated.GetPolicyId())
+	require.Len(t, updated.GetFederations(), 1)
+	assert.Equal(t, "tz-3", updated.GetFederations()[0].GetTrustZoneId())
+}
@@ -0,0 +1,51 @@
+package attestationpolicy
+
+import (
+	"context"
+	"fmt"
+
+	attestationpolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/attestation_policy/v1alpha1"
+	attestationpolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/attestation_policy_service/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// attestationPolicyClient is the part of the attestation policy API used to
+// adopt an existing attestation policy and to update one.
+type attestationPolicyClient interface {
+	ListAttestationPolicies(ctx context.Context, filter *attestationpolicysvcpb.ListAttestationPoliciesRequest_Filter) ([]*attestationpolicypb.AttestationPolicy, error)
+	UpdateAttestationPolicy(ctx context.Context, policy *attestationpolicypb.AttestationPolicy) (*attestationpolicypb.AttestationPolicy, error)
+}
+
+// adoptAttestationPolicy finds the existing attestation policy with the name
+// and organization of policy, which could not be created because it already
+// exists, and updates it to match policy.
+func adoptAttestationPolicy(ctx context.Context, client attestationPolicyClient, policy *attestationpolicypb.AttestationPolicy) (*attestationpolicypb.AttestationPolicy, error) {
+	name := policy.GetName()
+	orgID := policy.GetOrgId()
+
+	filter := &attestationpolicysvcpb.ListAttestationPoliciesRequest_Filter{Name: &name}
+	if orgID != "" {
+		filter.OrgId = &orgID
+	}
+	policies, err := client.ListAttestationPolicies(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("could not list attestation policies to adopt: %w", err)
+	}
+
+	existing, err := util.AdoptionCandidate("attestation policy", fmt.Sprintf("named %q", name), policies, func(p *attestationpolicypb.AttestationPolicy) bool {
+		return p.GetName() == name && (orgID == "" || p.GetOrgId() == orgID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	adopted := proto.Clone(policy).(*attestationpolicypb.AttestationPolicy)
+	adopted.Id = existing.Id
+	adopted.OrgId = existing.OrgId
+	updated, err := client.UpdateAttestationPolicy(ctx, adopted)
+	if err != nil {
+		return nil, fmt.Errorf("could not update the existing attestation policy %q (%s): %w", name, existing.GetId(), err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,52 @@
+package attestationpolicy
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = (*AttestationPolicyResource)(nil)
+
+// AttestationPolicyIdentityModel is the resource identity of an attestation policy.
+type AttestationPolicyIdentityModel struct {
+	OrgID types.String `tfsdk:"org_id"`
+	ID    types.String `tfsdk:"id"`
+}
+
+func newIdentityModel(m AttestationPolicyModel) AttestationPolicyIdentityModel {
+	return AttestationPolicyIdentityModel{
+		OrgID: m.OrgID,
+		ID:    m.ID,
+	}
+}
+
+func (r *AttestationPolicyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"org_id": identityschema.StringAttribute{
+				Description:       "The ID of the organization.",
+				OptionalForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the attestation policy.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+var _ resource.ResourceWithIdentity = (*WorkloadIdentityResource)(nil)
+
+func newWorkloadIdentityIdentityModel(m WorkloadIdentityModel) AttestationPolicyIdentityModel {
+	return AttestationPolicyIdentityModel{
+		OrgID: m.OrgID,
+		ID:    m.ID,
+	}
+}
+
+func (r *WorkloadIdentityResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	(&AttestationPolicyResource{}).IdentitySchema(ctx, req, resp)
+}
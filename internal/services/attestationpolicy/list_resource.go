@@ -0,0 +1,76 @@
+package attestationpolicy
+
+import (
+	"context"
+	"fmt"
+
+	attestationpolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/attestation_policy/v1alpha1"
+	attestationpolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/attestation_policy_service/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ list.ListResource              = &AttestationPolicyResource{}
+	_ list.ListResourceWithConfigure = &AttestationPolicyResource{}
+)
+
+// NewListResource returns the list resource for attestation policies, which lets
+// `terraform query` enumerate existing attestation policies for import.
+func NewListResource() list.ListResource {
+	return &AttestationPolicyResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists Cofide Connect attestation policies.",
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: "Filter by organization ID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *AttestationPolicyResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (r *AttestationPolicyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config AttestationPolicyListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &attestationpolicysvcpb.ListAttestationPoliciesRequest_Filter{
+		OrgId: config.OrgID.ValueStringPointer(),
+	}
+	policies, err := r.client.AttestationPolicyV1Alpha1().ListAttestationPolicies(ctx, filter)
+	if err != nil {
+		diags.AddError(
+			"Error listing attestation policies",
+			fmt.Sprintf("Could not list attestation policies: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.ListResults(ctx, req, policies, func(policy *attestationpolicypb.AttestationPolicy) (util.ListResult, diag.Diagnostics) {
+		model := protoToModel(policy)
+		return util.ListResult{
+			DisplayName: policy.GetName(),
+			Resource: AttestationPolicyResourceModel{
+				AttestationPolicyModel: model,
+				AdoptExisting:          tftypes.BoolValue(false),
+			},
+			Identity: newIdentityModel(model),
+		}, nil
+	})
+}
@@ -11,6 +11,14 @@ type AttestationPolicyModel struct {
 	TPMNode    *APTPMNodeModel    `tfsdk:"tpm_node"`
 }
 
+// AttestationPolicyResourceModel is the model of the attestation policy
+// resource, which adds attributes kept in Terraform state only to those
+// shared with the data source.
+type AttestationPolicyResourceModel struct {
+	AttestationPolicyModel
+	AdoptExisting tftypes.Bool `tfsdk:"adopt_existing"`
+}
+
 type APKubernetesModel struct {
 	NamespaceSelector    *APLabelSelectorModel `tfsdk:"namespace_selector"`
 	PodSelector          *APLabelSelectorModel `tfsdk:"pod_selector"`
@@ -45,3 +53,29 @@ type APTPMNodeModel struct {
 type TPMAttestationModel struct {
 	EKHash tftypes.String `tfsdk:"ek_hash"`
 }
+
+// AttestationPolicyListModel is the configuration of the attestation policy list resource.
+type AttestationPolicyListModel struct {
+	OrgID tftypes.String `tfsdk:"org_id"`
+}
+
+// WorkloadIdentityModel is an attestation policy together with its bindings.
+type WorkloadIdentityModel struct {
+	ID         tftypes.String     `tfsdk:"id"`
+	Name       tftypes.String     `tfsdk:"name"`
+	OrgID      tftypes.String     `tfsdk:"org_id"`
+	Kubernetes *APKubernetesModel `tfsdk:"kubernetes"`
+	Static     *APStaticModel     `tfsdk:"static"`
+	TPMNode    *APTPMNodeModel    `tfsdk:"tpm_node"`
+	Bindings   tftypes.List       `tfsdk:"bindings"`
+}
+
+type WorkloadIdentityBindingModel struct {
+	ID          tftypes.String                    `tfsdk:"id"`
+	TrustZoneID tftypes.String                    `tfsdk:"trust_zone_id"`
+	Federations []WorkloadIdentityFederationModel `tfsdk:"federations"`
+}
+
+type WorkloadIdentityFederationModel struct {
+	TrustZoneID tftypes.String `tfsdk:"trust_zone_id"`
+}
@@ -0,0 +1,61 @@
+package attestationpolicy_test
+
+import (
+	"testing"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPlanWorkloadIdentityBindings checks that the IDs of the bindings of a
+// workload identity are planned from the binding to the same trust zone, not
+// the one at the same position.
+func TestPlanWorkloadIdentityBindings(t *testing.T) {
+	static := map[string]any{
+		"spiffe_id_path": "ns/default/sa/app",
+		"parent_id_path": "node",
+		"selectors":      []any{map[string]any{"type": "k8s", "value": "ns:default"}},
+		"store_svid":     false,
+	}
+	server := providertest.NewServer(t)
+	resp := providertest.PlanUpdate(t, server, "cofide_connect_workload_identity",
+		map[string]any{
+			"id":     "ap-1",
+			"name":   "app",
+			"org_id": "org-1",
+			"static": static,
+			"bindings": []any{
+				map[string]any{"id": "apb-1", "trust_zone_id": "tz-1"},
+				map[string]any{"id": "apb-2", "trust_zone_id": "tz-2"},
+			},
+		},
+		map[string]any{
+			"name":   "app",
+			"static": map[string]any{"spiffe_id_path": "ns/default/sa/app", "parent_id_path": "node", "selectors": static["selectors"]},
+			"bindings": []any{
+				map[string]any{"trust_zone_id": "tz-3"},
+				map[string]any{"trust_zone_id": "tz-2"},
+				map[string]any{"trust_zone_id": "tz-1", "federations": []any{map[string]any{"trust_zone_id": "tz-2"}}},
+			},
+		},
+	)
+	assert.Empty(t, resp.RequiresReplace)
+
+	typ := server.ResourceSchema(t, "cofide_connect_workload_identity").ValueType()
+	attributes := providertest.Attributes(t, resp.PlannedState, typ)
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "ap-1"), attributes["id"])
+	var bindings []tftypes.Value
+	require.NoError(t, attributes["bindings"].As(&bindings))
+	require.Len(t, bindings, 3)
+
+	plannedID := func(i int) tftypes.Value {
+		var attributes map[string]tftypes.Value
+		require.NoError(t, bindings[i].As(&attributes))
+		return attributes["id"]
+	}
+	assert.False(t, plannedID(0).IsKnown())
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "apb-2"), plannedID(1))
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "apb-1"), plannedID(2))
+}
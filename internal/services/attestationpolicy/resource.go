@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -48,20 +51,23 @@ func (r *AttestationPolicyResource) Configure(_ context.Context, req resource.Co
 }
 
 func (r *AttestationPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan AttestationPolicyModel
+	var plan AttestationPolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	policy, diags := modelToProto(ctx, plan)
+	policy, diags := modelToProto(ctx, plan.AttestationPolicyModel)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	createResp, err := r.client.AttestationPolicyV1Alpha1().CreateAttestationPolicy(ctx, policy)
+	if util.ShouldAdopt(plan.AdoptExisting, err) {
+		createResp, err = adoptAttestationPolicy(ctx, r.client.AttestationPolicyV1Alpha1(), policy)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating attestation policy",
@@ -70,12 +76,16 @@ func (r *AttestationPolicyResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	state := protoToModel(createResp)
+	state := AttestationPolicyResourceModel{
+		AttestationPolicyModel: protoToModel(createResp),
+		AdoptExisting:          plan.AdoptExisting,
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state.AttestationPolicyModel))...)
 }
 
 func (r *AttestationPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state AttestationPolicyModel
+	var state AttestationPolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -96,23 +106,39 @@ func (r *AttestationPolicyResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	newState := protoToModel(policy)
+	newState := AttestationPolicyResourceModel{
+		AttestationPolicyModel: protoToModel(policy),
+		AdoptExisting:          tftypes.BoolValue(state.AdoptExisting.ValueBool()),
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState.AttestationPolicyModel))...)
 }
 
 func (r *AttestationPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state AttestationPolicyModel
+	var state AttestationPolicyResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var plan AttestationPolicyModel
+	var plan AttestationPolicyResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	stateOnly, err := util.IsStateOnlyUpdate(req, "adopt_existing")
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating attestation policy", err.Error())
+		return
+	}
+	if stateOnly {
+		state.AdoptExisting = plan.AdoptExisting
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state.AttestationPolicyModel))...)
+		return
+	}
+
 	policyID := state.ID.ValueString()
 	if policyID == "" {
 		resp.Diagnostics.AddError(
@@ -122,20 +148,13 @@ func (r *AttestationPolicyResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	policy, diags := modelToProto(ctx, plan)
+	policy, diags := modelToProto(ctx, plan.AttestationPolicyModel)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	policy.Id = &policyID
-	if (policy.OrgId == nil || *policy.OrgId == "") && !state.OrgID.IsNull() && !state.OrgID.IsUnknown() {
-		orgID := state.OrgID.ValueString()
-		if orgID != "" {
-			policy.OrgId = &orgID
-		}
-	}
 
-	updateResp, err := r.client.AttestationPolicyV1Alpha1().UpdateAttestationPolicy(ctx, policy)
+	updateResp, err := updateAttestationPolicy(ctx, r.client.AttestationPolicyV1Alpha1(), policy, state.AttestationPolicyModel)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating attestation policy",
@@ -144,12 +163,16 @@ func (r *AttestationPolicyResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	newState := protoToModel(updateResp)
+	newState := AttestationPolicyResourceModel{
+		AttestationPolicyModel: protoToModel(updateResp),
+		AdoptExisting:          plan.AdoptExisting,
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState.AttestationPolicyModel))...)
 }
 
 func (r *AttestationPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state AttestationPolicyModel
+	var state AttestationPolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -169,5 +192,14 @@ func (r *AttestationPolicyResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *AttestationPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		id, err := importid.AttestationPolicy(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing attestation policy", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
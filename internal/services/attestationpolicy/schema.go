@@ -4,32 +4,50 @@ import (
 	"context"
 	"reflect"
 
+	"github.com/cofide/terraform-provider-cofide/internal/planmodifiers"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ resource.ResourceWithConfigValidators = (*AttestationPolicyResource)(nil)
+var (
+	_ resource.ResourceWithConfigValidators = (*AttestationPolicyResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*AttestationPolicyResource)(nil)
+)
 
 func ResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a Cofide Connect attestation policy. Attestation policies define how workloads are identified and what SPIFFE IDs they receive. Exactly one of `kubernetes`, `static`, or `tpm_node` must be configured.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The ID of the attestation policy.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Description: "The name of the attestation policy.",
 				Required:    true,
 			},
 			"org_id": schema.StringAttribute{
-				Description: "The ID of the organization.",
+				Description: "The ID of the organization. Cannot be changed after creation.",
 				Optional:    true,
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.OptionalComputedModifier{},
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
+			"adopt_existing": util.AdoptExistingAttribute("attestation policy", "name and organization"),
 			"kubernetes": schema.SingleNestedAttribute{
 				Description: "The configuration of the Kubernetes attestation policy.",
 				Optional:    true,
@@ -184,6 +202,13 @@ func (a *AttestationPolicyResource) Schema(ctx context.Context, req resource.Sch
 	resp.Schema = ResourceSchema(ctx)
 }
 
+func (a *AttestationPolicyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 1 made org_id force replacement.
+		0: util.UnchangedStateUpgrader(ResourceSchema(ctx)),
+	}
+}
+
 type exactlyOneOfValidator struct{}
 
 var _ resource.ConfigValidator = exactlyOneOfValidator{}
@@ -197,14 +222,20 @@ func (v exactlyOneOfValidator) MarkdownDescription(ctx context.Context) string {
 }
 
 func (v exactlyOneOfValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data AttestationPolicyModel
+	// The attributes are read individually so that the validator also
+	// applies to the workload identity resource, which embeds the policy.
+	var kubernetes *APKubernetesModel
+	var static *APStaticModel
+	var tpmNode *APTPMNodeModel
 
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("kubernetes"), &kubernetes)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("static"), &static)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tpm_node"), &tpmNode)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	valid, reason := isExactlyOneNonNil(data.Kubernetes, data.Static, data.TPMNode)
+	valid, reason := isExactlyOneNonNil(kubernetes, static, tpmNode)
 	if !valid {
 		resp.Diagnostics.AddError(
 			"Invalid configuration",
@@ -0,0 +1,19 @@
+package attestationpolicy
+
+import (
+	"context"
+
+	attestationpolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/attestation_policy/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+)
+
+// updateAttestationPolicy updates the attestation policy in state to policy.
+// The organization cannot be changed after creation and is sent as it is in
+// state, as a change to it replaces the policy rather than updating it.
+func updateAttestationPolicy(ctx context.Context, client attestationPolicyClient, policy *attestationpolicypb.AttestationPolicy, state AttestationPolicyModel) (*attestationpolicypb.AttestationPolicy, error) {
+	policy.Id = state.ID.ValueStringPointer()
+	if util.IsStringAttributeNonEmpty(state.OrgID) {
+		policy.OrgId = state.OrgID.ValueStringPointer()
+	}
+	return client.UpdateAttestationPolicy(ctx, policy)
+}
@@ -0,0 +1,65 @@
+package attestationpolicy
+
+import (
+	"context"
+	"testing"
+
+	attestationpolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/attestation_policy/v1alpha1"
+	attestationpolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/attestation_policy_service/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAttestationPolicyClient records the updates made.
+type fakeAttestationPolicyClient struct {
+	updated []*attestationpolicypb.AttestationPolicy
+}
+
+func (c *fakeAttestationPolicyClient) ListAttestationPolicies(_ context.Context, _ *attestationpolicysvcpb.ListAttestationPoliciesRequest_Filter) ([]*attestationpolicypb.AttestationPolicy, error) {
+	return nil, nil
+}
+
+func (c *fakeAttestationPolicyClient) UpdateAttestationPolicy(_ context.Context, policy *attestationpolicypb.AttestationPolicy) (*attestationpolicypb.AttestationPolicy, error) {
+	c.updated = append(c.updated, policy)
+	return policy, nil
+}
+
+func TestUpdateAttestationPolicyKeepsOrganization(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    *attestationpolicypb.AttestationPolicy
+		wantOrgID string
+	}{
+		{
+			name:      "organization changed",
+			policy:    &attestationpolicypb.AttestationPolicy{Name: "renamed", OrgId: ptr("org-2")},
+			wantOrgID: "org-1",
+		},
+		{
+			name:      "organization not configured",
+			policy:    &attestationpolicypb.AttestationPolicy{Name: "renamed"},
+			wantOrgID: "org-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeAttestationPolicyClient{}
+			state := AttestationPolicyModel{
+				ID:    tftypes.StringValue("ap-1"),
+				Name:  tftypes.StringValue("policy"),
+				OrgID: tftypes.StringValue("org-1"),
+			}
+
+			_, err := updateAttestationPolicy(context.Background(), client, tt.policy, state)
+			require.NoError(t, err)
+
+			require.Len(t, client.updated, 1)
+			updated := client.updated[0]
+			assert.Equal(t, "ap-1", updated.GetId())
+			assert.Equal(t, "renamed", updated.GetName())
+			assert.Equal(t, tt.wantOrgID, updated.GetOrgId())
+		})
+	}
+}
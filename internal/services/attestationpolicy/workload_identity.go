@@ -0,0 +1,213 @@
+package attestationpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
+	apbindingsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/ap_binding_service/v1alpha1"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// apBindingClient is the part of the attestation policy binding API used to
+// reconcile the bindings of a workload identity.
+type apBindingClient interface {
+	CreateAPBinding(ctx context.Context, binding *apbindingpb.APBinding) (*apbindingpb.APBinding, error)
+	ListAPBindings(ctx context.Context, filter *apbindingsvcpb.ListAPBindingsRequest_Filter) ([]*apbindingpb.APBinding, error)
+	UpdateAPBinding(ctx context.Context, binding *apbindingpb.APBinding) (*apbindingpb.APBinding, error)
+	DestroyAPBinding(ctx context.Context, id string) error
+}
+
+// listPolicyBindings returns the bindings of an attestation policy.
+func listPolicyBindings(ctx context.Context, client apBindingClient, policyID string) ([]*apbindingpb.APBinding, error) {
+	bindings, err := client.ListAPBindings(ctx, &apbindingsvcpb.ListAPBindingsRequest_Filter{
+		PolicyId: &policyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list the bindings of attestation policy %q: %w", policyID, err)
+	}
+
+	return slices.DeleteFunc(slices.Clone(bindings), func(binding *apbindingpb.APBinding) bool {
+		return binding.GetPolicyId() != policyID
+	}), nil
+}
+
+// orderBindings returns bindings with those to the trust zones in
+// trustZoneIDs first, in the order of trustZoneIDs, followed by the rest
+// sorted by trust zone.
+func orderBindings(bindings []*apbindingpb.APBinding, trustZoneIDs []string) []*apbindingpb.APBinding {
+	ordered := slices.Clone(bindings)
+	rank := func(binding *apbindingpb.APBinding) int {
+		if i := slices.Index(trustZoneIDs, binding.GetTrustZoneId()); i >= 0 {
+			return i
+		}
+		return len(trustZoneIDs)
+	}
+	slices.SortStableFunc(ordered, func(a, b *apbindingpb.APBinding) int {
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return ra - rb
+		}
+		return strings.Compare(a.GetTrustZoneId(), b.GetTrustZoneId())
+	})
+	return ordered
+}
+
+// reconcileBindings makes desired the complete set of bindings of the
+// attestation policy policyID, given its existing bindings. Desired bindings
+// are matched to existing ones by trust zone; those whose federations differ
+// are updated and those that are missing are created. The remaining existing
+// bindings are then deleted, as are any to a trust zone already matched.
+//
+// Every change is attempted even if some fail. The bindings afterwards are
+// returned, those for desired first and in the same order, along with the
+// failures.
+func reconcileBindings(ctx context.Context, client apBindingClient, policyID string, desired []WorkloadIdentityBindingModel, existing []*apbindingpb.APBinding) ([]*apbindingpb.APBinding, error) {
+	remaining := slices.Clone(existing)
+
+	var errs []error
+	var result []*apbindingpb.APBinding
+	for _, model := range desired {
+		trustZoneID := model.TrustZoneID.ValueString()
+
+		var current *apbindingpb.APBinding
+		if i := slices.IndexFunc(remaining, func(b *apbindingpb.APBinding) bool { return b.GetTrustZoneId() == trustZoneID }); i >= 0 {
+			current = remaining[i]
+			remaining = slices.Delete(remaining, i, i+1)
+		}
+
+		binding := &apbindingpb.APBinding{
+			TrustZoneId: &trustZoneID,
+			PolicyId:    &policyID,
+			Federations: bindingFederations(model),
+		}
+
+		switch {
+		case current == nil:
+			created, err := client.CreateAPBinding(ctx, binding)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not bind the policy to trust zone %q: %w", trustZoneID, err))
+				continue
+			}
+			result = append(result, created)
+		case slices.Equal(federationTrustZoneIDs(current.GetFederations()), federationTrustZoneIDs(binding.GetFederations())):
+			result = append(result, current)
+		default:
+			binding.Id = current.Id
+			updated, err := client.UpdateAPBinding(ctx, binding)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not update the binding to trust zone %q: %w", trustZoneID, err))
+				result = append(result, current)
+				continue
+			}
+			result = append(result, updated)
+		}
+	}
+
+	for _, binding := range remaining {
+		err := client.DestroyAPBinding(ctx, binding.GetId())
+		if err != nil && status.Code(err) != codes.NotFound {
+			errs = append(errs, fmt.Errorf("could not delete the binding %q to trust zone %q: %w", binding.GetId(), binding.GetTrustZoneId(), err))
+			result = append(result, binding)
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func bindingFederations(model WorkloadIdentityBindingModel) []*apbindingpb.APBindingFederation {
+	federations := make([]*apbindingpb.APBindingFederation, 0, len(model.Federations))
+	for _, federation := range model.Federations {
+		federations = append(federations, &apbindingpb.APBindingFederation{
+			TrustZoneId: federation.TrustZoneID.ValueStringPointer(),
+		})
+	}
+	return federations
+}
+
+func federationTrustZoneIDs(federations []*apbindingpb.APBindingFederation) []string {
+	trustZoneIDs := make([]string, 0, len(federations))
+	for _, federation := range federations {
+		trustZoneIDs = append(trustZoneIDs, federation.GetTrustZoneId())
+	}
+	return trustZoneIDs
+}
+
+// bindingsToList returns the list value of bindings. The API does not tell an
+// empty list of federations from none, so a binding without federations has
+// an empty list if its trust zone has one in prior, the planned or prior
+// bindings, and null otherwise.
+func bindingsToList(ctx context.Context, bindings []*apbindingpb.APBinding, prior tftypes.List) (tftypes.List, diag.Diagnostics) {
+	var priorModels []WorkloadIdentityBindingModel
+	diags := prior.ElementsAs(ctx, &priorModels, true)
+	if diags.HasError() {
+		return tftypes.ListNull(workloadIdentityBindingType()), diags
+	}
+
+	models := make([]WorkloadIdentityBindingModel, 0, len(bindings))
+	for _, binding := range bindings {
+		model := WorkloadIdentityBindingModel{
+			ID:          tftypes.StringValue(binding.GetId()),
+			TrustZoneID: tftypes.StringValue(binding.GetTrustZoneId()),
+		}
+		if slices.ContainsFunc(priorModels, func(m WorkloadIdentityBindingModel) bool {
+			return m.TrustZoneID.ValueString() == binding.GetTrustZoneId() && m.Federations != nil
+		}) {
+			model.Federations = []WorkloadIdentityFederationModel{}
+		}
+		for _, federation := range binding.GetFederations() {
+			model.Federations = append(model.Federations, WorkloadIdentityFederationModel{
+				TrustZoneID: tftypes.StringValue(federation.GetTrustZoneId()),
+			})
+		}
+		models = append(models, model)
+	}
+	list, d := tftypes.ListValueFrom(ctx, workloadIdentityBindingType(), models)
+	diags.Append(d...)
+	return list, diags
+}
+
+// bindingTrustZoneIDs returns the trust zones of the bindings in list, which
+// may be null.
+func bindingTrustZoneIDs(ctx context.Context, list tftypes.List) ([]string, diag.Diagnostics) {
+	var models []WorkloadIdentityBindingModel
+	diags := list.ElementsAs(ctx, &models, true)
+
+	trustZoneIDs := make([]string, 0, len(models))
+	for _, model := range models {
+		trustZoneIDs = append(trustZoneIDs, model.TrustZoneID.ValueString())
+	}
+	return trustZoneIDs, diags
+}
+
+// workloadIdentityToModel returns the model of an attestation policy and its
+// bindings, given the planned or prior bindings.
+func workloadIdentityToModel(ctx context.Context, policy AttestationPolicyModel, bindings []*apbindingpb.APBinding, prior tftypes.List) (WorkloadIdentityModel, diag.Diagnostics) {
+	list, diags := bindingsToList(ctx, bindings, prior)
+	return WorkloadIdentityModel{
+		ID:         policy.ID,
+		Name:       policy.Name,
+		OrgID:      policy.OrgID,
+		Kubernetes: policy.Kubernetes,
+		Static:     policy.Static,
+		TPMNode:    policy.TPMNode,
+		Bindings:   list,
+	}, diags
+}
+
+// policyOf returns the attestation policy part of a workload identity.
+func policyOf(model WorkloadIdentityModel) AttestationPolicyModel {
+	return AttestationPolicyModel{
+		ID:         model.ID,
+		Name:       model.Name,
+		OrgID:      model.OrgID,
+		Kubernetes: model.Kubernetes,
+		Static:     model.Static,
+		TPMNode:    model.TPMNode,
+	}
+}
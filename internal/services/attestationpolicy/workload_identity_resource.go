@@ -0,0 +1,391 @@
+package attestationpolicy
+
+import (
+	"context"
+	"fmt"
+
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ resource.Resource                   = &WorkloadIdentityResource{}
+	_ resource.ResourceWithImportState    = &WorkloadIdentityResource{}
+	_ resource.ResourceWithModifyPlan     = &WorkloadIdentityResource{}
+	_ resource.ResourceWithValidateConfig = &WorkloadIdentityResource{}
+)
+
+type WorkloadIdentityResource struct {
+	client sdkclient.ClientSet
+}
+
+func NewWorkloadIdentityResource() resource.Resource {
+	return &WorkloadIdentityResource{}
+}
+
+func (r *WorkloadIdentityResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connect_workload_identity"
+}
+
+func (r *WorkloadIdentityResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdkclient.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected sdkclient.ClientSet, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *WorkloadIdentityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan WorkloadIdentityModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var desired []WorkloadIdentityBindingModel
+	resp.Diagnostics.Append(plan.Bindings.ElementsAs(ctx, &desired, false)...)
+
+	policy, diags := modelToProto(ctx, policyOf(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies := r.client.AttestationPolicyV1Alpha1()
+	created, err := policies.CreateAttestationPolicy(ctx, policy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating workload identity",
+			fmt.Sprintf("Could not create attestation policy: %s", err),
+		)
+		return
+	}
+
+	bindingClient := r.client.APBindingV1Alpha1()
+	policyID := created.GetId()
+	bindings, err := reconcileBindings(ctx, bindingClient, policyID, desired, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating workload identity",
+			fmt.Sprintf("Could not bind attestation policy %q: %s", policyID, err),
+		)
+
+		// Roll back by deleting the bindings and then the policy.
+		remaining, err := reconcileBindings(ctx, bindingClient, policyID, nil, bindings)
+		if err == nil {
+			err = policies.DestroyAttestationPolicy(ctx, policyID)
+			if err == nil || status.Code(err) == codes.NotFound {
+				return
+			}
+		}
+		resp.Diagnostics.AddError(
+			"Error rolling back workload identity",
+			fmt.Sprintf("Could not delete attestation policy %q and its bindings: %s", policyID, err),
+		)
+		// What remains is kept in state, which Terraform marks as tainted
+		// so that it is deleted on the next apply.
+		bindings = remaining
+	}
+
+	newState, diags := workloadIdentityToModel(ctx, protoToModel(created), bindings, plan.Bindings)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newWorkloadIdentityIdentityModel(newState))...)
+}
+
+func (r *WorkloadIdentityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state WorkloadIdentityModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyID := state.ID.ValueString()
+	policy, err := r.client.AttestationPolicyV1Alpha1().GetAttestationPolicy(ctx, policyID)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading workload identity",
+			fmt.Sprintf("Could not read attestation policy %q: %s", policyID, err),
+		)
+		return
+	}
+
+	bindings, err := listPolicyBindings(ctx, r.client.APBindingV1Alpha1(), policyID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading workload identity", err.Error())
+		return
+	}
+
+	// Bindings keep their configured order, and unmanaged bindings are
+	// added at the end, where they show up as drift.
+	trustZoneIDs, diags := bindingTrustZoneIDs(ctx, state.Bindings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState, diags := workloadIdentityToModel(ctx, protoToModel(policy), orderBindings(bindings, trustZoneIDs), state.Bindings)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newWorkloadIdentityIdentityModel(newState))...)
+}
+
+func (r *WorkloadIdentityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state WorkloadIdentityModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan WorkloadIdentityModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var desired []WorkloadIdentityBindingModel
+	resp.Diagnostics.Append(plan.Bindings.ElementsAs(ctx, &desired, false)...)
+
+	policy, diags := modelToProto(ctx, policyOf(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The policy as it is in state is restored if the bindings cannot be
+	// reconciled.
+	prior, diags := modelToProto(ctx, policyOf(state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyID := state.ID.ValueString()
+	policies := r.client.AttestationPolicyV1Alpha1()
+
+	client := r.client.APBindingV1Alpha1()
+	existing, err := listPolicyBindings(ctx, client, policyID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating workload identity", err.Error())
+		return
+	}
+
+	// The policy is updated first, so that a failure leaves the bindings
+	// unchanged.
+	updated, err := updateAttestationPolicy(ctx, policies, policy, policyOf(state))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating workload identity",
+			fmt.Sprintf("Could not update attestation policy %q: %s", policyID, err),
+		)
+		return
+	}
+
+	// On failure the policy is restored, and the state records the bindings
+	// that exist, so that the next plan shows the changes that are still
+	// needed.
+	bindings, err := reconcileBindings(ctx, client, policyID, desired, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating workload identity",
+			fmt.Sprintf("Could not reconcile the bindings of attestation policy %q: %s", policyID, err),
+		)
+
+		restored, err := updateAttestationPolicy(ctx, policies, prior, policyOf(state))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error rolling back workload identity",
+				fmt.Sprintf("Could not restore attestation policy %q: %s", policyID, err),
+			)
+		} else {
+			updated = restored
+		}
+	}
+
+	newState, diags := workloadIdentityToModel(ctx, protoToModel(updated), bindings, plan.Bindings)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newWorkloadIdentityIdentityModel(newState))...)
+}
+
+func (r *WorkloadIdentityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state WorkloadIdentityModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.APBindingV1Alpha1()
+	policyID := state.ID.ValueString()
+
+	existing, err := listPolicyBindings(ctx, client, policyID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting workload identity", err.Error())
+		return
+	}
+
+	// The bindings are deleted first, as the policy cannot be deleted
+	// while it is bound.
+	remaining, err := reconcileBindings(ctx, client, policyID, nil, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting workload identity",
+			fmt.Sprintf("Could not delete the bindings of attestation policy %q: %s", policyID, err),
+		)
+
+		newState, diags := workloadIdentityToModel(ctx, policyOf(state), remaining, state.Bindings)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newWorkloadIdentityIdentityModel(newState))...)
+		return
+	}
+
+	err = r.client.AttestationPolicyV1Alpha1().DestroyAttestationPolicy(ctx, policyID)
+	if err != nil && status.Code(err) != codes.NotFound {
+		resp.Diagnostics.AddError(
+			"Error deleting workload identity",
+			fmt.Sprintf("Could not delete attestation policy %q: %s", policyID, err),
+		)
+	}
+}
+
+// ModifyPlan matches the planned bindings to the bindings in state by trust
+// zone, and plans the ID of each from its match, so that adding, removing or
+// reordering bindings only changes the bindings concerned.
+func (r *WorkloadIdentityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan WorkloadIdentityModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Bindings.IsUnknown() || plan.Bindings.IsNull() {
+		return
+	}
+
+	prior := map[string]attr.Value{}
+	if !req.State.Raw.IsNull() {
+		var state WorkloadIdentityModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, element := range state.Bindings.Elements() {
+			if trustZoneID, ok := bindingTrustZoneID(element); ok {
+				if _, seen := prior[trustZoneID]; !seen {
+					prior[trustZoneID] = element.(tftypes.Object).Attributes()["id"]
+				}
+			}
+		}
+	}
+
+	elements := plan.Bindings.Elements()
+	for i, element := range elements {
+		trustZoneID, ok := bindingTrustZoneID(element)
+		if !ok || prior[trustZoneID] == nil {
+			continue
+		}
+
+		attributes := element.(tftypes.Object).Attributes()
+		if attributes["id"].IsUnknown() {
+			attributes["id"] = prior[trustZoneID]
+		}
+		elements[i] = tftypes.ObjectValueMust(workloadIdentityBindingType().AttrTypes, attributes)
+	}
+
+	bindings, diags := tftypes.ListValue(workloadIdentityBindingType(), elements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("bindings"), bindings)...)
+}
+
+func (r *WorkloadIdentityResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var bindings tftypes.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bindings"), &bindings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, element := range bindings.Elements() {
+		trustZoneID, ok := bindingTrustZoneID(element)
+		if !ok {
+			continue
+		}
+		if seen[trustZoneID] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bindings").AtListIndex(i).AtName("trust_zone_id"),
+				"Duplicate attestation policy binding",
+				fmt.Sprintf("An attestation policy can be bound to a trust zone only once, but trust zone %q is used more than once.", trustZoneID),
+			)
+		}
+		seen[trustZoneID] = true
+	}
+}
+
+func (r *WorkloadIdentityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		id, err := importid.AttestationPolicy(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing workload identity", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// bindingTrustZoneID returns the trust zone of a binding object, if it is
+// known.
+func bindingTrustZoneID(value attr.Value) (string, bool) {
+	object, ok := value.(tftypes.Object)
+	if !ok || object.IsNull() || object.IsUnknown() {
+		return "", false
+	}
+	trustZoneID, ok := object.Attributes()["trust_zone_id"].(tftypes.String)
+	if !ok || trustZoneID.IsNull() || trustZoneID.IsUnknown() {
+		return "", false
+	}
+	return trustZoneID.ValueString(), true
+}
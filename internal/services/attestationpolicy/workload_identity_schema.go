@@ -0,0 +1,86 @@
+package attestationpolicy
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithConfigValidators = (*WorkloadIdentityResource)(nil)
+
+func WorkloadIdentityResourceSchema(ctx context.Context) schema.Schema {
+	policy := ResourceSchema(ctx).Attributes
+
+	return schema.Schema{
+		MarkdownDescription: "Manages a Cofide Connect attestation policy together with its bindings to trust zones, as a single unit. " +
+			"The policy is created before its bindings and deleted after them. If any part of the creation fails, whatever was created is deleted again. " +
+			"Bindings of the policy that are not configured here, including ones created outside of Terraform, show up as drift and are deleted on apply. " +
+			"Exactly one of `kubernetes`, `static`, or `tpm_node` must be configured. Do not use it together with `cofide_connect_ap_binding` for the same policy.",
+		Attributes: map[string]schema.Attribute{
+			"id":         policy["id"],
+			"name":       policy["name"],
+			"org_id":     policy["org_id"],
+			"kubernetes": policy["kubernetes"],
+			"static":     policy["static"],
+			"tpm_node":   policy["tpm_node"],
+			"bindings": schema.ListNestedAttribute{
+				Description: "The bindings of the attestation policy to trust zones. Each trust zone can be bound only once. Defaults to no bindings.",
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(tftypes.ListValueMust(workloadIdentityBindingType(), []attr.Value{})),
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: workloadIdentityBindingAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// workloadIdentityBindingAttributes returns the attributes of a binding.
+//
+// The computed id has no plan modifier because plan modifiers match the
+// elements of a list by index. ModifyPlan matches bindings by trust zone
+// instead.
+func workloadIdentityBindingAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The ID of the attestation policy binding.",
+			Computed:    true,
+		},
+		"trust_zone_id": schema.StringAttribute{
+			Description: "The ID of the trust zone to which the policy is bound.",
+			Required:    true,
+		},
+		"federations": schema.ListNestedAttribute{
+			Description: "The federated trust zones which will be visible to workloads matching the policy in this trust zone.",
+			Optional:    true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"trust_zone_id": schema.StringAttribute{
+						Description: "The ID of the federated trust zone.",
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// workloadIdentityBindingType is the type of a binding.
+func workloadIdentityBindingType() tftypes.ObjectType {
+	return schema.NestedAttributeObject{Attributes: workloadIdentityBindingAttributes()}.Type().(tftypes.ObjectType)
+}
+
+func (r *WorkloadIdentityResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = WorkloadIdentityResourceSchema(ctx)
+}
+
+func (r *WorkloadIdentityResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		exactlyOneOfValidator{},
+	}
+}
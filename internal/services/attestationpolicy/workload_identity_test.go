@@ -0,0 +1,232 @@
+package attestationpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
+	apbindingsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/ap_binding_service/v1alpha1"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeAPBindingClient stores attestation policy bindings in memory and records
+// the changes made to them. Changing the binding to failTrustZoneID fails.
+type fakeAPBindingClient struct {
+	bindings        []*apbindingpb.APBinding
+	nextID          int
+	failTrustZoneID string
+	calls           []string
+}
+
+func (c *fakeAPBindingClient) add(policyID, trustZoneID string, federations ...string) {
+	c.nextID++
+	binding := &apbindingpb.APBinding{
+		Id:          ptr(fmt.Sprintf("apb-%d", c.nextID)),
+		TrustZoneId: ptr(trustZoneID),
+		PolicyId:    ptr(policyID),
+	}
+	for _, federation := range federations {
+		binding.Federations = append(binding.Federations, &apbindingpb.APBindingFederation{TrustZoneId: ptr(federation)})
+	}
+	c.bindings = append(c.bindings, binding)
+}
+
+func (c *fakeAPBindingClient) CreateAPBinding(_ context.Context, binding *apbindingpb.APBinding) (*apbindingpb.APBinding, error) {
+	c.calls = append(c.calls, "create "+binding.GetTrustZoneId())
+	if binding.GetTrustZoneId() == c.failTrustZoneID {
+		return nil, errors.New("create failed")
+	}
+	c.nextID++
+	created := proto.Clone(binding).(*apbindingpb.APBinding)
+	created.Id = ptr(fmt.Sprintf("apb-%d", c.nextID))
+	c.bindings = append(c.bindings, created)
+	return created, nil
+}
+
+func (c *fakeAPBindingClient) ListAPBindings(_ context.Context, filter *apbindingsvcpb.ListAPBindingsRequest_Filter) ([]*apbindingpb.APBinding, error) {
+	var bindings []*apbindingpb.APBinding
+	for _, binding := range c.bindings {
+		if binding.GetPolicyId() == *filter.PolicyId {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
+
+func (c *fakeAPBindingClient) UpdateAPBinding(_ context.Context, binding *apbindingpb.APBinding) (*apbindingpb.APBinding, error) {
+	c.calls = append(c.calls, "update "+binding.GetTrustZoneId())
+	if binding.GetTrustZoneId() == c.failTrustZoneID {
+		return nil, errors.New("update failed")
+	}
+	for i, existing := range c.bindings {
+		if existing.GetId() == binding.GetId() {
+			updated := proto.Clone(binding).(*apbindingpb.APBinding)
+			c.bindings[i] = updated
+			return updated, nil
+		}
+	}
+	return nil, fmt.Errorf("binding %q not found", binding.GetId())
+}
+
+func (c *fakeAPBindingClient) DestroyAPBinding(_ context.Context, id string) error {
+	for i, binding := range c.bindings {
+		if binding.GetId() == id {
+			c.calls = append(c.calls, "delete "+binding.GetTrustZoneId())
+			if binding.GetTrustZoneId() == c.failTrustZoneID {
+				return errors.New("delete failed")
+			}
+			c.bindings = append(c.bindings[:i], c.bindings[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("binding %q not found", id)
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+// desiredBinding returns a planned binding, whose ID is not yet known.
+func desiredBinding(trustZoneID string, federations ...string) WorkloadIdentityBindingModel {
+	model := WorkloadIdentityBindingModel{
+		ID:          tftypes.StringUnknown(),
+		TrustZoneID: tftypes.StringValue(trustZoneID),
+	}
+	for _, federation := range federations {
+		model.Federations = append(model.Federations, WorkloadIdentityFederationModel{TrustZoneID: tftypes.StringValue(federation)})
+	}
+	return model
+}
+
+func bindingSummaries(bindings []*apbindingpb.APBinding) []string {
+	var summaries []string
+	for _, binding := range bindings {
+		summaries = append(summaries, fmt.Sprintf("%s %s [%s]", binding.GetId(), binding.GetTrustZoneId(), strings.Join(federationTrustZoneIDs(binding.GetFederations()), " ")))
+	}
+	return summaries
+}
+
+func TestReconcileBindings(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeAPBindingClient{}
+	client.add("ap-1", "tz-unchanged", "tz-fed")
+	client.add("ap-1", "tz-changed")
+	client.add("ap-1", "tz-unmanaged")
+	client.add("ap-1", "tz-unchanged", "tz-fed")
+	client.add("ap-2", "tz-unchanged")
+
+	existing, err := listPolicyBindings(ctx, client, "ap-1")
+	require.NoError(t, err)
+
+	bindings, err := reconcileBindings(ctx, client, "ap-1", []WorkloadIdentityBindingModel{
+		desiredBinding("tz-new"),
+		desiredBinding("tz-changed", "tz-fed"),
+		desiredBinding("tz-unchanged", "tz-fed"),
+	}, existing)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"create tz-new", "update tz-changed", "delete tz-unmanaged", "delete tz-unchanged"}, client.calls)
+	assert.Equal(t, []string{
+		"apb-6 tz-new []",
+		"apb-2 tz-changed [tz-fed]",
+		"apb-1 tz-unchanged [tz-fed]",
+	}, bindingSummaries(bindings))
+
+	listed, err := listPolicyBindings(ctx, client, "ap-2")
+	require.NoError(t, err)
+	assert.Len(t, listed, 1, "the bindings of other policies should be left alone")
+}
+
+func TestReconcileBindingsPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeAPBindingClient{failTrustZoneID: "tz-unmanaged"}
+	client.add("ap-1", "tz-unmanaged")
+
+	existing, err := listPolicyBindings(ctx, client, "ap-1")
+	require.NoError(t, err)
+
+	bindings, err := reconcileBindings(ctx, client, "ap-1", []WorkloadIdentityBindingModel{desiredBinding("tz-new")}, existing)
+	assert.EqualError(t, err, `could not delete the binding "apb-1" to trust zone "tz-unmanaged": delete failed`)
+	assert.Equal(t, []string{
+		"apb-2 tz-new []",
+		"apb-1 tz-unmanaged []",
+	}, bindingSummaries(bindings))
+}
+
+func TestOrderBindings(t *testing.T) {
+	bindings := []*apbindingpb.APBinding{
+		{Id: ptr("apb-1"), TrustZoneId: ptr("tz-d")},
+		{Id: ptr("apb-2"), TrustZoneId: ptr("tz-b")},
+		{Id: ptr("apb-3"), TrustZoneId: ptr("tz-c")},
+		{Id: ptr("apb-4"), TrustZoneId: ptr("tz-a")},
+	}
+
+	var trustZoneIDs []string
+	for _, binding := range orderBindings(bindings, []string{"tz-c", "tz-d"}) {
+		trustZoneIDs = append(trustZoneIDs, binding.GetTrustZoneId())
+	}
+	assert.Equal(t, []string{"tz-c", "tz-d", "tz-a", "tz-b"}, trustZoneIDs)
+}
+
+func TestBindingsToListFederations(t *testing.T) {
+	ctx := context.Background()
+	bindings := []*apbindingpb.APBinding{
+		{Id: ptr("apb-1"), TrustZoneId: ptr("tz-empty")},
+		{Id: ptr("apb-2"), TrustZoneId: ptr("tz-omitted")},
+		{Id: ptr("apb-3"), TrustZoneId: ptr("tz-federated"), Federations: []*apbindingpb.APBindingFederation{{TrustZoneId: ptr("tz-fed")}}},
+	}
+	empty := desiredBinding("tz-empty")
+	empty.Federations = []WorkloadIdentityFederationModel{}
+
+	tests := []struct {
+		name  string
+		prior []WorkloadIdentityBindingModel
+		want  map[string]string
+	}{
+		{
+			name:  "planned",
+			prior: []WorkloadIdentityBindingModel{empty, desiredBinding("tz-omitted"), desiredBinding("tz-federated", "tz-fed")},
+			want:  map[string]string{"tz-empty": "empty", "tz-omitted": "null", "tz-federated": "1"},
+		},
+		{
+			name: "imported",
+			want: map[string]string{"tz-empty": "null", "tz-omitted": "null", "tz-federated": "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := tftypes.ListNull(workloadIdentityBindingType())
+			if tt.prior != nil {
+				var diags diag.Diagnostics
+				prior, diags = tftypes.ListValueFrom(ctx, workloadIdentityBindingType(), tt.prior)
+				require.False(t, diags.HasError(), diags)
+			}
+
+			list, diags := bindingsToList(ctx, bindings, prior)
+			require.False(t, diags.HasError(), diags)
+
+			var models []WorkloadIdentityBindingModel
+			require.False(t, list.ElementsAs(ctx, &models, false).HasError())
+			got := map[string]string{}
+			for _, model := range models {
+				switch {
+				case model.Federations == nil:
+					got[model.TrustZoneID.ValueString()] = "null"
+				case len(model.Federations) == 0:
+					got[model.TrustZoneID.ValueString()] = "empty"
+				default:
+					got[model.TrustZoneID.ValueString()] = fmt.Sprint(len(model.Federations))
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	clustersvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/cluster_service/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// clusterClient is the part of the cluster API used to adopt an existing
+// cluster and to update one.
+type clusterClient interface {
+	ListClusters(ctx context.Context, filter *clustersvcpb.ListClustersRequest_Filter) ([]*clusterpb.Cluster, error)
+	UpdateCluster(ctx context.Context, cluster *clusterpb.Cluster) (*clusterpb.Cluster, error)
+}
+
+// adoptCluster finds the existing cluster with the name and trust zone of
+// cluster, which could not be created because it already exists, and updates
+// it to match cluster.
+func adoptCluster(ctx context.Context, client clusterClient, cluster *clusterpb.Cluster) (*clusterpb.Cluster, error) {
+	name := cluster.GetName()
+	trustZoneID := cluster.GetTrustZoneId()
+	clusters, err := client.ListClusters(ctx, &clustersvcpb.ListClustersRequest_Filter{
+		Name:        &name,
+		TrustZoneId: &trustZoneID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list clusters to adopt: %w", err)
+	}
+
+	existing, err := util.AdoptionCandidate("cluster", fmt.Sprintf("named %q in trust zone %q", name, trustZoneID), clusters, func(c *clusterpb.Cluster) bool {
+		return c.GetName() == name && c.GetTrustZoneId() == trustZoneID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	adopted := proto.Clone(cluster).(*clusterpb.Cluster)
+	adopted.Id = existing.Id
+	updated, err := client.UpdateCluster(ctx, adopted)
+	if err != nil {
+		return nil, fmt.Errorf("could not update the existing cluster %q (%s): %w", name, existing.GetId(), err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,47 @@
+package cluster
+
+import (
+	"fmt"
+
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// protoToModel converts a cluster returned by the API to its model, without
+// reference to any prior state. Helm values are rendered as JSON.
+func protoToModel(cluster *clusterpb.Cluster) (ClusterModel, error) {
+	extraHelmValues, err := util.HelmValuesFromProto(cluster.GetExtraHelmValues(), util.NewHelmValuesNull())
+	if err != nil {
+		return ClusterModel{}, fmt.Errorf("could not convert extra_helm_values: %w", err)
+	}
+	extraHelmValuesMerged, err := util.HelmValuesJSON(cluster.GetExtraHelmValues())
+	if err != nil {
+		return ClusterModel{}, fmt.Errorf("could not convert extra_helm_values_merged: %w", err)
+	}
+
+	var oidcIssuerURL tftypes.String
+	if url := cluster.GetOidcIssuerUrl(); url != "" {
+		oidcIssuerURL = tftypes.StringValue(url)
+	} else {
+		oidcIssuerURL = tftypes.StringNull()
+	}
+
+	model := ClusterModel{
+		ID:                    tftypes.StringValue(cluster.GetId()),
+		Name:                  tftypes.StringValue(cluster.GetName()),
+		OrgID:                 tftypes.StringValue(cluster.GetOrgId()),
+		TrustZoneID:           tftypes.StringValue(cluster.GetTrustZoneId()),
+		KubernetesContext:     tftypes.StringValue(cluster.GetKubernetesContext()),
+		TrustProvider:         trustProviderFromProto(cluster.GetTrustProvider()),
+		ExtraHelmValues:       extraHelmValues,
+		ExtraHelmValuesObject: util.NewHelmValuesObjectNull(),
+		ExtraHelmValuesMerged: extraHelmValuesMerged,
+		Profile:               tftypes.StringValue(cluster.GetProfile()),
+		ExternalServer:        tftypes.BoolValue(cluster.GetExternalServer()),
+		OidcIssuerURL:         oidcIssuerURL,
+		OidcIssuerCaCert:      util.CACertificateFromBytes(cluster.GetOidcIssuerCaCert()),
+	}
+	model.setOidcIssuerCaCertInfo()
+	return model, nil
+}
@@ -3,6 +3,7 @@ package cluster
 import (
 	"context"
 
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -77,7 +78,20 @@ func DataSourceSchema(ctx context.Context) schema.Schema {
 								ElementType: types.StringType,
 							},
 							"api_server_ca_cert": schema.StringAttribute{
-								Description: "Base64-encoded CA certificate of the cluster's API server.",
+								Description: "Base64-encoded PEM CA certificate of the cluster's API server.",
+								Computed:    true,
+								CustomType:  util.CACertificateType{},
+							},
+							"api_server_ca_cert_fingerprint_sha256": schema.StringAttribute{
+								Description: "The SHA-256 fingerprint of the first certificate in `api_server_ca_cert`, as lowercase hex.",
+								Computed:    true,
+							},
+							"api_server_ca_cert_not_after": schema.StringAttribute{
+								Description: "The time the earliest-expiring certificate in `api_server_ca_cert` expires (RFC3339), for alerting on upcoming expiry.",
+								Computed:    true,
+							},
+							"api_server_ca_cert_subject": schema.StringAttribute{
+								Description: "The subject of the first certificate in `api_server_ca_cert`, as an RFC 2253 distinguished name.",
 								Computed:    true,
 							},
 							"api_server_url": schema.StringAttribute{
@@ -101,8 +115,21 @@ func DataSourceSchema(ctx context.Context) schema.Schema {
 				},
 			},
 			"extra_helm_values": schema.StringAttribute{
-				Description: "Additional Helm values for the Cofide SPIRE Helm chart installation, in YAML format.",
+				Description: "Additional Helm values for the Cofide SPIRE Helm chart installation, in YAML format. Sensitive, as the values include any set through the resource's write-only `sensitive_extra_helm_values`.",
+				Computed:    true,
+				Sensitive:   true,
+				CustomType:  util.HelmValuesType{},
+			},
+			"extra_helm_values_object": schema.DynamicAttribute{
+				Description: "Additional Helm values for the Cofide SPIRE Helm chart installation, as an object. Sensitive, like `extra_helm_values`.",
 				Computed:    true,
+				Sensitive:   true,
+				CustomType:  util.HelmValuesObjectType{},
+			},
+			"extra_helm_values_merged": schema.StringAttribute{
+				Description: "The additional Helm values for the Cofide SPIRE Helm chart installation, as a JSON document. Sensitive, like `extra_helm_values`.",
+				Computed:    true,
+				Sensitive:   true,
 			},
 			"profile": schema.StringAttribute{
 				Description: "The Cofide profile used by the cluster (e.g. `kubernetes`, `istio`). Ensures Cofide SPIRE is configured correctly for the target environment.",
@@ -117,7 +144,20 @@ func DataSourceSchema(ctx context.Context) schema.Schema {
 				Computed:    true,
 			},
 			"oidc_issuer_ca_cert": schema.StringAttribute{
-				Description: "The CA certificate (base64-encoded) to validate the cluster's OIDC issuer URL.",
+				Description: "The CA certificate (base64-encoded PEM) to validate the cluster's OIDC issuer URL.",
+				Computed:    true,
+				CustomType:  util.CACertificateType{},
+			},
+			"oidc_issuer_ca_cert_fingerprint_sha256": schema.StringAttribute{
+				Description: "The SHA-256 fingerprint of the first certificate in `oidc_issuer_ca_cert`, as lowercase hex.",
+				Computed:    true,
+			},
+			"oidc_issuer_ca_cert_not_after": schema.StringAttribute{
+				Description: "The time the earliest-expiring certificate in `oidc_issuer_ca_cert` expires (RFC3339), for alerting on upcoming expiry.",
+				Computed:    true,
+			},
+			"oidc_issuer_ca_cert_subject": schema.StringAttribute{
+				Description: "The subject of the first certificate in `oidc_issuer_ca_cert`, as an RFC 2253 distinguished name.",
 				Computed:    true,
 			},
 		},
@@ -0,0 +1,94 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	clustersvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/cluster_service/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ list.ListResource              = &ClusterResource{}
+	_ list.ListResourceWithConfigure = &ClusterResource{}
+)
+
+// NewListResource returns the list resource for clusters, which lets
+// `terraform query` enumerate existing clusters for import.
+func NewListResource() list.ListResource {
+	return &ClusterResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists Cofide Connect clusters.",
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: "Filter by organization ID.",
+				Optional:    true,
+			},
+			"trust_zone_id": listschema.StringAttribute{
+				Description: "Filter by trust zone ID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (c *ClusterResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (c *ClusterResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config ClusterListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &clustersvcpb.ListClustersRequest_Filter{
+		OrgId:       config.OrgID.ValueStringPointer(),
+		TrustZoneId: config.TrustZoneID.ValueStringPointer(),
+	}
+	clusters, err := c.client.ClusterV1Alpha1().ListClusters(ctx, filter)
+	if err != nil {
+		diags.AddError(
+			"Error listing clusters",
+			fmt.Sprintf("Could not list clusters: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.ListResults(ctx, req, clusters, func(cluster *clusterpb.Cluster) (util.ListResult, diag.Diagnostics) {
+		model, err := protoToModel(cluster)
+		if err != nil {
+			return util.ListResult{}, diag.Diagnostics{
+				diag.NewErrorDiagnostic("Error processing cluster data", fmt.Sprintf("Could not process cluster %q: %s", cluster.GetId(), err)),
+			}
+		}
+		// As on import, the extra Helm values are left out, since sensitive
+		// values merged into them cannot be told apart from the rest.
+		model.ExtraHelmValues = util.NewHelmValuesNull()
+		model.ExtraHelmValuesMerged = tftypes.StringNull()
+		return util.ListResult{
+			DisplayName: cluster.GetName(),
+			Resource: ClusterResourceModel{
+				ClusterModel:          model,
+				DeletionProtection:    tftypes.BoolValue(deletionProtectionDefault),
+				AdoptExisting:         tftypes.BoolValue(false),
+				RetainOnDelete:        tftypes.BoolValue(false),
+				ValidateHelmValues:    tftypes.BoolValue(true),
+				ExtraHelmValuesLayers: tftypes.ListNull(util.HelmValuesType{}),
+			},
+			Identity: newIdentityModel(model),
+		}, nil
+	})
+}
@@ -1,19 +1,91 @@
 package cluster
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/structpb"
+)
 
 type ClusterModel struct {
-	ID                types.String        `tfsdk:"id"`
-	Name              types.String        `tfsdk:"name"`
-	OrgID             types.String        `tfsdk:"org_id"`
-	TrustZoneID       types.String        `tfsdk:"trust_zone_id"`
-	KubernetesContext types.String        `tfsdk:"kubernetes_context"`
-	TrustProvider     *TrustProviderModel `tfsdk:"trust_provider"`
-	ExtraHelmValues   types.String        `tfsdk:"extra_helm_values"`
-	Profile           types.String        `tfsdk:"profile"`
-	ExternalServer    types.Bool          `tfsdk:"external_server"`
-	OidcIssuerURL     types.String        `tfsdk:"oidc_issuer_url"`
-	OidcIssuerCaCert  types.String        `tfsdk:"oidc_issuer_ca_cert"`
+	ID                    types.String          `tfsdk:"id"`
+	Name                  types.String          `tfsdk:"name"`
+	OrgID                 types.String          `tfsdk:"org_id"`
+	TrustZoneID           types.String          `tfsdk:"trust_zone_id"`
+	KubernetesContext     types.String          `tfsdk:"kubernetes_context"`
+	TrustProvider         *TrustProviderModel   `tfsdk:"trust_provider"`
+	ExtraHelmValues       util.HelmValues       `tfsdk:"extra_helm_values"`
+	ExtraHelmValuesObject util.HelmValuesObject `tfsdk:"extra_helm_values_object"`
+	ExtraHelmValuesMerged types.String          `tfsdk:"extra_helm_values_merged"`
+	Profile               types.String          `tfsdk:"profile"`
+	ExternalServer        types.Bool            `tfsdk:"external_server"`
+	OidcIssuerURL         types.String          `tfsdk:"oidc_issuer_url"`
+	OidcIssuerCaCert      util.CACertificate    `tfsdk:"oidc_issuer_ca_cert"`
+
+	OidcIssuerCaCertFingerprintSHA256 types.String `tfsdk:"oidc_issuer_ca_cert_fingerprint_sha256"`
+	OidcIssuerCaCertNotAfter          types.String `tfsdk:"oidc_issuer_ca_cert_not_after"`
+	OidcIssuerCaCertSubject           types.String `tfsdk:"oidc_issuer_ca_cert_subject"`
+}
+
+// setOidcIssuerCaCertInfo sets the attributes describing oidc_issuer_ca_cert.
+func (m *ClusterModel) setOidcIssuerCaCertInfo() {
+	info := util.CACertificateInfoFromValue(m.OidcIssuerCaCert)
+	m.OidcIssuerCaCertFingerprintSHA256 = info.FingerprintSHA256
+	m.OidcIssuerCaCertNotAfter = info.NotAfter
+	m.OidcIssuerCaCertSubject = info.Subject
+}
+
+// ClusterResourceModel is the model of the cluster resource, which adds
+// attributes kept in Terraform state only to those shared with the data
+// source.
+type ClusterResourceModel struct {
+	ClusterModel
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool `tfsdk:"adopt_existing"`
+	RetainOnDelete     types.Bool `tfsdk:"retain_on_delete"`
+	ValidateHelmValues types.Bool `tfsdk:"validate_helm_values"`
+
+	// ExtraHelmValuesLayers is kept in state only, as the API returns the
+	// merged values.
+	ExtraHelmValuesLayers types.List `tfsdk:"extra_helm_values_layers"`
+
+	// SensitiveExtraHelmValues is write-only, so always null outside the
+	// configuration.
+	SensitiveExtraHelmValues        util.HelmValues `tfsdk:"sensitive_extra_helm_values"`
+	SensitiveExtraHelmValuesVersion types.Int64     `tfsdk:"sensitive_extra_helm_values_version"`
+}
+
+// extraHelmValuesForms returns the forms the extra Helm values may be
+// configured in.
+func (m ClusterResourceModel) extraHelmValuesForms() util.HelmValuesForms {
+	return util.HelmValuesForms{
+		Document: m.ExtraHelmValues,
+		Object:   m.ExtraHelmValuesObject,
+		Layers:   m.ExtraHelmValuesLayers,
+	}
+}
+
+// setExtraHelmValues sets the extra Helm values returned by the API, in the
+// form they were configured in according to prior. The sensitive values at
+// sensitivePaths are left out.
+func (m *ClusterResourceModel) setExtraHelmValues(values *structpb.Struct, prior util.HelmValuesForms, sensitivePaths [][]string) error {
+	values, err := util.WithoutSensitiveHelmValues(values, sensitivePaths, prior)
+	if err != nil {
+		return err
+	}
+	forms, err := util.HelmValuesFormsFromProto(values, prior)
+	if err != nil {
+		return err
+	}
+	merged, err := util.HelmValuesJSON(values)
+	if err != nil {
+		return err
+	}
+
+	m.ExtraHelmValues = forms.Document
+	m.ExtraHelmValuesObject = forms.Object
+	m.ExtraHelmValuesLayers = forms.Layers
+	m.ExtraHelmValuesMerged = merged
+	return nil
 }
 
 type TrustProviderModel struct {
@@ -26,14 +98,24 @@ type K8sPsatConfigModel struct {
 	AllowedServiceAccounts []ServiceAccountModel `tfsdk:"allowed_service_accounts"`
 	AllowedNodeLabelKeys   types.List            `tfsdk:"allowed_node_label_keys"`
 	AllowedPodLabelKeys    types.List            `tfsdk:"allowed_pod_label_keys"`
-	ApiServerCaCert        types.String          `tfsdk:"api_server_ca_cert"`
+	ApiServerCaCert        util.CACertificate    `tfsdk:"api_server_ca_cert"`
 	ApiServerURL           types.String          `tfsdk:"api_server_url"`
 	ApiServerTLSServerName types.String          `tfsdk:"api_server_tls_server_name"`
 	ApiServerProxyURL      types.String          `tfsdk:"api_server_proxy_url"`
 	SpireServerAudience    types.String          `tfsdk:"spire_server_audience"`
+
+	ApiServerCaCertFingerprintSHA256 types.String `tfsdk:"api_server_ca_cert_fingerprint_sha256"`
+	ApiServerCaCertNotAfter          types.String `tfsdk:"api_server_ca_cert_not_after"`
+	ApiServerCaCertSubject           types.String `tfsdk:"api_server_ca_cert_subject"`
 }
 
 type ServiceAccountModel struct {
 	Namespace          types.String `tfsdk:"namespace"`
 	ServiceAccountName types.String `tfsdk:"service_account_name"`
 }
+
+// ClusterListModel is the configuration of the cluster list resource.
+type ClusterListModel struct {
+	OrgID       types.String `tfsdk:"org_id"`
+	TrustZoneID types.String `tfsdk:"trust_zone_id"`
+}
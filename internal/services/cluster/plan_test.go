@@ -0,0 +1,172 @@
+package cluster_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const resourceType = "cofide_connect_cluster"
+
+// clusterAttributes returns the attributes of a cluster, overridden by
+// overrides.
+func clusterAttributes(overrides map[string]any) map[string]any {
+	attributes := map[string]any{
+		"name":               "cluster",
+		"trust_zone_id":      "tz-1",
+		"kubernetes_context": "kind",
+		"trust_provider":     map[string]any{"kind": "kubernetes"},
+		"profile":            "kubernetes",
+		"external_server":    false,
+	}
+	for name, value := range overrides {
+		attributes[name] = value
+	}
+	return attributes
+}
+
+// TestValidateHelmValues checks that Helm values configured in one form
+// conflict with the other, that the object form must be an object, and that
+// values must be accepted by the chart's values schema unless
+// validate_helm_values is false.
+func TestValidateHelmValues(t *testing.T) {
+	replicas := tftypes.NewValue(
+		tftypes.Object{AttributeTypes: map[string]tftypes.Type{"replicaCount": tftypes.Number}},
+		map[string]tftypes.Value{"replicaCount": tftypes.NewValue(tftypes.Number, 2)},
+	)
+
+	providertest.RunValidateTests(t, resourceType, []providertest.ValidateTest{
+		{
+			Name:   "object",
+			Config: clusterAttributes(map[string]any{"extra_helm_values_object": replicas}),
+		},
+		{
+			Name:        "both forms",
+			Config:      clusterAttributes(map[string]any{"extra_helm_values": "replicaCount: 2", "extra_helm_values_object": replicas}),
+			WantSummary: "Invalid Attribute Combination",
+		},
+		{
+			Name:        "string instead of object",
+			Config:      clusterAttributes(map[string]any{"extra_helm_values_object": tftypes.NewValue(tftypes.String, "replicas: 2")}),
+			WantSummary: "Invalid Helm values",
+		},
+		{
+			Name:        "unknown key",
+			Config:      clusterAttributes(map[string]any{"extra_helm_values": "spire-server:\n  replicaCont: 2\n"}),
+			WantSummary: "Invalid Helm value",
+			WantDetail:  `spire-server.replicaCont: unknown key (did you mean "replicaCount"?)`,
+		},
+		{
+			Name:   "unknown key with validation disabled",
+			Config: clusterAttributes(map[string]any{"extra_helm_values": "spire-server:\n  replicaCont: 2\n", "validate_helm_values": false}),
+		},
+		{
+			Name:        "invalid YAML",
+			Config:      clusterAttributes(map[string]any{"extra_helm_values": "spire-server: ["}),
+			WantSummary: "Invalid Helm values",
+		},
+		{
+			Name:   "sensitive values",
+			Config: clusterAttributes(map[string]any{"extra_helm_values": "spire-server:\n  replicaCount: 2\n", "sensitive_extra_helm_values": "spire-server:\n  logLevel: debug\n", "sensitive_extra_helm_values_version": 1}),
+		},
+		{
+			Name:        "sensitive unknown key",
+			Config:      clusterAttributes(map[string]any{"sensitive_extra_helm_values": "spire-server:\n  replicaCont: 2\n"}),
+			WantSummary: "Invalid Helm value",
+			WantDetail:  `spire-server.replicaCont: unknown key`,
+		},
+	})
+}
+
+// TestPlanHelmValuesMerged checks that the merged Helm values are planned from
+// whichever attribute the Helm values are configured in, with layers merged
+// the way Helm merges values files.
+func TestPlanHelmValuesMerged(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   string
+	}{
+		{
+			name: "layers",
+			config: clusterAttributes(map[string]any{"extra_helm_values_layers": []any{
+				"global:\n  logLevel: info\n  trustDomain: td\nargs: [a, b]\n",
+				`{"global": {"logLevel": "debug"}, "args": ["c"]}`,
+			}}),
+			want: `{"args":["c"],"global":{"logLevel":"debug","trustDomain":"td"}}`,
+		},
+		{
+			name:   "document",
+			config: clusterAttributes(map[string]any{"extra_helm_values": "replicas: 2\n"}),
+			want:   `{"replicas":2}`,
+		},
+	}
+
+	server := providertest.NewServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planned := providertest.PlanCreate(t, server, resourceType, tt.config)
+			assert.Equal(t, tt.want, providertest.String(t, planned, "extra_helm_values_merged"))
+		})
+	}
+}
+
+// TestCACertificates checks that CA certificates are accepted as PEM or
+// base64-encoded PEM, that certificates that are not CA certificates or have
+// expired are rejected when validating, and that the attributes describing
+// them are planned from the configured certificates.
+func TestCACertificates(t *testing.T) {
+	notAfter := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+	ca := providertest.NewCertificate(t, "oidc-ca", true, notAfter)
+	apiServerCA := providertest.NewCertificate(t, "api-server-ca", true, notAfter)
+	leaf := providertest.NewCertificate(t, "leaf", false, notAfter)
+	expired := providertest.NewCertificate(t, "expired-ca", true, time.Now().Add(-time.Hour))
+
+	withCertificates := func(oidcIssuerCACert, apiServerCACert string) map[string]any {
+		return clusterAttributes(map[string]any{
+			"oidc_issuer_ca_cert": oidcIssuerCACert,
+			"trust_provider": map[string]any{
+				"kind":            "kubernetes",
+				"k8s_psat_config": map[string]any{"enabled": true, "api_server_ca_cert": apiServerCACert},
+			},
+		})
+	}
+
+	providertest.RunValidateTests(t, resourceType, []providertest.ValidateTest{
+		{
+			Name:   "PEM and base64",
+			Config: withCertificates(ca, base64.StdEncoding.EncodeToString([]byte(apiServerCA))),
+		},
+		{
+			Name:        "not a CA certificate",
+			Config:      withCertificates(leaf, apiServerCA),
+			WantSummary: "Invalid CA certificate",
+			WantDetail:  "certificate 1 (CN=leaf) is not a CA certificate",
+		},
+		{
+			Name:        "expired",
+			Config:      withCertificates(ca, expired),
+			WantSummary: "Invalid CA certificate",
+			WantDetail:  "certificate 1 (CN=expired-ca) expired at",
+		},
+		{
+			Name:        "not a certificate",
+			Config:      withCertificates("dGVzdC1jYQ==", apiServerCA),
+			WantSummary: "Invalid CA certificate",
+			WantDetail:  "no PEM-encoded certificate found",
+		},
+	})
+
+	t.Run("planned attributes", func(t *testing.T) {
+		planned := providertest.PlanCreate(t, providertest.NewServer(t), resourceType, withCertificates(ca, base64.StdEncoding.EncodeToString([]byte(apiServerCA))))
+
+		assert.Equal(t, "CN=oidc-ca", providertest.String(t, planned, "oidc_issuer_ca_cert_subject"))
+		assert.Equal(t, notAfter.UTC().Format(time.RFC3339), providertest.String(t, planned, "oidc_issuer_ca_cert_not_after"))
+		assert.Len(t, providertest.String(t, planned, "oidc_issuer_ca_cert_fingerprint_sha256"), 64)
+		assert.Equal(t, "CN=api-server-ca", providertest.String(t, planned, "trust_provider", "k8s_psat_config", "api_server_ca_cert_subject"))
+	})
+}
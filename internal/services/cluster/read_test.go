@@ -0,0 +1,69 @@
+package cluster_test
+
+import (
+	"testing"
+
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	trustproviderpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_provider/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+)
+
+// secret is a value set through sensitive_extra_helm_values, which the API
+// returns merged into the extra Helm values.
+const secret = "hunter2"
+
+func newClient(t *testing.T) *providertest.Client {
+	t.Helper()
+
+	values, err := structpb.NewStruct(map[string]any{
+		"global":   map[string]any{"logLevel": "info"},
+		"database": map[string]any{"password": secret},
+	})
+	require.NoError(t, err)
+	return &providertest.Client{Clusters: []*clusterpb.Cluster{{
+		Id:                proto.String("c-1"),
+		OrgId:             proto.String("org-1"),
+		Name:              proto.String("cluster"),
+		TrustZoneId:       proto.String("tz-1"),
+		KubernetesContext: proto.String("kind"),
+		TrustProvider:     &trustproviderpb.TrustProvider{Kind: proto.String("kubernetes")},
+		ExtraHelmValues:   values,
+		Profile:           proto.String("kubernetes"),
+		ExternalServer:    proto.Bool(false),
+	}}}
+}
+
+// TestImportWithholdsHelmValues checks that the extra Helm values of an
+// imported cluster are not stored in state, as any of them may be sensitive.
+func TestImportWithholdsHelmValues(t *testing.T) {
+	server := providertest.NewServerWithClient(t, newClient(t))
+
+	state := providertest.ImportAndRead(t, server, resourceType, "c-1")
+	providertest.AssertNotExposed(t, server.ResourceSchema(t, resourceType).Block, state, secret)
+	assert.Equal(t, "cluster", providertest.String(t, state, "name"))
+}
+
+// TestListWithholdsHelmValues checks that the clusters listed by `terraform
+// query`, and so exported, have no extra Helm values.
+func TestListWithholdsHelmValues(t *testing.T) {
+	server := providertest.NewServerWithClient(t, newClient(t))
+
+	resources := providertest.List(t, server, resourceType, map[string]any{})
+	require.Len(t, resources, 1)
+	providertest.AssertNotExposed(t, server.ResourceSchema(t, resourceType).Block, resources[0], secret)
+}
+
+// TestDataSourceHelmValuesSensitive checks that the data source returns the
+// extra Helm values only in attributes marked sensitive.
+func TestDataSourceHelmValuesSensitive(t *testing.T) {
+	server := providertest.NewServerWithClient(t, newClient(t))
+
+	state := providertest.ReadDataSource(t, server, resourceType, map[string]any{"name": "cluster"})
+	providertest.AssertNotExposed(t, server.DataSourceSchema(t, resourceType).Block, state, secret)
+	assert.Contains(t, providertest.String(t, state, "extra_helm_values_merged"), secret)
+}
@@ -2,13 +2,14 @@ package cluster
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
 	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
 	trustproviderpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_provider/v1alpha1"
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/helmschema"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
 	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -55,7 +56,7 @@ func (c *ClusterResource) Configure(_ context.Context, req resource.ConfigureReq
 }
 
 func (c *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan ClusterModel
+	var plan ClusterResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -74,12 +75,12 @@ func (c *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		cluster.OidcIssuerUrl = plan.OidcIssuerURL.ValueStringPointer()
 	}
 
-	if util.IsStringAttributeNonEmpty(plan.OidcIssuerCaCert) {
-		decodedCert, err := base64.StdEncoding.DecodeString(plan.OidcIssuerCaCert.ValueString())
+	if util.IsStringAttributeNonEmpty(plan.OidcIssuerCaCert.StringValue) {
+		decodedCert, err := plan.OidcIssuerCaCert.Bytes()
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error decoding oidc_issuer_ca_cert",
-				fmt.Sprintf("Failed to decode oidc_issuer_ca_cert from base64: %s", err),
+				fmt.Sprintf("Failed to decode oidc_issuer_ca_cert: %s", err),
 			)
 
 			return
@@ -113,7 +114,54 @@ func (c *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		cluster.ExtraHelmValues = parsedHelmValues
 	}
 
+	if !plan.ExtraHelmValuesObject.IsNull() {
+		parsedHelmValues, err := util.HelmValuesObjectToProto(plan.ExtraHelmValuesObject)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing extra_helm_values_object",
+				fmt.Sprintf("Failed to parse extra_helm_values_object: %s", err),
+			)
+
+			return
+		}
+
+		cluster.ExtraHelmValues = parsedHelmValues
+	}
+
+	if !plan.ExtraHelmValuesLayers.IsNull() {
+		parsedHelmValues, err := util.HelmValuesLayersToProto(plan.ExtraHelmValuesLayers)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing extra_helm_values_layers",
+				fmt.Sprintf("Failed to parse extra_helm_values_layers: %s", err),
+			)
+
+			return
+		}
+
+		cluster.ExtraHelmValues = parsedHelmValues
+	}
+
+	var sensitiveExtraHelmValues util.HelmValues
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_extra_helm_values"), &sensitiveExtraHelmValues)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	extraHelmValues, sensitivePaths, err := util.MergeSensitiveHelmValues(cluster.ExtraHelmValues, sensitiveExtraHelmValues)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing sensitive_extra_helm_values",
+			fmt.Sprintf("Failed to parse sensitive_extra_helm_values: %s", err),
+		)
+
+		return
+	}
+	cluster.ExtraHelmValues = extraHelmValues
+
 	createResp, err := c.client.ClusterV1Alpha1().CreateCluster(ctx, cluster)
+	if util.ShouldAdopt(plan.AdoptExisting, err) {
+		createResp, err = adoptCluster(ctx, c.client.ClusterV1Alpha1(), cluster)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating cluster",
@@ -123,10 +171,6 @@ func (c *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	// Store the plan value for extra_helm_values to preserve the original format
-	// (YAML or JSON) and avoid a plan/state inconsistency on apply.
-	extraHelmValues := plan.ExtraHelmValues
-
 	var oidcIssuerURL tftypes.String
 	if url := createResp.GetOidcIssuerUrl(); url != "" {
 		oidcIssuerURL = tftypes.StringValue(url)
@@ -134,34 +178,47 @@ func (c *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		oidcIssuerURL = tftypes.StringNull()
 	}
 
-	var oidcIssuerCaCert tftypes.String
-	if certBytes := createResp.GetOidcIssuerCaCert(); len(certBytes) > 0 {
-		oidcIssuerCaCert = tftypes.StringValue(base64.StdEncoding.EncodeToString(certBytes))
-	} else if !plan.OidcIssuerCaCert.IsNull() && !plan.OidcIssuerCaCert.IsUnknown() {
+	oidcIssuerCaCert := util.CACertificateFromBytes(createResp.GetOidcIssuerCaCert())
+	if oidcIssuerCaCert.IsNull() && !plan.OidcIssuerCaCert.IsNull() && !plan.OidcIssuerCaCert.IsUnknown() {
 		oidcIssuerCaCert = plan.OidcIssuerCaCert
-	} else {
-		oidcIssuerCaCert = tftypes.StringNull()
 	}
 
-	state := ClusterModel{
-		ID:                tftypes.StringValue(createResp.GetId()),
-		Name:              tftypes.StringValue(createResp.GetName()),
-		OrgID:             tftypes.StringValue(createResp.GetOrgId()),
-		TrustZoneID:       tftypes.StringValue(createResp.GetTrustZoneId()),
-		KubernetesContext: tftypes.StringValue(createResp.GetKubernetesContext()),
-		TrustProvider:     trustProviderForState(createResp.GetTrustProvider(), plan.TrustProvider),
-		ExtraHelmValues:   extraHelmValues,
-		Profile:           tftypes.StringValue(createResp.GetProfile()),
-		ExternalServer:    tftypes.BoolValue(createResp.GetExternalServer()),
-		OidcIssuerURL:     oidcIssuerURL,
-		OidcIssuerCaCert:  oidcIssuerCaCert,
+	state := ClusterResourceModel{
+		ClusterModel: ClusterModel{
+			ID:                tftypes.StringValue(createResp.GetId()),
+			Name:              tftypes.StringValue(createResp.GetName()),
+			OrgID:             tftypes.StringValue(createResp.GetOrgId()),
+			TrustZoneID:       tftypes.StringValue(createResp.GetTrustZoneId()),
+			KubernetesContext: tftypes.StringValue(createResp.GetKubernetesContext()),
+			TrustProvider:     trustProviderForState(createResp.GetTrustProvider(), plan.TrustProvider),
+			Profile:           tftypes.StringValue(createResp.GetProfile()),
+			ExternalServer:    tftypes.BoolValue(createResp.GetExternalServer()),
+			OidcIssuerURL:     oidcIssuerURL,
+			OidcIssuerCaCert:  oidcIssuerCaCert,
+		},
+		DeletionProtection: plan.DeletionProtection,
+		AdoptExisting:      plan.AdoptExisting,
+		RetainOnDelete:     plan.RetainOnDelete,
+		ValidateHelmValues: plan.ValidateHelmValues,
+	}
+	state.SensitiveExtraHelmValuesVersion = plan.SensitiveExtraHelmValuesVersion
+
+	state.setOidcIssuerCaCertInfo()
+	if err := state.setExtraHelmValues(createResp.GetExtraHelmValues(), plan.extraHelmValuesForms(), sensitivePaths); err != nil {
+		resp.Diagnostics.AddError(
+			"Error processing cluster data",
+			fmt.Sprintf("Could not process extra_helm_values: %s", err),
+		)
+		return
 	}
+	resp.Diagnostics.Append(util.SetSensitiveHelmValuesPaths(ctx, resp.Private, sensitivePaths)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state.ClusterModel))...)
 }
 
 func (c *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state ClusterModel
+	var state ClusterResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -182,15 +239,6 @@ func (c *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	extraHelmValues, err := helmValuesForState(cluster.GetExtraHelmValues(), state.ExtraHelmValues)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error processing cluster data",
-			fmt.Sprintf("Could not process extra_helm_values: %s", err),
-		)
-		return
-	}
-
 	var oidcIssuerURL tftypes.String
 	if url := cluster.GetOidcIssuerUrl(); url != "" {
 		oidcIssuerURL = tftypes.StringValue(url)
@@ -198,48 +246,81 @@ func (c *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		oidcIssuerURL = tftypes.StringNull()
 	}
 
-	var oidcIssuerCaCert tftypes.String
-	if certBytes := cluster.GetOidcIssuerCaCert(); len(certBytes) > 0 {
-		oidcIssuerCaCert = tftypes.StringValue(base64.StdEncoding.EncodeToString(certBytes))
-	} else {
-		oidcIssuerCaCert = tftypes.StringNull()
+	newState := ClusterResourceModel{
+		ClusterModel: ClusterModel{
+			ID:                tftypes.StringValue(cluster.GetId()),
+			Name:              tftypes.StringValue(cluster.GetName()),
+			OrgID:             tftypes.StringValue(cluster.GetOrgId()),
+			TrustZoneID:       tftypes.StringValue(cluster.GetTrustZoneId()),
+			KubernetesContext: tftypes.StringValue(cluster.GetKubernetesContext()),
+			TrustProvider:     trustProviderForState(cluster.GetTrustProvider(), state.TrustProvider),
+			Profile:           tftypes.StringValue(cluster.GetProfile()),
+			ExternalServer:    tftypes.BoolValue(cluster.GetExternalServer()),
+			OidcIssuerURL:     oidcIssuerURL,
+			OidcIssuerCaCert:  util.CACertificateFromBytes(cluster.GetOidcIssuerCaCert()),
+		},
+		DeletionProtection: tftypes.BoolValue(util.DeletionProtected(state.DeletionProtection, deletionProtectionDefault)),
+		AdoptExisting:      tftypes.BoolValue(state.AdoptExisting.ValueBool()),
+		RetainOnDelete:     tftypes.BoolValue(state.RetainOnDelete.ValueBool()),
+		ValidateHelmValues: tftypes.BoolValue(helmschema.Enabled(state.ValidateHelmValues)),
+	}
+	newState.SensitiveExtraHelmValuesVersion = state.SensitiveExtraHelmValuesVersion
+
+	sensitivePaths, diags := util.SensitiveHelmValuesPaths(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	imported, diags := util.HelmValuesImported(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-
-	newState := ClusterModel{
-		ID:                tftypes.StringValue(cluster.GetId()),
-		Name:              tftypes.StringValue(cluster.GetName()),
-		OrgID:             tftypes.StringValue(cluster.GetOrgId()),
-		TrustZoneID:       tftypes.StringValue(cluster.GetTrustZoneId()),
-		KubernetesContext: tftypes.StringValue(cluster.GetKubernetesContext()),
-		TrustProvider:     trustProviderForState(cluster.GetTrustProvider(), state.TrustProvider),
-		ExtraHelmValues:   extraHelmValues,
-		Profile:           tftypes.StringValue(cluster.GetProfile()),
-		ExternalServer:    tftypes.BoolValue(cluster.GetExternalServer()),
-		OidcIssuerURL:     oidcIssuerURL,
-		OidcIssuerCaCert:  oidcIssuerCaCert,
+	extraHelmValues := cluster.GetExtraHelmValues()
+	if imported {
+		// Any of the imported values may have been sensitive.
+		extraHelmValues = nil
+	}
+	newState.setOidcIssuerCaCertInfo()
+	if err := newState.setExtraHelmValues(extraHelmValues, state.extraHelmValuesForms(), sensitivePaths); err != nil {
+		resp.Diagnostics.AddError(
+			"Error processing cluster data",
+			fmt.Sprintf("Could not process extra_helm_values: %s", err),
+		)
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState.ClusterModel))...)
 }
 
 func (c *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan ClusterModel
+	var plan ClusterResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state ClusterModel
+	var state ClusterResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	clusterID := state.ID.ValueString()
+
+	stateOnly, err := util.IsStateOnlyUpdate(req, "deletion_protection", "adopt_existing", "retain_on_delete", "validate_helm_values")
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating cluster", err.Error())
+		return
+	}
+	if stateOnly {
+		state.DeletionProtection = plan.DeletionProtection
+		state.AdoptExisting = plan.AdoptExisting
+		state.RetainOnDelete = plan.RetainOnDelete
+		state.ValidateHelmValues = plan.ValidateHelmValues
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state.ClusterModel))...)
+		return
+	}
 
 	cluster := &clusterpb.Cluster{
-		Id:                &clusterID,
 		Name:              plan.Name.ValueStringPointer(),
-		TrustZoneId:       plan.TrustZoneID.ValueStringPointer(),
 		KubernetesContext: plan.KubernetesContext.ValueStringPointer(),
 		Profile:           plan.Profile.ValueStringPointer(),
 		ExternalServer:    plan.ExternalServer.ValueBoolPointer(),
@@ -249,10 +330,10 @@ func (c *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		cluster.OidcIssuerUrl = plan.OidcIssuerURL.ValueStringPointer()
 	}
 
-	if util.IsStringAttributeNonEmpty(plan.OidcIssuerCaCert) {
-		decodedCert, err := base64.StdEncoding.DecodeString(plan.OidcIssuerCaCert.ValueString())
+	if util.IsStringAttributeNonEmpty(plan.OidcIssuerCaCert.StringValue) {
+		decodedCert, err := plan.OidcIssuerCaCert.Bytes()
 		if err != nil {
-			resp.Diagnostics.AddError("Error decoding oidc_issuer_ca_cert", fmt.Sprintf("Failed to decode oidc_issuer_ca_cert from base64: %s", err))
+			resp.Diagnostics.AddError("Error decoding oidc_issuer_ca_cert", fmt.Sprintf("Failed to decode oidc_issuer_ca_cert: %s", err))
 			return
 		}
 		cluster.OidcIssuerCaCert = decodedCert
@@ -279,15 +360,41 @@ func (c *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		cluster.ExtraHelmValues = parsedHelmValues
 	}
 
-	updateResp, err := c.client.ClusterV1Alpha1().UpdateCluster(ctx, cluster)
+	if !plan.ExtraHelmValuesObject.IsNull() {
+		parsedHelmValues, err := util.HelmValuesObjectToProto(plan.ExtraHelmValuesObject)
+		if err != nil {
+			resp.Diagnostics.AddError("Error parsing extra_helm_values_object", fmt.Sprintf("Failed to parse extra_helm_values_object: %s", err))
+			return
+		}
+		cluster.ExtraHelmValues = parsedHelmValues
+	}
+
+	if !plan.ExtraHelmValuesLayers.IsNull() {
+		parsedHelmValues, err := util.HelmValuesLayersToProto(plan.ExtraHelmValuesLayers)
+		if err != nil {
+			resp.Diagnostics.AddError("Error parsing extra_helm_values_layers", fmt.Sprintf("Failed to parse extra_helm_values_layers: %s", err))
+			return
+		}
+		cluster.ExtraHelmValues = parsedHelmValues
+	}
+
+	var sensitiveExtraHelmValues util.HelmValues
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_extra_helm_values"), &sensitiveExtraHelmValues)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	extraHelmValues, sensitivePaths, err := util.MergeSensitiveHelmValues(cluster.ExtraHelmValues, sensitiveExtraHelmValues)
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating cluster", err.Error())
+		resp.Diagnostics.AddError("Error parsing sensitive_extra_helm_values", fmt.Sprintf("Failed to parse sensitive_extra_helm_values: %s", err))
 		return
 	}
+	cluster.ExtraHelmValues = extraHelmValues
 
-	// Store the plan value for extra_helm_values to preserve the original format
-	// (YAML or JSON) and avoid a plan/state inconsistency on apply.
-	extraHelmValuesStr := plan.ExtraHelmValues
+	updateResp, err := updateCluster(ctx, c.client.ClusterV1Alpha1(), cluster, state.ClusterModel)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating cluster", err.Error())
+		return
+	}
 
 	var oidcIssuerURLStr tftypes.String
 	if url := updateResp.GetOidcIssuerUrl(); url != "" {
@@ -298,40 +405,60 @@ func (c *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		oidcIssuerURLStr = tftypes.StringNull()
 	}
 
-	var oidcIssuerCaCertStr tftypes.String
-	if certBytes := updateResp.GetOidcIssuerCaCert(); len(certBytes) > 0 {
-		oidcIssuerCaCertStr = tftypes.StringValue(base64.StdEncoding.EncodeToString(certBytes))
-	} else if !plan.OidcIssuerCaCert.IsNull() && !plan.OidcIssuerCaCert.IsUnknown() {
+	oidcIssuerCaCertStr := util.CACertificateFromBytes(updateResp.GetOidcIssuerCaCert())
+	if oidcIssuerCaCertStr.IsNull() && !plan.OidcIssuerCaCert.IsNull() && !plan.OidcIssuerCaCert.IsUnknown() {
 		oidcIssuerCaCertStr = plan.OidcIssuerCaCert
-	} else {
-		oidcIssuerCaCertStr = tftypes.StringNull()
 	}
 
-	newState := ClusterModel{
-		ID:                tftypes.StringValue(updateResp.GetId()),
-		Name:              tftypes.StringValue(updateResp.GetName()),
-		OrgID:             tftypes.StringValue(updateResp.GetOrgId()),
-		TrustZoneID:       tftypes.StringValue(updateResp.GetTrustZoneId()),
-		KubernetesContext: tftypes.StringValue(updateResp.GetKubernetesContext()),
-		TrustProvider:     trustProviderForState(updateResp.GetTrustProvider(), plan.TrustProvider),
-		ExtraHelmValues:   extraHelmValuesStr,
-		Profile:           tftypes.StringValue(updateResp.GetProfile()),
-		ExternalServer:    tftypes.BoolValue(updateResp.GetExternalServer()),
-		OidcIssuerURL:     oidcIssuerURLStr,
-		OidcIssuerCaCert:  oidcIssuerCaCertStr,
+	newState := ClusterResourceModel{
+		ClusterModel: ClusterModel{
+			ID:                tftypes.StringValue(updateResp.GetId()),
+			Name:              tftypes.StringValue(updateResp.GetName()),
+			OrgID:             tftypes.StringValue(updateResp.GetOrgId()),
+			TrustZoneID:       tftypes.StringValue(updateResp.GetTrustZoneId()),
+			KubernetesContext: tftypes.StringValue(updateResp.GetKubernetesContext()),
+			TrustProvider:     trustProviderForState(updateResp.GetTrustProvider(), plan.TrustProvider),
+			Profile:           tftypes.StringValue(updateResp.GetProfile()),
+			ExternalServer:    tftypes.BoolValue(updateResp.GetExternalServer()),
+			OidcIssuerURL:     oidcIssuerURLStr,
+			OidcIssuerCaCert:  oidcIssuerCaCertStr,
+		},
+		DeletionProtection: plan.DeletionProtection,
+		AdoptExisting:      plan.AdoptExisting,
+		RetainOnDelete:     plan.RetainOnDelete,
+		ValidateHelmValues: plan.ValidateHelmValues,
+	}
+	newState.SensitiveExtraHelmValuesVersion = plan.SensitiveExtraHelmValuesVersion
+
+	newState.setOidcIssuerCaCertInfo()
+	if err := newState.setExtraHelmValues(updateResp.GetExtraHelmValues(), plan.extraHelmValuesForms(), sensitivePaths); err != nil {
+		resp.Diagnostics.AddError("Error processing cluster data", fmt.Sprintf("Could not process extra_helm_values: %s", err))
+		return
 	}
+	resp.Diagnostics.Append(util.SetSensitiveHelmValuesPaths(ctx, resp.Private, sensitivePaths)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState.ClusterModel))...)
 }
 
 func (c *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state ClusterModel
+	var state ClusterResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if state.RetainOnDelete.ValueBool() {
+		resp.Diagnostics.Append(util.RetainedWarning("cluster", state.Name.ValueString()))
+		return
+	}
+
+	if util.DeletionProtected(state.DeletionProtection, deletionProtectionDefault) {
+		resp.Diagnostics.Append(util.DeletionProtectionError("cluster", state.Name.ValueString()))
+		return
+	}
+
 	err := c.client.ClusterV1Alpha1().DestroyCluster(ctx, state.ID.ValueString())
 	if err != nil {
 		if status.Code(err) != codes.NotFound {
@@ -345,16 +472,40 @@ func (c *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (c *ClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		id, err := importid.Cluster(ctx, c.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing cluster", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+	resp.Diagnostics.Append(util.SetHelmValuesImported(ctx, resp.Private)...)
 }
 
 func (c *ClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data ClusterModel
+	var data ClusterResourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if helmschema.Enabled(data.ValidateHelmValues) {
+		resp.Diagnostics.Append(helmschema.ValidateForms(helmschema.CofideSPIREChart, data.extraHelmValuesForms(), helmschema.Attributes{
+			Document: path.Root("extra_helm_values"),
+			Object:   path.Root("extra_helm_values_object"),
+			Layers:   path.Root("extra_helm_values_layers"),
+		})...)
+
+		sensitive := util.NewHelmValuesFormsNull()
+		sensitive.Document = data.SensitiveExtraHelmValues
+		resp.Diagnostics.Append(helmschema.ValidateForms(helmschema.CofideSPIREChart, sensitive, helmschema.Attributes{
+			Document: path.Root("sensitive_extra_helm_values"),
+		})...)
+	}
 }
 
 // newTrustProvider validates the trust provider kind and returns the corresponding trust provider.
@@ -411,9 +562,9 @@ func k8sPsatConfigToProto(ctx context.Context, model *K8sPsatConfigModel) (*trus
 	cfg.AllowedPodLabelKeys = allowedPodLabelKeys
 
 	if !model.ApiServerCaCert.IsNull() && model.ApiServerCaCert.ValueString() != "" {
-		decoded, err := base64.StdEncoding.DecodeString(model.ApiServerCaCert.ValueString())
+		decoded, err := model.ApiServerCaCert.Bytes()
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode api_server_ca_cert from base64: %w", err)
+			return nil, fmt.Errorf("failed to decode api_server_ca_cert: %w", err)
 		}
 		cfg.ApiServerCaCert = decoded
 	}
@@ -506,11 +657,11 @@ func k8sPsatConfigFromProto(cfg *trustproviderpb.K8SPsatConfig) *K8sPsatConfigMo
 		model.AllowedPodLabelKeys = tftypes.ListNull(tftypes.StringType)
 	}
 
-	if len(cfg.ApiServerCaCert) > 0 {
-		model.ApiServerCaCert = tftypes.StringValue(base64.StdEncoding.EncodeToString(cfg.ApiServerCaCert))
-	} else {
-		model.ApiServerCaCert = tftypes.StringNull()
-	}
+	model.ApiServerCaCert = util.CACertificateFromBytes(cfg.ApiServerCaCert)
+	info := util.CACertificateInfoFromBytes(cfg.ApiServerCaCert)
+	model.ApiServerCaCertFingerprintSHA256 = info.FingerprintSHA256
+	model.ApiServerCaCertNotAfter = info.NotAfter
+	model.ApiServerCaCertSubject = info.Subject
 
 	if cfg.ApiServerUrl != "" {
 		model.ApiServerURL = tftypes.StringValue(cfg.ApiServerUrl)
@@ -537,7 +688,7 @@ func k8sPsatConfigFromProto(cfg *trustproviderpb.K8SPsatConfig) *K8sPsatConfigMo
 }
 
 // parseExtraHelmValues parses the extra_helm_values field from a string to a structpb.Struct object.
-func parseExtraHelmValues(valueStr tftypes.String) (*structpb.Struct, error) {
+func parseExtraHelmValues(valueStr util.HelmValues) (*structpb.Struct, error) {
 	if valueStr.IsNull() || valueStr.ValueString() == "" {
 		return nil, nil
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
@@ -140,7 +141,7 @@ func TestParseExtraHelmValues(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseExtraHelmValues(types.StringValue(tt.valueStr))
+			got, err := parseExtraHelmValues(util.NewHelmValuesValue(tt.valueStr))
 			if !tt.wantErr {
 				require.NoError(t, err)
 				if tt.want == nil {
@@ -261,7 +262,7 @@ func TestTrustProviderRoundTrip(t *testing.T) {
 					AllowedServiceAccounts: nil,
 					AllowedNodeLabelKeys:   types.ListNull(types.StringType),
 					AllowedPodLabelKeys:    types.ListNull(types.StringType),
-					ApiServerCaCert:        types.StringNull(),
+					ApiServerCaCert:        util.NewCACertificateNull(),
 					ApiServerURL:           types.StringNull(),
 					ApiServerTLSServerName: types.StringNull(),
 					ApiServerProxyURL:      types.StringNull(),
@@ -278,7 +279,7 @@ func TestTrustProviderRoundTrip(t *testing.T) {
 					AllowedServiceAccounts: nil,
 					AllowedNodeLabelKeys:   types.ListNull(types.StringType),
 					AllowedPodLabelKeys:    types.ListNull(types.StringType),
-					ApiServerCaCert:        types.StringNull(),
+					ApiServerCaCert:        util.NewCACertificateNull(),
 					ApiServerURL:           types.StringNull(),
 					ApiServerTLSServerName: types.StringNull(),
 					ApiServerProxyURL:      types.StringNull(),
@@ -304,7 +305,7 @@ func TestTrustProviderRoundTrip(t *testing.T) {
 					},
 					AllowedNodeLabelKeys:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue("kubernetes.io/hostname")}),
 					AllowedPodLabelKeys:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("app"), types.StringValue("version")}),
-					ApiServerCaCert:        types.StringValue("dGVzdC1jYQ=="), // base64("test-ca")
+					ApiServerCaCert:        util.NewCACertificateValue("dGVzdC1jYQ=="), // base64("test-ca")
 					ApiServerURL:           types.StringValue("https://kubernetes.default.svc"),
 					ApiServerTLSServerName: types.StringValue("kubernetes"),
 					ApiServerProxyURL:      types.StringValue("http://proxy:3128"),
@@ -345,10 +346,10 @@ func TestK8sPsatConfigToProto_InvalidBase64CaCert(t *testing.T) {
 		Enabled:              types.BoolValue(true),
 		AllowedNodeLabelKeys: types.ListNull(types.StringType),
 		AllowedPodLabelKeys:  types.ListNull(types.StringType),
-		ApiServerCaCert:      types.StringValue("not-valid-base64!!!"),
+		ApiServerCaCert:      util.NewCACertificateValue("not-valid-base64!!!"),
 	}
 	_, err := k8sPsatConfigToProto(context.Background(), model)
-	require.ErrorContains(t, err, "failed to decode api_server_ca_cert from base64")
+	require.ErrorContains(t, err, "failed to decode api_server_ca_cert: not PEM, and not valid base64")
 }
 
 
@@ -3,8 +3,12 @@ package cluster
 import (
 	"context"
 
+	"github.com/cofide/terraform-provider-cofide/internal/helmschema"
 	"github.com/cofide/terraform-provider-cofide/internal/planmodifiers"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -15,10 +19,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-var _ resource.ResourceWithConfigValidators = (*ClusterResource)(nil)
+var (
+	_ resource.ResourceWithConfigValidators = (*ClusterResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*ClusterResource)(nil)
+)
+
+// deletionProtectionDefault is the default of deletion_protection.
+const deletionProtectionDefault = false
 
 func ResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a Cofide Connect cluster. A cluster represents a Kubernetes cluster registered with a trust zone.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -40,8 +51,11 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 				},
 			},
 			"trust_zone_id": schema.StringAttribute{
-				Description: "The ID of the associated trust zone.",
+				Description: "The ID of the associated trust zone. Cannot be changed after creation.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"kubernetes_context": schema.StringAttribute{
 				Description: "The Kubernetes context of the cluster.",
@@ -95,8 +109,39 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 								ElementType: types.StringType,
 							},
 							"api_server_ca_cert": schema.StringAttribute{
-								Description: "Base64-encoded CA certificate of the cluster's API server.",
+								Description: "PEM-encoded CA certificate of the cluster's API server, either as PEM or base64-encoded. Must be a CA certificate that has not expired.",
 								Optional:    true,
+								CustomType:  util.CACertificateType{},
+							},
+							"api_server_ca_cert_fingerprint_sha256": schema.StringAttribute{
+								Description: "The SHA-256 fingerprint of the first certificate in `api_server_ca_cert`, as lowercase hex.",
+								Computed:    true,
+								PlanModifiers: []planmodifier.String{
+									planmodifiers.CACertificateInfoModifier{
+										Certificate: "api_server_ca_cert",
+										Field:       util.CACertificateFingerprintSHA256,
+									},
+								},
+							},
+							"api_server_ca_cert_not_after": schema.StringAttribute{
+								Description: "The time the earliest-expiring certificate in `api_server_ca_cert` expires (RFC3339), for alerting on upcoming expiry.",
+								Computed:    true,
+								PlanModifiers: []planmodifier.String{
+									planmodifiers.CACertificateInfoModifier{
+										Certificate: "api_server_ca_cert",
+										Field:       util.CACertificateNotAfter,
+									},
+								},
+							},
+							"api_server_ca_cert_subject": schema.StringAttribute{
+								Description: "The subject of the first certificate in `api_server_ca_cert`, as an RFC 2253 distinguished name.",
+								Computed:    true,
+								PlanModifiers: []planmodifier.String{
+									planmodifiers.CACertificateInfoModifier{
+										Certificate: "api_server_ca_cert",
+										Field:       util.CACertificateSubject,
+									},
+								},
 							},
 							"api_server_url": schema.StringAttribute{
 								Description: "URL of the cluster's API server.",
@@ -121,6 +166,39 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 			"extra_helm_values": schema.StringAttribute{
 				Description: "Additional Helm values for the Cofide SPIRE Helm chart installation, in YAML format. Use `yamlencode()` to generate from a Terraform map.",
 				Optional:    true,
+				CustomType:  util.HelmValuesType{},
+			},
+			"extra_helm_values_object": schema.DynamicAttribute{
+				Description: "Additional Helm values for the Cofide SPIRE Helm chart installation, as an object. Unlike `extra_helm_values`, plans show changes to individual values. Conflicts with `extra_helm_values` and `extra_helm_values_layers`.",
+				Optional:    true,
+				CustomType:  util.HelmValuesObjectType{},
+			},
+			"extra_helm_values_layers": schema.ListAttribute{
+				Description: "Additional Helm values for the Cofide SPIRE Helm chart installation, as an ordered list of YAML or JSON documents merged the way Helm merges values files: maps are merged key by key, later documents taking precedence, while lists and other values are replaced, and a `null` value removes the key. Conflicts with `extra_helm_values` and `extra_helm_values_object`.",
+				Optional:    true,
+				ElementType: util.HelmValuesType{},
+			},
+			"extra_helm_values_merged": schema.StringAttribute{
+				Description: "The additional Helm values sent to Cofide Connect, as a JSON document, whichever attribute they are configured in. The extra Helm values of an imported cluster are not read until they are next applied, as any of them may have been set through `sensitive_extra_helm_values`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.HelmValuesMergedModifier{
+						Document: path.Root("extra_helm_values"),
+						Object:   path.Root("extra_helm_values_object"),
+						Layers:   path.Root("extra_helm_values_layers"),
+					},
+				},
+			},
+			"sensitive_extra_helm_values": schema.StringAttribute{
+				Description: "Sensitive additional Helm values for the Cofide SPIRE Helm chart installation, in YAML format, deep-merged over the other extra Helm values before they are sent to Cofide Connect. The values are write-only: they are never stored in Terraform state, and are left out of `extra_helm_values_merged` and the other extra Helm values when read back. Changes are only sent when `sensitive_extra_helm_values_version` changes. Requires Terraform 1.11 or later.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				CustomType:  util.HelmValuesType{},
+			},
+			"sensitive_extra_helm_values_version": schema.Int64Attribute{
+				Description: "A version for `sensitive_extra_helm_values`. Change it to send changed sensitive values, which Terraform cannot otherwise detect.",
+				Optional:    true,
 			},
 			"profile": schema.StringAttribute{
 				Description: "The Cofide profile used by the cluster (e.g. `kubernetes`, `istio`). Ensures Cofide SPIRE is configured correctly for the target environment.",
@@ -142,14 +220,52 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 				},
 			},
 			"oidc_issuer_ca_cert": schema.StringAttribute{
-				Description: "The CA certificate (base64-encoded) to validate the cluster's OIDC issuer URL. Use `base64encode(file(...))` to supply a PEM certificate file.",
+				Description: "The PEM-encoded CA certificate to validate the cluster's OIDC issuer URL, either as PEM, such as `file(...)`, or base64-encoded, such as `base64encode(file(...))`. Must be a CA certificate that has not expired.",
 				Optional:    true,
 				Computed:    true,
+				CustomType:  util.CACertificateType{},
 				PlanModifiers: []planmodifier.String{
 					planmodifiers.OptionalComputedModifier{},
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"oidc_issuer_ca_cert_fingerprint_sha256": schema.StringAttribute{
+				Description: "The SHA-256 fingerprint of the first certificate in `oidc_issuer_ca_cert`, as lowercase hex.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.CACertificateInfoModifier{
+						Certificate: "oidc_issuer_ca_cert",
+						Computed:    true,
+						Field:       util.CACertificateFingerprintSHA256,
+					},
+				},
+			},
+			"oidc_issuer_ca_cert_not_after": schema.StringAttribute{
+				Description: "The time the earliest-expiring certificate in `oidc_issuer_ca_cert` expires (RFC3339), for alerting on upcoming expiry.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.CACertificateInfoModifier{
+						Certificate: "oidc_issuer_ca_cert",
+						Computed:    true,
+						Field:       util.CACertificateNotAfter,
+					},
+				},
+			},
+			"oidc_issuer_ca_cert_subject": schema.StringAttribute{
+				Description: "The subject of the first certificate in `oidc_issuer_ca_cert`, as an RFC 2253 distinguished name.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.CACertificateInfoModifier{
+						Certificate: "oidc_issuer_ca_cert",
+						Computed:    true,
+						Field:       util.CACertificateSubject,
+					},
+				},
+			},
+			"deletion_protection":  util.DeletionProtectionAttribute("cluster", deletionProtectionDefault),
+			"adopt_existing":       util.AdoptExistingAttribute("cluster", "name and trust zone"),
+			"retain_on_delete":     util.RetainOnDeleteAttribute("cluster"),
+			"validate_helm_values": helmschema.ValidateHelmValuesAttribute(),
 		},
 	}
 }
@@ -158,6 +274,19 @@ func (c *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 	resp.Schema = ResourceSchema(ctx)
 }
 
+func (c *ClusterResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 1 made trust_zone_id force replacement.
+		0: util.UnchangedStateUpgrader(ResourceSchema(ctx)),
+	}
+}
+
 func (c *ClusterResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
-	return []resource.ConfigValidator{}
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("extra_helm_values"),
+			path.MatchRoot("extra_helm_values_object"),
+			path.MatchRoot("extra_helm_values_layers"),
+		),
+	}
 }
@@ -0,0 +1,16 @@
+package cluster
+
+import (
+	"context"
+
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+)
+
+// updateCluster updates the cluster in state to cluster. The trust zone
+// cannot be changed after creation and is sent as it is in state, as a change
+// to it replaces the cluster rather than updating it.
+func updateCluster(ctx context.Context, client clusterClient, cluster *clusterpb.Cluster, state ClusterModel) (*clusterpb.Cluster, error) {
+	cluster.Id = state.ID.ValueStringPointer()
+	cluster.TrustZoneId = state.TrustZoneID.ValueStringPointer()
+	return client.UpdateCluster(ctx, cluster)
+}
@@ -0,0 +1,47 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	clustersvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/cluster_service/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClusterClient records the updates made.
+type fakeClusterClient struct {
+	updated []*clusterpb.Cluster
+}
+
+func (c *fakeClusterClient) ListClusters(_ context.Context, _ *clustersvcpb.ListClustersRequest_Filter) ([]*clusterpb.Cluster, error) {
+	return nil, nil
+}
+
+func (c *fakeClusterClient) UpdateCluster(_ context.Context, cluster *clusterpb.Cluster) (*clusterpb.Cluster, error) {
+	c.updated = append(c.updated, cluster)
+	return cluster, nil
+}
+
+func TestUpdateClusterKeepsTrustZone(t *testing.T) {
+	client := &fakeClusterClient{}
+	state := ClusterModel{
+		ID:          tftypes.StringValue("c-1"),
+		Name:        tftypes.StringValue("cluster"),
+		TrustZoneID: tftypes.StringValue("tz-1"),
+	}
+
+	_, err := updateCluster(context.Background(), client, &clusterpb.Cluster{
+		Name:        ptrOf("renamed"),
+		TrustZoneId: ptrOf("tz-2"),
+	}, state)
+	require.NoError(t, err)
+
+	require.Len(t, client.updated, 1)
+	updated := client.updated[0]
+	assert.Equal(t, "c-1", updated.GetId())
+	assert.Equal(t, "renamed", updated.GetName())
+	assert.Equal(t, "tz-1", updated.GetTrustZoneId())
+}
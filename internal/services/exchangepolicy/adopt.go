@@ -0,0 +1,37 @@
+package exchangepolicy
+
+import (
+	"context"
+	"fmt"
+
+	exchangepolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/exchange_policy/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// adoptExchangePolicy finds the existing exchange policy with the name and
+// trust zone of policy, which could not be created because it already exists,
+// and updates it to match policy.
+func adoptExchangePolicy(ctx context.Context, client exchangePolicyClient, policy *exchangepolicypb.ExchangePolicy) (*exchangepolicypb.ExchangePolicy, error) {
+	name := policy.GetName()
+	trustZoneID := policy.GetTrustZoneId()
+	policies, err := listTrustZonePolicies(ctx, client, trustZoneID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find the exchange policy to adopt: %w", err)
+	}
+
+	existing, err := util.AdoptionCandidate("exchange policy", fmt.Sprintf("named %q in trust zone %q", name, trustZoneID), policies, func(p *exchangepolicypb.ExchangePolicy) bool {
+		return p.GetName() == name
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	adopted := proto.Clone(policy).(*exchangepolicypb.ExchangePolicy)
+	adopted.Id = existing.GetId()
+	updated, err := client.UpdateExchangePolicy(ctx, adopted, newUpdateMask())
+	if err != nil {
+		return nil, fmt.Errorf("could not update the existing exchange policy %q (%s): %w", name, existing.GetId(), err)
+	}
+	return updated, nil
+}
@@ -0,0 +1,41 @@
+package exchangepolicy
+
+import (
+	"context"
+	"testing"
+
+	exchangepolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/exchange_policy/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdoptExchangePolicy(t *testing.T) {
+	client := &fakeExchangePolicyClient{}
+	client.add("tz-1", "other", exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_ALLOW)
+	client.add("tz-1", "policy", exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_ALLOW)
+	client.add("tz-2", "policy", exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_ALLOW)
+
+	deny := exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_DENY
+	adopted, err := adoptExchangePolicy(context.Background(), client, &exchangepolicypb.ExchangePolicy{
+		Name:        "policy",
+		TrustZoneId: "tz-1",
+		Action:      &deny,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ep-2", adopted.GetId())
+	assert.Equal(t, deny, adopted.GetAction())
+	assert.Equal(t, []string{"update policy"}, client.calls)
+}
+
+func TestAdoptExchangePolicyNotFound(t *testing.T) {
+	client := &fakeExchangePolicyClient{}
+	client.add("tz-2", "policy", exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_ALLOW)
+
+	_, err := adoptExchangePolicy(context.Background(), client, &exchangepolicypb.ExchangePolicy{
+		Name:        "policy",
+		TrustZoneId: "tz-1",
+	})
+	assert.EqualError(t, err, `no exchange policy named "policy" in trust zone "tz-1" found to adopt`)
+	assert.Empty(t, client.calls)
+}
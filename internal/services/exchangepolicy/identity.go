@@ -0,0 +1,71 @@
+package exchangepolicy
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = (*ExchangePolicyResource)(nil)
+
+// ExchangePolicyIdentityModel is the resource identity of an exchange policy,
+// qualified by its trust zone.
+type ExchangePolicyIdentityModel struct {
+	OrgID       types.String `tfsdk:"org_id"`
+	TrustZoneID types.String `tfsdk:"trust_zone_id"`
+	ID          types.String `tfsdk:"id"`
+}
+
+func newIdentityModel(m ExchangePolicyModel) ExchangePolicyIdentityModel {
+	return ExchangePolicyIdentityModel{
+		OrgID:       m.OrgID,
+		TrustZoneID: m.TrustZoneID,
+		ID:          m.ID,
+	}
+}
+
+func (r *ExchangePolicyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"org_id": identityschema.StringAttribute{
+				Description:       "The ID of the organization.",
+				OptionalForImport: true,
+			},
+			"trust_zone_id": identityschema.StringAttribute{
+				Description:       "The ID of the associated trust zone.",
+				OptionalForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the exchange policy.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+var _ resource.ResourceWithIdentity = (*TrustZoneExchangePoliciesResource)(nil)
+
+// TrustZoneExchangePoliciesIdentityModel is the resource identity of the
+// exchange policies of a trust zone, which is the trust zone's ID.
+type TrustZoneExchangePoliciesIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func newTrustZonePoliciesIdentityModel(m TrustZoneExchangePoliciesModel) TrustZoneExchangePoliciesIdentityModel {
+	return TrustZoneExchangePoliciesIdentityModel{
+		ID: m.ID,
+	}
+}
+
+func (r *TrustZoneExchangePoliciesResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the trust zone.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package exchangepolicy
+
+import (
+	"context"
+	"fmt"
+
+	exchangepolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/exchange_policy_service/v1alpha1"
+	exchangepolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/exchange_policy/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ list.ListResource              = &ExchangePolicyResource{}
+	_ list.ListResourceWithConfigure = &ExchangePolicyResource{}
+)
+
+// NewListResource returns the list resource for exchange policies, which lets
+// `terraform query` enumerate existing exchange policies for import.
+func NewListResource() list.ListResource {
+	return &ExchangePolicyResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists Cofide Connect exchange policies.",
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: "Filter by organization ID.",
+				Optional:    true,
+			},
+			"trust_zone_id": listschema.StringAttribute{
+				Description: "Filter by trust zone ID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *ExchangePolicyResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (r *ExchangePolicyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config ExchangePolicyListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &exchangepolicysvcpb.ListExchangePoliciesRequest_Filter{
+		OrgId:       config.OrgID.ValueString(),
+		TrustZoneId: config.TrustZoneID.ValueString(),
+	}
+	policies, err := r.client.ExchangePolicyV1Alpha1().ListExchangePolicies(ctx, filter)
+	if err != nil {
+		diags.AddError(
+			"Error listing exchange policies",
+			fmt.Sprintf("Could not list exchange policies: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.ListResults(ctx, req, policies, func(policy *exchangepolicypb.ExchangePolicy) (util.ListResult, diag.Diagnostics) {
+		model, err := protoToModel(policy)
+		if err != nil {
+			return util.ListResult{}, diag.Diagnostics{
+				diag.NewErrorDiagnostic("Invalid exchange policy response", err.Error()),
+			}
+		}
+		return util.ListResult{
+			DisplayName: policy.GetName(),
+			Resource: ExchangePolicyResourceModel{
+				ExchangePolicyModel: model,
+				AdoptExisting:       tftypes.BoolValue(false),
+			},
+			Identity: newIdentityModel(model),
+		}, nil
+	})
+}
@@ -21,6 +21,14 @@ type ExchangePolicyModel struct {
 	ExternalHooks    tftypes.List   `tfsdk:"external_hooks"`
 }
 
+// ExchangePolicyResourceModel is the model of the exchange policy resource,
+// which adds attributes kept in Terraform state only to those shared with the
+// data sources.
+type ExchangePolicyResourceModel struct {
+	ExchangePolicyModel
+	AdoptExisting tftypes.Bool `tfsdk:"adopt_existing"`
+}
+
 type StringMatcherModel struct {
 	Exact tftypes.String `tfsdk:"exact"`
 	Glob  tftypes.String `tfsdk:"glob"`
@@ -44,3 +52,17 @@ type ExchangePoliciesDataSourceModel struct {
 	Name             tftypes.String        `tfsdk:"name"`
 	ExchangePolicies []ExchangePolicyModel `tfsdk:"exchange_policies"`
 }
+
+// TrustZoneExchangePoliciesModel is the complete set of exchange policies of
+// a trust zone.
+type TrustZoneExchangePoliciesModel struct {
+	ID               tftypes.String `tfsdk:"id"`
+	TrustZoneID      tftypes.String `tfsdk:"trust_zone_id"`
+	ExchangePolicies tftypes.List   `tfsdk:"exchange_policies"`
+}
+
+// ExchangePolicyListModel is the configuration of the exchange policy list resource.
+type ExchangePolicyListModel struct {
+	OrgID       tftypes.String `tfsdk:"org_id"`
+	TrustZoneID tftypes.String `tfsdk:"trust_zone_id"`
+}
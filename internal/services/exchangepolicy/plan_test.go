@@ -0,0 +1,54 @@
+package exchangepolicy_test
+
+import (
+	"testing"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPlanTrustZoneExchangePolicies checks that the computed values of
+// exchange policies in a set are planned from the policy with the same name,
+// not the one at the same position.
+func TestPlanTrustZoneExchangePolicies(t *testing.T) {
+	const resourceType = "cofide_connect_trust_zone_exchange_policies"
+
+	policy := func(id, name, action string) map[string]any {
+		return map[string]any{"id": id, "org_id": "org-1", "trust_zone_id": "tz-1", "name": name, "action": action, "outbound_scopes": []any{}}
+	}
+	server := providertest.NewServer(t)
+	resp := providertest.PlanUpdate(t, server, resourceType,
+		map[string]any{
+			"id":                "tz-1",
+			"trust_zone_id":     "tz-1",
+			"exchange_policies": []any{policy("ep-1", "deny-all", "DENY"), policy("ep-2", "allow-ci", "ALLOW")},
+		},
+		map[string]any{
+			"trust_zone_id": "tz-1",
+			"exchange_policies": []any{
+				map[string]any{"name": "allow-deploy", "action": "ALLOW"},
+				map[string]any{"name": "allow-ci", "action": "ALLOW"},
+				map[string]any{"name": "deny-all"},
+			},
+		},
+	)
+	assert.Empty(t, resp.RequiresReplace)
+
+	attributes := providertest.Attributes(t, resp.PlannedState, server.ResourceSchema(t, resourceType).ValueType())
+	var policies []tftypes.Value
+	require.NoError(t, attributes["exchange_policies"].As(&policies))
+	require.Len(t, policies, 3)
+
+	plannedAttribute := func(i int, name string) tftypes.Value {
+		var attributes map[string]tftypes.Value
+		require.NoError(t, policies[i].As(&attributes))
+		return attributes[name]
+	}
+	assert.False(t, plannedAttribute(0, "id").IsKnown())
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "tz-1"), plannedAttribute(0, "trust_zone_id"))
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "ep-2"), plannedAttribute(1, "id"))
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "ep-1"), plannedAttribute(2, "id"))
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "DENY"), plannedAttribute(2, "action"))
+}
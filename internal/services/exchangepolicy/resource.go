@@ -6,8 +6,11 @@ import (
 
 	exchangepolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/exchange_policy_service/v1alpha1"
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -47,19 +50,22 @@ func (r *ExchangePolicyResource) Configure(_ context.Context, req resource.Confi
 }
 
 func (r *ExchangePolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan ExchangePolicyModel
+	var plan ExchangePolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	policy, err := modelToProto(ctx, plan)
+	policy, err := modelToProto(ctx, plan.ExchangePolicyModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid exchange policy", err.Error())
 		return
 	}
 	createResp, err := r.client.ExchangePolicyV1Alpha1().CreateExchangePolicy(ctx, policy)
+	if util.ShouldAdopt(plan.AdoptExisting, err) {
+		createResp, err = adoptExchangePolicy(ctx, r.client.ExchangePolicyV1Alpha1(), policy)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating exchange policy",
@@ -68,16 +74,21 @@ func (r *ExchangePolicyResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	state, err := protoToModel(createResp)
+	model, err := protoToModel(createResp)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid exchange policy response", err.Error())
 		return
 	}
+	state := ExchangePolicyResourceModel{
+		ExchangePolicyModel: model,
+		AdoptExisting:       plan.AdoptExisting,
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(model))...)
 }
 
 func (r *ExchangePolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state ExchangePolicyModel
+	var state ExchangePolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -106,29 +117,46 @@ func (r *ExchangePolicyResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	newState, err := protoToModel(getResp)
+	model, err := protoToModel(getResp)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid exchange policy response", err.Error())
 		return
 	}
+	newState := ExchangePolicyResourceModel{
+		ExchangePolicyModel: model,
+		AdoptExisting:       tftypes.BoolValue(state.AdoptExisting.ValueBool()),
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(model))...)
 }
 
 func (r *ExchangePolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan ExchangePolicyModel
+	var plan ExchangePolicyResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state ExchangePolicyModel
+	var state ExchangePolicyResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	policy, err := modelToProto(ctx, plan)
+	stateOnly, err := util.IsStateOnlyUpdate(req, "adopt_existing")
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating exchange policy", err.Error())
+		return
+	}
+	if stateOnly {
+		state.AdoptExisting = plan.AdoptExisting
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state.ExchangePolicyModel))...)
+		return
+	}
+
+	policy, err := modelToProto(ctx, plan.ExchangePolicyModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid exchange policy", err.Error())
 		return
@@ -146,12 +174,17 @@ func (r *ExchangePolicyResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	newState, err := protoToModel(updateResp)
+	model, err := protoToModel(updateResp)
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid exchange policy response", err.Error())
 		return
 	}
+	newState := ExchangePolicyResourceModel{
+		ExchangePolicyModel: model,
+		AdoptExisting:       plan.AdoptExisting,
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(model))...)
 }
 
 // newUpdateMask builds the update mask covering every field the resource can
@@ -179,7 +212,7 @@ func newUpdateMask() *exchangepolicysvcpb.UpdateExchangePolicyRequest_UpdateMask
 }
 
 func (r *ExchangePolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state ExchangePolicyModel
+	var state ExchangePolicyResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -199,5 +232,14 @@ func (r *ExchangePolicyResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *ExchangePolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		id, err := importid.ExchangePolicy(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing exchange policy", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -122,6 +123,7 @@ func ResourceSchema() schema.Schema {
 				Description: "The name of the exchange policy.",
 				Required:    true,
 			},
+			"adopt_existing": util.AdoptExistingAttribute("exchange policy", "name and trust zone"),
 			"action": schema.StringAttribute{
 				Description: "Action to take when all conditions match. One of `ALLOW`, or `DENY`. Defaults to ALLOW when unset.",
 				Optional:    true,
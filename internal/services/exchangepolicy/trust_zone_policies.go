@@ -0,0 +1,204 @@
+package exchangepolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	exchangepolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/exchange_policy_service/v1alpha1"
+	exchangepolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/exchange_policy/v1alpha1"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exchangePolicyClient is the part of the exchange policy API used to
+// reconcile the policies of a trust zone.
+type exchangePolicyClient interface {
+	CreateExchangePolicy(ctx context.Context, policy *exchangepolicypb.ExchangePolicy) (*exchangepolicypb.ExchangePolicy, error)
+	ListExchangePolicies(ctx context.Context, filter *exchangepolicysvcpb.ListExchangePoliciesRequest_Filter) ([]*exchangepolicypb.ExchangePolicy, error)
+	UpdateExchangePolicy(ctx context.Context, policy *exchangepolicypb.ExchangePolicy, mask *exchangepolicysvcpb.UpdateExchangePolicyRequest_UpdateMask) (*exchangepolicypb.ExchangePolicy, error)
+	DestroyExchangePolicy(ctx context.Context, id string) error
+}
+
+// listTrustZonePolicies returns the exchange policies of a trust zone.
+func listTrustZonePolicies(ctx context.Context, client exchangePolicyClient, trustZoneID string) ([]*exchangepolicypb.ExchangePolicy, error) {
+	policies, err := client.ListExchangePolicies(ctx, &exchangepolicysvcpb.ListExchangePoliciesRequest_Filter{
+		TrustZoneId: trustZoneID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list the exchange policies of trust zone %q: %w", trustZoneID, err)
+	}
+
+	return slices.DeleteFunc(slices.Clone(policies), func(policy *exchangepolicypb.ExchangePolicy) bool {
+		return policy.GetTrustZoneId() != trustZoneID
+	}), nil
+}
+
+// orderPolicies returns policies with those named in names first, in the
+// order of names, followed by the rest sorted by name.
+func orderPolicies(policies []*exchangepolicypb.ExchangePolicy, names []string) []*exchangepolicypb.ExchangePolicy {
+	ordered := slices.Clone(policies)
+	rank := func(policy *exchangepolicypb.ExchangePolicy) int {
+		if i := slices.Index(names, policy.GetName()); i >= 0 {
+			return i
+		}
+		return len(names)
+	}
+	slices.SortStableFunc(ordered, func(a, b *exchangepolicypb.ExchangePolicy) int {
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return ra - rb
+		}
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return ordered
+}
+
+// reconcilePolicies makes desired the complete set of exchange policies of a
+// trust zone, given its existing policies. Desired policies are matched to
+// existing ones by name; those that differ are updated and those that are
+// missing are created. The remaining existing policies are then deleted, as
+// are any with the same name as one already matched.
+//
+// Every change is attempted even if some fail. The policies afterwards are
+// returned, those for desired first and in the same order, along with the
+// failures.
+func reconcilePolicies(ctx context.Context, client exchangePolicyClient, desired []ExchangePolicyModel, existing []*exchangepolicypb.ExchangePolicy) ([]*exchangepolicypb.ExchangePolicy, error) {
+	remaining := slices.Clone(existing)
+
+	var errs []error
+	var result []*exchangepolicypb.ExchangePolicy
+	for _, model := range desired {
+		name := model.Name.ValueString()
+
+		var current *exchangepolicypb.ExchangePolicy
+		if i := slices.IndexFunc(remaining, func(p *exchangepolicypb.ExchangePolicy) bool { return p.GetName() == name }); i >= 0 {
+			current = remaining[i]
+			remaining = slices.Delete(remaining, i, i+1)
+		}
+
+		policy, err := modelToProto(ctx, model)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid exchange policy %q: %w", name, err))
+			if current != nil {
+				result = append(result, current)
+			}
+			continue
+		}
+
+		switch {
+		case current == nil:
+			created, err := client.CreateExchangePolicy(ctx, policy)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not create exchange policy %q: %w", name, err))
+				continue
+			}
+			result = append(result, created)
+		case policyMatches(ctx, model, current):
+			result = append(result, current)
+		default:
+			policy.Id = current.GetId()
+			updated, err := client.UpdateExchangePolicy(ctx, policy, newUpdateMask())
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not update exchange policy %q: %w", name, err))
+				result = append(result, current)
+				continue
+			}
+			result = append(result, updated)
+		}
+	}
+
+	for _, policy := range remaining {
+		err := client.DestroyExchangePolicy(ctx, policy.GetId())
+		if err != nil && status.Code(err) != codes.NotFound {
+			errs = append(errs, fmt.Errorf("could not delete exchange policy %q (%s): %w", policy.GetName(), policy.GetId(), err))
+			result = append(result, policy)
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// policyMatches returns whether current already has the values of model.
+// Values of model that are unknown are taken to match.
+func policyMatches(ctx context.Context, model ExchangePolicyModel, current *exchangepolicypb.ExchangePolicy) bool {
+	currentModel, err := protoToModel(current)
+	if err != nil {
+		return false
+	}
+	model.ID = currentModel.ID
+	model.OrgID = currentModel.OrgID
+	model.TrustZoneID = currentModel.TrustZoneID
+
+	want, diags := tftypes.ObjectValueFrom(ctx, trustZonePolicyType().AttrTypes, model)
+	if diags.HasError() {
+		return false
+	}
+	got, diags := tftypes.ObjectValueFrom(ctx, trustZonePolicyType().AttrTypes, currentModel)
+	if diags.HasError() {
+		return false
+	}
+	return fillUnknown(want, got).Equal(got)
+}
+
+// fillUnknown returns planned with its unknown values, including those of
+// nested objects, replaced by the corresponding values of prior. It applies
+// UseStateForUnknown to a whole policy.
+func fillUnknown(planned, prior attr.Value) attr.Value {
+	if planned.IsUnknown() {
+		return prior
+	}
+
+	plannedObject, ok := planned.(tftypes.Object)
+	if !ok || planned.IsNull() {
+		return planned
+	}
+	priorObject, ok := prior.(tftypes.Object)
+	if !ok || prior.IsNull() || prior.IsUnknown() {
+		return planned
+	}
+
+	attributes := plannedObject.Attributes()
+	priorAttributes := priorObject.Attributes()
+	for name, value := range attributes {
+		if priorValue, ok := priorAttributes[name]; ok {
+			attributes[name] = fillUnknown(value, priorValue)
+		}
+	}
+	return tftypes.ObjectValueMust(plannedObject.AttributeTypes(context.Background()), attributes)
+}
+
+// policiesToList returns the list value of policies.
+func policiesToList(ctx context.Context, policies []*exchangepolicypb.ExchangePolicy) (tftypes.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	models := make([]ExchangePolicyModel, 0, len(policies))
+	for _, policy := range policies {
+		model, err := protoToModel(policy)
+		if err != nil {
+			diags.AddError("Invalid exchange policy response", err.Error())
+			return tftypes.ListNull(trustZonePolicyType()), diags
+		}
+		models = append(models, model)
+	}
+
+	list, d := tftypes.ListValueFrom(ctx, trustZonePolicyType(), models)
+	diags.Append(d...)
+	return list, diags
+}
+
+// policyNames returns the names of the policies in list, which may be null.
+func policyNames(ctx context.Context, list tftypes.List) ([]string, diag.Diagnostics) {
+	var models []ExchangePolicyModel
+	diags := list.ElementsAs(ctx, &models, true)
+
+	names := make([]string, 0, len(models))
+	for _, model := range models {
+		names = append(names, model.Name.ValueString())
+	}
+	return names, diags
+}
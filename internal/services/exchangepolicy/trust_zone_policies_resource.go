@@ -0,0 +1,339 @@
+package exchangepolicy
+
+import (
+	"context"
+	"fmt"
+
+	exchangepolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/exchange_policy/v1alpha1"
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &TrustZoneExchangePoliciesResource{}
+	_ resource.ResourceWithImportState    = &TrustZoneExchangePoliciesResource{}
+	_ resource.ResourceWithModifyPlan     = &TrustZoneExchangePoliciesResource{}
+	_ resource.ResourceWithValidateConfig = &TrustZoneExchangePoliciesResource{}
+)
+
+type TrustZoneExchangePoliciesResource struct {
+	client sdkclient.ClientSet
+}
+
+func NewTrustZonePoliciesResource() resource.Resource {
+	return &TrustZoneExchangePoliciesResource{}
+}
+
+func (r *TrustZoneExchangePoliciesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connect_trust_zone_exchange_policies"
+}
+
+func (r *TrustZoneExchangePoliciesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdkclient.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected sdkclient.ClientSet, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TrustZoneExchangePoliciesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TrustZoneExchangePoliciesModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := desiredPolicies(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.ExchangePolicyV1Alpha1()
+	trustZoneID := plan.TrustZoneID.ValueString()
+
+	existing, err := listTrustZonePolicies(ctx, client, trustZoneID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating trust zone exchange policies", err.Error())
+		return
+	}
+
+	policies, err := reconcilePolicies(ctx, client, desired, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating trust zone exchange policies",
+			fmt.Sprintf("Could not reconcile the exchange policies of trust zone %q: %s", trustZoneID, err),
+		)
+		// The policies are kept in state, which Terraform marks as tainted
+		// so that they are replaced on the next apply.
+		if len(policies) == 0 {
+			return
+		}
+	}
+
+	newState, diags := trustZonePoliciesToModel(ctx, plan.TrustZoneID, policies)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newTrustZonePoliciesIdentityModel(newState))...)
+}
+
+func (r *TrustZoneExchangePoliciesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TrustZoneExchangePoliciesModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// After import only the ID is known.
+	trustZoneID := state.TrustZoneID
+	if trustZoneID.IsNull() {
+		trustZoneID = state.ID
+	}
+
+	policies, err := listTrustZonePolicies(ctx, r.client.ExchangePolicyV1Alpha1(), trustZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading trust zone exchange policies", err.Error())
+		return
+	}
+
+	// Policies keep their configured order, and unmanaged policies are
+	// added at the end, where they show up as drift.
+	names, diags := policyNames(ctx, state.ExchangePolicies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	newState, diags := trustZonePoliciesToModel(ctx, trustZoneID, orderPolicies(policies, names))
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newTrustZonePoliciesIdentityModel(newState))...)
+}
+
+func (r *TrustZoneExchangePoliciesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan TrustZoneExchangePoliciesModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := desiredPolicies(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.ExchangePolicyV1Alpha1()
+	trustZoneID := plan.TrustZoneID.ValueString()
+
+	existing, err := listTrustZonePolicies(ctx, client, trustZoneID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating trust zone exchange policies", err.Error())
+		return
+	}
+
+	// On failure the state records the policies that exist, so that the
+	// next plan shows the changes that are still needed.
+	policies, err := reconcilePolicies(ctx, client, desired, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating trust zone exchange policies",
+			fmt.Sprintf("Could not reconcile the exchange policies of trust zone %q: %s", trustZoneID, err),
+		)
+	}
+
+	newState, diags := trustZonePoliciesToModel(ctx, plan.TrustZoneID, policies)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newTrustZonePoliciesIdentityModel(newState))...)
+}
+
+func (r *TrustZoneExchangePoliciesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TrustZoneExchangePoliciesModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.ExchangePolicyV1Alpha1()
+	trustZoneID := state.TrustZoneID.ValueString()
+
+	existing, err := listTrustZonePolicies(ctx, client, trustZoneID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting trust zone exchange policies", err.Error())
+		return
+	}
+
+	remaining, err := reconcilePolicies(ctx, client, nil, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting trust zone exchange policies",
+			fmt.Sprintf("Could not delete the exchange policies of trust zone %q: %s", trustZoneID, err),
+		)
+
+		newState, diags := trustZonePoliciesToModel(ctx, state.TrustZoneID, remaining)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newTrustZonePoliciesIdentityModel(newState))...)
+	}
+}
+
+// ModifyPlan matches the planned policies to the policies in state by name,
+// and plans the computed values of each from its match, so that inserting,
+// removing or reordering policies only changes the policies concerned.
+func (r *TrustZoneExchangePoliciesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan TrustZoneExchangePoliciesModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.ExchangePolicies.IsUnknown() {
+		return
+	}
+
+	prior := map[string]attr.Value{}
+	if !req.State.Raw.IsNull() {
+		var state TrustZoneExchangePoliciesModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, element := range state.ExchangePolicies.Elements() {
+			if name, ok := policyName(element); ok {
+				if _, seen := prior[name]; !seen {
+					prior[name] = element
+				}
+			}
+		}
+	}
+
+	elements := plan.ExchangePolicies.Elements()
+	for i, element := range elements {
+		object, ok := element.(tftypes.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+
+		if name, ok := policyName(element); ok && prior[name] != nil {
+			object = fillUnknown(object, prior[name]).(tftypes.Object)
+		}
+
+		attributes := object.Attributes()
+		attributes["trust_zone_id"] = plan.TrustZoneID
+		elements[i] = tftypes.ObjectValueMust(trustZonePolicyType().AttrTypes, attributes)
+	}
+
+	policies, diags := tftypes.ListValue(trustZonePolicyType(), elements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("exchange_policies"), policies)...)
+}
+
+func (r *TrustZoneExchangePoliciesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var policies tftypes.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("exchange_policies"), &policies)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, element := range policies.Elements() {
+		name, ok := policyName(element)
+		if !ok {
+			continue
+		}
+		if seen[name] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("exchange_policies").AtListIndex(i).AtName("name"),
+				"Duplicate exchange policy name",
+				fmt.Sprintf("Exchange policies are matched by name, so each name can be used only once, but %q is used more than once.", name),
+			)
+		}
+		seen[name] = true
+	}
+}
+
+func (r *TrustZoneExchangePoliciesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		id, err := importid.TrustZone(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing trust zone exchange policies", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// trustZonePoliciesToModel returns the model of the exchange policies of a
+// trust zone.
+func trustZonePoliciesToModel(ctx context.Context, trustZoneID tftypes.String, policies []*exchangepolicypb.ExchangePolicy) (TrustZoneExchangePoliciesModel, diag.Diagnostics) {
+	list, diags := policiesToList(ctx, policies)
+	return TrustZoneExchangePoliciesModel{
+		ID:               trustZoneID,
+		TrustZoneID:      trustZoneID,
+		ExchangePolicies: list,
+	}, diags
+}
+
+// desiredPolicies returns the planned policies, with the trust zone of the
+// set.
+func desiredPolicies(ctx context.Context, plan TrustZoneExchangePoliciesModel) ([]ExchangePolicyModel, diag.Diagnostics) {
+	var desired []ExchangePolicyModel
+	diags := plan.ExchangePolicies.ElementsAs(ctx, &desired, false)
+	for i := range desired {
+		desired[i].TrustZoneID = plan.TrustZoneID
+	}
+	return desired, diags
+}
+
+// policyName returns the name of a policy object, if it is known.
+func policyName(value attr.Value) (string, bool) {
+	object, ok := value.(tftypes.Object)
+	if !ok || object.IsNull() || object.IsUnknown() {
+		return "", false
+	}
+	name, ok := object.Attributes()["name"].(tftypes.String)
+	if !ok || name.IsNull() || name.IsUnknown() {
+		return "", false
+	}
+	return name.ValueString(), true
+}
@@ -0,0 +1,100 @@
+package exchangepolicy
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TrustZonePoliciesResourceSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Authoritatively manages the complete set of Cofide Connect exchange policies of a trust zone. " +
+			"Policies are matched to the existing policies of the trust zone by name. " +
+			"Policies that are not configured here, including ones created outside of Terraform, show up as drift and are deleted on apply, " +
+			"after the configured policies have been created and updated. Destroying this resource deletes every exchange policy of the trust zone. " +
+			"Do not use it together with `cofide_connect_exchange_policy` for the same trust zone.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the trust zone.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"trust_zone_id": schema.StringAttribute{
+				Description: "The ID of the trust zone whose exchange policies are managed. Cannot be changed after creation.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"exchange_policies": schema.ListNestedAttribute{
+				Description: "The exchange policies of the trust zone, with the same attributes as `cofide_connect_exchange_policy`. Names must be unique.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: trustZonePolicyAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// trustZonePolicyAttributes returns the attributes of a policy in the set,
+// which are those of the exchange policy resource with trust_zone_id taken
+// from the set. adopt_existing is left out, as the set adopts existing
+// policies anyway.
+//
+// Plan modifiers are removed because they match the elements of a list by
+// index, so inserting a policy would plan it with the computed values of the
+// policy it displaced. ModifyPlan matches policies by name instead.
+func trustZonePolicyAttributes() map[string]schema.Attribute {
+	attrs := withoutPlanModifiers(ResourceSchema().Attributes)
+	delete(attrs, "adopt_existing")
+	attrs["trust_zone_id"] = schema.StringAttribute{
+		Description: "The ID of the trust zone to which this policy applies. Set from the `trust_zone_id` of the set.",
+		Computed:    true,
+	}
+	return attrs
+}
+
+// withoutPlanModifiers returns a copy of attrs, and of their nested
+// attributes, without plan modifiers.
+func withoutPlanModifiers(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	result := make(map[string]schema.Attribute, len(attrs))
+	for name, attr := range attrs {
+		switch a := attr.(type) {
+		case schema.StringAttribute:
+			a.PlanModifiers = nil
+			attr = a
+		case schema.Int64Attribute:
+			a.PlanModifiers = nil
+			attr = a
+		case schema.ListAttribute:
+			a.PlanModifiers = nil
+			attr = a
+		case schema.SingleNestedAttribute:
+			a.PlanModifiers = nil
+			a.Attributes = withoutPlanModifiers(a.Attributes)
+			attr = a
+		case schema.ListNestedAttribute:
+			a.PlanModifiers = nil
+			a.NestedObject.Attributes = withoutPlanModifiers(a.NestedObject.Attributes)
+			attr = a
+		}
+		result[name] = attr
+	}
+	return result
+}
+
+// trustZonePolicyType is the type of a policy in the set.
+func trustZonePolicyType() tftypes.ObjectType {
+	return schema.NestedAttributeObject{Attributes: trustZonePolicyAttributes()}.Type().(tftypes.ObjectType)
+}
+
+func (r *TrustZoneExchangePoliciesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = TrustZonePoliciesResourceSchema()
+}
@@ -0,0 +1,234 @@
+package exchangepolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	exchangepolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/exchange_policy_service/v1alpha1"
+	exchangepolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/exchange_policy/v1alpha1"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeExchangePolicyClient stores exchange policies in memory and records the
+// changes made to them. Changing the policy named failName fails.
+type fakeExchangePolicyClient struct {
+	policies []*exchangepolicypb.ExchangePolicy
+	nextID   int
+	failName string
+	calls    []string
+}
+
+func (c *fakeExchangePolicyClient) add(trustZoneID, name string, action exchangepolicypb.ExchangePolicyAction) {
+	c.nextID++
+	c.policies = append(c.policies, &exchangepolicypb.ExchangePolicy{
+		Id:          fmt.Sprintf("ep-%d", c.nextID),
+		OrgId:       "org-1",
+		Name:        name,
+		TrustZoneId: trustZoneID,
+		Action:      &action,
+	})
+}
+
+func (c *fakeExchangePolicyClient) CreateExchangePolicy(_ context.Context, policy *exchangepolicypb.ExchangePolicy) (*exchangepolicypb.ExchangePolicy, error) {
+	c.calls = append(c.calls, "create "+policy.Name)
+	if policy.Name == c.failName {
+		return nil, errors.New("create failed")
+	}
+	c.nextID++
+	created := proto.Clone(policy).(*exchangepolicypb.ExchangePolicy)
+	created.Id = fmt.Sprintf("ep-%d", c.nextID)
+	created.OrgId = "org-1"
+	c.policies = append(c.policies, created)
+	return created, nil
+}
+
+func (c *fakeExchangePolicyClient) ListExchangePolicies(_ context.Context, filter *exchangepolicysvcpb.ListExchangePoliciesRequest_Filter) ([]*exchangepolicypb.ExchangePolicy, error) {
+	var policies []*exchangepolicypb.ExchangePolicy
+	for _, policy := range c.policies {
+		if policy.TrustZoneId == filter.TrustZoneId {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+func (c *fakeExchangePolicyClient) UpdateExchangePolicy(_ context.Context, policy *exchangepolicypb.ExchangePolicy, _ *exchangepolicysvcpb.UpdateExchangePolicyRequest_UpdateMask) (*exchangepolicypb.ExchangePolicy, error) {
+	c.calls = append(c.calls, "update "+policy.Name)
+	if policy.Name == c.failName {
+		return nil, errors.New("update failed")
+	}
+	for i, existing := range c.policies {
+		if existing.Id == policy.Id {
+			updated := proto.Clone(policy).(*exchangepolicypb.ExchangePolicy)
+			updated.OrgId = existing.OrgId
+			c.policies[i] = updated
+			return updated, nil
+		}
+	}
+	return nil, fmt.Errorf("exchange policy %q not found", policy.Id)
+}
+
+func (c *fakeExchangePolicyClient) DestroyExchangePolicy(_ context.Context, id string) error {
+	for i, policy := range c.policies {
+		if policy.Id == id {
+			c.calls = append(c.calls, "delete "+policy.Name)
+			if policy.Name == c.failName {
+				return errors.New("delete failed")
+			}
+			c.policies = append(c.policies[:i], c.policies[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("exchange policy %q not found", id)
+}
+
+// desiredPolicy returns a planned policy, whose ID is not yet known.
+func desiredPolicy(t *testing.T, name string, action exchangepolicypb.ExchangePolicyAction) ExchangePolicyModel {
+	t.Helper()
+
+	model, err := protoToModel(&exchangepolicypb.ExchangePolicy{Name: name, TrustZoneId: "tz-1", Action: &action})
+	require.NoError(t, err)
+	model.ID = types.StringUnknown()
+	model.OrgID = types.StringUnknown()
+	model.OutboundScopes = types.ListUnknown(types.StringType)
+	return model
+}
+
+func policySummaries(policies []*exchangepolicypb.ExchangePolicy) []string {
+	var summaries []string
+	for _, policy := range policies {
+		summaries = append(summaries, fmt.Sprintf("%s %s %s", policy.GetId(), policy.GetName(), policy.GetAction()))
+	}
+	return summaries
+}
+
+func TestReconcilePolicies(t *testing.T) {
+	allow := exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_ALLOW
+	deny := exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_DENY
+
+	ctx := context.Background()
+	client := &fakeExchangePolicyClient{}
+	client.add("tz-1", "unchanged", deny)
+	client.add("tz-1", "changed", allow)
+	client.add("tz-1", "unmanaged", allow)
+	client.add("tz-1", "unchanged", deny)
+	client.add("tz-2", "other-trust-zone", allow)
+
+	existing, err := listTrustZonePolicies(ctx, client, "tz-1")
+	require.NoError(t, err)
+
+	policies, err := reconcilePolicies(ctx, client, []ExchangePolicyModel{
+		desiredPolicy(t, "new", deny),
+		desiredPolicy(t, "changed", deny),
+		desiredPolicy(t, "unchanged", deny),
+	}, existing)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"create new", "update changed", "delete unmanaged", "delete unchanged"}, client.calls)
+	assert.Equal(t, []string{
+		"ep-6 new EXCHANGE_POLICY_ACTION_DENY",
+		"ep-2 changed EXCHANGE_POLICY_ACTION_DENY",
+		"ep-1 unchanged EXCHANGE_POLICY_ACTION_DENY",
+	}, policySummaries(policies))
+
+	listed, err := listTrustZonePolicies(ctx, client, "tz-2")
+	require.NoError(t, err)
+	assert.Len(t, listed, 1, "the policies of other trust zones should be left alone")
+}
+
+func TestReconcilePoliciesPartialFailure(t *testing.T) {
+	allow := exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_ALLOW
+	deny := exchangepolicypb.ExchangePolicyAction_EXCHANGE_POLICY_ACTION_DENY
+
+	ctx := context.Background()
+	client := &fakeExchangePolicyClient{failName: "unmanaged"}
+	client.add("tz-1", "unmanaged", allow)
+
+	existing, err := listTrustZonePolicies(ctx, client, "tz-1")
+	require.NoError(t, err)
+
+	policies, err := reconcilePolicies(ctx, client, []ExchangePolicyModel{desiredPolicy(t, "new", deny)}, existing)
+	assert.EqualError(t, err, `could not delete exchange policy "unmanaged" (ep-1): delete failed`)
+	assert.Equal(t, []string{
+		"ep-2 new EXCHANGE_POLICY_ACTION_DENY",
+		"ep-1 unmanaged EXCHANGE_POLICY_ACTION_ALLOW",
+	}, policySummaries(policies))
+}
+
+func TestOrderPolicies(t *testing.T) {
+	policies := []*exchangepolicypb.ExchangePolicy{
+		{Id: "ep-1", Name: "d"},
+		{Id: "ep-2", Name: "b"},
+		{Id: "ep-3", Name: "c"},
+		{Id: "ep-4", Name: "a"},
+	}
+
+	var names []string
+	for _, policy := range orderPolicies(policies, []string{"c", "d"}) {
+		names = append(names, policy.GetName())
+	}
+	assert.Equal(t, []string{"c", "d", "a", "b"}, names)
+}
+
+func TestFillUnknown(t *testing.T) {
+	attrTypes := map[string]attr.Type{
+		"id":     types.StringType,
+		"name":   types.StringType,
+		"nested": types.ObjectType{AttrTypes: map[string]attr.Type{"url": types.StringType}},
+	}
+	nested := func(url types.String) types.Object {
+		return types.ObjectValueMust(attrTypes["nested"].(types.ObjectType).AttrTypes, map[string]attr.Value{"url": url})
+	}
+
+	planned := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"id":     types.StringUnknown(),
+		"name":   types.StringValue("new-name"),
+		"nested": nested(types.StringUnknown()),
+	})
+	prior := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"id":     types.StringValue("ep-1"),
+		"name":   types.StringValue("old-name"),
+		"nested": nested(types.StringValue("https://example.com")),
+	})
+
+	assert.Equal(t, types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"id":     types.StringValue("ep-1"),
+		"name":   types.StringValue("new-name"),
+		"nested": nested(types.StringValue("https://example.com")),
+	}), fillUnknown(planned, prior))
+}
+
+func TestTrustZonePolicyAttributesHaveNoPlanModifiers(t *testing.T) {
+	var check func(prefix string, attrs map[string]schema.Attribute)
+	check = func(prefix string, attrs map[string]schema.Attribute) {
+		for name, a := range attrs {
+			switch a := a.(type) {
+			case schema.StringAttribute:
+				assert.Empty(t, a.PlanModifiers, prefix+name)
+			case schema.Int64Attribute:
+				assert.Empty(t, a.PlanModifiers, prefix+name)
+			case schema.ListAttribute:
+				assert.Empty(t, a.PlanModifiers, prefix+name)
+			case schema.SingleNestedAttribute:
+				assert.Empty(t, a.PlanModifiers, prefix+name)
+				check(prefix+name+".", a.Attributes)
+			case schema.ListNestedAttribute:
+				assert.Empty(t, a.PlanModifiers, prefix+name)
+				check(prefix+name+".", a.NestedObject.Attributes)
+			default:
+				t.Errorf("%s%s: unexpected attribute type %T; handle it in withoutPlanModifiers", prefix, name, a)
+			}
+		}
+	}
+	check("", trustZonePolicyAttributes())
+
+	// The exchange policy resource itself must keep its plan modifiers.
+	assert.NotEmpty(t, ResourceSchema().Attributes["id"].(schema.StringAttribute).PlanModifiers)
+}
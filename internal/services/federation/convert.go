@@ -0,0 +1,15 @@
+package federation
+
+import (
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func protoToModel(federation *federationpb.Federation) FederationModel {
+	return FederationModel{
+		ID:                tftypes.StringValue(federation.GetId()),
+		OrgID:             tftypes.StringValue(federation.GetOrgId()),
+		TrustZoneID:       tftypes.StringValue(federation.GetTrustZoneId()),
+		RemoteTrustZoneID: tftypes.StringValue(federation.GetRemoteTrustZoneId()),
+	}
+}
@@ -0,0 +1,46 @@
+package federation
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = (*FederationResource)(nil)
+
+// FederationIdentityModel is the resource identity of a federation, qualified
+// by the trust zone it federates.
+type FederationIdentityModel struct {
+	OrgID       types.String `tfsdk:"org_id"`
+	TrustZoneID types.String `tfsdk:"trust_zone_id"`
+	ID          types.String `tfsdk:"id"`
+}
+
+func newIdentityModel(m FederationModel) FederationIdentityModel {
+	return FederationIdentityModel{
+		OrgID:       m.OrgID,
+		TrustZoneID: m.TrustZoneID,
+		ID:          m.ID,
+	}
+}
+
+func (f *FederationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"org_id": identityschema.StringAttribute{
+				Description:       "The ID of the organization.",
+				OptionalForImport: true,
+			},
+			"trust_zone_id": identityschema.StringAttribute{
+				Description:       "The ID of the associated trust zone.",
+				OptionalForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the federation.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
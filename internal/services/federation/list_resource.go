@@ -0,0 +1,82 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+
+	federationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/federation_service/v1alpha1"
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+)
+
+var (
+	_ list.ListResource              = &FederationResource{}
+	_ list.ListResourceWithConfigure = &FederationResource{}
+)
+
+// NewListResource returns the list resource for federations, which lets
+// `terraform query` enumerate existing federations for import.
+func NewListResource() list.ListResource {
+	return &FederationResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists Cofide Connect federations.",
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: "Filter by organization ID.",
+				Optional:    true,
+			},
+			"trust_zone_id": listschema.StringAttribute{
+				Description: "Filter by trust zone ID.",
+				Optional:    true,
+			},
+			"remote_trust_zone_id": listschema.StringAttribute{
+				Description: "Filter by remote trust zone ID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (f *FederationResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (f *FederationResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config FederationListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &federationsvcpb.ListFederationsRequest_Filter{
+		OrgId:             config.OrgID.ValueStringPointer(),
+		TrustZoneId:       config.TrustZoneID.ValueStringPointer(),
+		RemoteTrustZoneId: config.RemoteTrustZoneID.ValueStringPointer(),
+	}
+	federations, err := f.client.FederationV1Alpha1().ListFederations(ctx, filter)
+	if err != nil {
+		diags.AddError(
+			"Error listing federations",
+			fmt.Sprintf("Could not list federations: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.ListResults(ctx, req, federations, func(federation *federationpb.Federation) (util.ListResult, diag.Diagnostics) {
+		model := protoToModel(federation)
+		return util.ListResult{
+			DisplayName: federation.GetId(),
+			Resource:    model,
+			Identity:    newIdentityModel(model),
+		}, nil
+	})
+}
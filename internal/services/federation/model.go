@@ -8,3 +8,10 @@ type FederationModel struct {
 	TrustZoneID       types.String `tfsdk:"trust_zone_id"`
 	RemoteTrustZoneID types.String `tfsdk:"remote_trust_zone_id"`
 }
+
+// FederationListModel is the configuration of the federation list resource.
+type FederationListModel struct {
+	OrgID             types.String `tfsdk:"org_id"`
+	TrustZoneID       types.String `tfsdk:"trust_zone_id"`
+	RemoteTrustZoneID types.String `tfsdk:"remote_trust_zone_id"`
+}
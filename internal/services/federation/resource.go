@@ -6,6 +6,7 @@ import (
 
 	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
@@ -78,6 +79,7 @@ func (f *FederationResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state))...)
 }
 
 func (f *FederationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -102,14 +104,10 @@ func (f *FederationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	newState := FederationModel{
-		ID:                tftypes.StringValue(federation.GetId()),
-		OrgID:             tftypes.StringValue(federation.GetOrgId()),
-		TrustZoneID:       tftypes.StringValue(federation.GetTrustZoneId()),
-		RemoteTrustZoneID: tftypes.StringValue(federation.GetRemoteTrustZoneId()),
-	}
+	newState := protoToModel(federation)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
 }
 
 func (f *FederationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -141,7 +139,16 @@ func (f *FederationResource) Delete(ctx context.Context, req resource.DeleteRequ
 }
 
 func (f *FederationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		id, err := importid.Federation(ctx, f.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing federation", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func (f *FederationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
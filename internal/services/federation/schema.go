@@ -3,16 +3,21 @@ package federation
 import (
 	"context"
 
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 )
 
-var _ resource.ResourceWithConfigValidators = (*FederationResource)(nil)
+var (
+	_ resource.ResourceWithConfigValidators = (*FederationResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*FederationResource)(nil)
+)
 
 func ResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a Cofide Connect federation. Establishes a trust relationship between two trust zones so their workloads can mutually authenticate.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -30,12 +35,18 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 				},
 			},
 			"trust_zone_id": schema.StringAttribute{
-				Description: "The ID of the associated trust zone.",
+				Description: "The ID of the associated trust zone. Cannot be changed after creation.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"remote_trust_zone_id": schema.StringAttribute{
-				Description: "The ID of the associated remote trust zone.",
+				Description: "The ID of the associated remote trust zone. Cannot be changed after creation.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 		},
 	}
@@ -45,6 +56,14 @@ func (f *FederationResource) Schema(ctx context.Context, req resource.SchemaRequ
 	resp.Schema = ResourceSchema(ctx)
 }
 
+func (f *FederationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 1 made trust_zone_id and remote_trust_zone_id force
+		// replacement.
+		0: util.UnchangedStateUpgrader(ResourceSchema(ctx)),
+	}
+}
+
 func (f *FederationResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{}
 }
@@ -0,0 +1,35 @@
+package federationmesh
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = (*FederationMeshResource)(nil)
+
+// FederationMeshIdentityModel is the resource identity of a federation mesh.
+// A mesh is not a Cofide Connect object, so its ID is built from the IDs of
+// its trust zones.
+type FederationMeshIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func newIdentityModel(m FederationMeshModel) FederationMeshIdentityModel {
+	return FederationMeshIdentityModel{
+		ID: m.ID,
+	}
+}
+
+func (r *FederationMeshResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The sorted IDs of the trust zones of the mesh, separated by commas.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,191 @@
+package federationmesh
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	federationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/federation_service/v1alpha1"
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// federationClient is the part of the federation API used to reconcile a
+// mesh.
+type federationClient interface {
+	CreateFederation(ctx context.Context, federation *federationpb.Federation) (*federationpb.Federation, error)
+	ListFederations(ctx context.Context, filter *federationsvcpb.ListFederationsRequest_Filter) ([]*federationpb.Federation, error)
+	DestroyFederation(ctx context.Context, id string) error
+}
+
+// edge is a federation from one trust zone to another.
+type edge struct {
+	trustZoneID       string
+	remoteTrustZoneID string
+}
+
+func edgeOf(federation *federationpb.Federation) edge {
+	return edge{trustZoneID: federation.GetTrustZoneId(), remoteTrustZoneID: federation.GetRemoteTrustZoneId()}
+}
+
+// listFederations returns the federations between the given trust zones,
+// sorted by trust zone, remote trust zone and ID.
+func listFederations(ctx context.Context, client federationClient, trustZoneIDs []string) ([]*federationpb.Federation, error) {
+	var federations []*federationpb.Federation
+	for _, trustZoneID := range trustZoneIDs {
+		listed, err := client.ListFederations(ctx, &federationsvcpb.ListFederationsRequest_Filter{
+			TrustZoneId: &trustZoneID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not list federations of trust zone %q: %w", trustZoneID, err)
+		}
+
+		for _, federation := range listed {
+			if federation.GetTrustZoneId() == trustZoneID && slices.Contains(trustZoneIDs, federation.GetRemoteTrustZoneId()) {
+				federations = append(federations, federation)
+			}
+		}
+	}
+
+	sortFederations(federations)
+	return federations, nil
+}
+
+// partition splits existing into the federations with the given IDs, which
+// the mesh created or adopted before, and the others.
+func partition(ids []string, existing []*federationpb.Federation) (federations, unmanaged []*federationpb.Federation) {
+	for _, federation := range existing {
+		if slices.Contains(ids, federation.GetId()) {
+			federations = append(federations, federation)
+		} else {
+			unmanaged = append(unmanaged, federation)
+		}
+	}
+	return federations, unmanaged
+}
+
+// readFederations returns the federations between members that the mesh
+// manages, those with the given IDs, and the other federations between them,
+// which were created outside of the mesh and are reported as drift. With nil
+// ids, as after import, the mesh adopts every federation between members.
+func readFederations(ctx context.Context, client federationClient, members, ids []string) (federations, unmanaged []*federationpb.Federation, err error) {
+	existing, err := listFederations(ctx, client, members)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ids == nil {
+		return existing, nil, nil
+	}
+	federations, unmanaged = partition(ids, existing)
+	return federations, unmanaged, nil
+}
+
+// managed returns the federations of existing that belong to the mesh over
+// members: those with the given IDs, which the mesh created or adopted
+// before, and, if adopt is true, any other federation that is an edge of the
+// mesh. Without adopt, an edge of the mesh that exists as a federation outside
+// of the mesh is an error, as the mesh would otherwise create it again or
+// leave a duplicate that Read keeps reporting. Adopted duplicates are
+// destroyed by reconcile; other federations outside of the mesh are never
+// destroyed.
+func managed(members, ids []string, existing []*federationpb.Federation, adopt bool) ([]*federationpb.Federation, error) {
+	federations, unmanaged := partition(ids, existing)
+
+	var conflicts []string
+	for _, federation := range unmanaged {
+		e := edgeOf(federation)
+		if !isMeshEdge(members, e) {
+			continue
+		}
+		if !adopt {
+			conflicts = append(conflicts, fmt.Sprintf("%q from trust zone %q to %q", federation.GetId(), e.trustZoneID, e.remoteTrustZoneID))
+			continue
+		}
+		federations = append(federations, federation)
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("federations between the trust zones of the mesh already exist: %s. Set adopt_existing to manage them as part of the mesh, or destroy them", strings.Join(conflicts, ", "))
+	}
+
+	return federations, nil
+}
+
+// diff returns the edges of the full mesh over members that are missing from
+// federations, and the federations that are not part of it: those from or to
+// a trust zone that is not a member, and duplicates of an edge.
+func diff(members []string, federations []*federationpb.Federation) (create []edge, destroy []*federationpb.Federation) {
+	seen := make(map[edge]bool, len(federations))
+	for _, federation := range federations {
+		e := edgeOf(federation)
+		if seen[e] || !isMeshEdge(members, e) {
+			destroy = append(destroy, federation)
+			continue
+		}
+		seen[e] = true
+	}
+
+	for _, from := range members {
+		for _, to := range members {
+			if e := (edge{trustZoneID: from, remoteTrustZoneID: to}); from != to && !seen[e] {
+				create = append(create, e)
+			}
+		}
+	}
+	return create, destroy
+}
+
+func isMeshEdge(members []string, e edge) bool {
+	return e.trustZoneID != e.remoteTrustZoneID && slices.Contains(members, e.trustZoneID) && slices.Contains(members, e.remoteTrustZoneID)
+}
+
+// reconcile makes federations, the existing federations between a set of
+// trust zones, the full mesh over members, which must be a subset of those
+// trust zones.
+//
+// Every change is attempted even if some fail. The federations between the
+// trust zones afterwards are returned along with the failures.
+func reconcile(ctx context.Context, client federationClient, members []string, federations []*federationpb.Federation) ([]*federationpb.Federation, error) {
+	federations = slices.Clone(federations)
+	create, destroy := diff(members, federations)
+
+	var errs []error
+	for _, federation := range destroy {
+		err := client.DestroyFederation(ctx, federation.GetId())
+		if err != nil && status.Code(err) != codes.NotFound {
+			errs = append(errs, fmt.Errorf("could not destroy federation %q: %w", federation.GetId(), err))
+			continue
+		}
+		federations = slices.DeleteFunc(federations, func(f *federationpb.Federation) bool {
+			return f.GetId() == federation.GetId()
+		})
+	}
+
+	for _, e := range create {
+		federation, err := client.CreateFederation(ctx, &federationpb.Federation{
+			TrustZoneId:       &e.trustZoneID,
+			RemoteTrustZoneId: &e.remoteTrustZoneID,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not create federation from trust zone %q to %q: %w", e.trustZoneID, e.remoteTrustZoneID, err))
+			continue
+		}
+		federations = append(federations, federation)
+	}
+
+	sortFederations(federations)
+	return federations, errors.Join(errs...)
+}
+
+func sortFederations(federations []*federationpb.Federation) {
+	slices.SortFunc(federations, func(a, b *federationpb.Federation) int {
+		return cmp.Or(
+			cmp.Compare(a.GetTrustZoneId(), b.GetTrustZoneId()),
+			cmp.Compare(a.GetRemoteTrustZoneId(), b.GetRemoteTrustZoneId()),
+			cmp.Compare(a.GetId(), b.GetId()),
+		)
+	})
+}
@@ -0,0 +1,290 @@
+package federationmesh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	federationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/federation_service/v1alpha1"
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFederationClient stores federations in memory. Creating a federation
+// from failCreateFrom and destroying failDestroyID fail.
+type fakeFederationClient struct {
+	federations    []*federationpb.Federation
+	nextID         int
+	failCreateFrom string
+	failDestroyID  string
+	created        []string
+	destroyed      []string
+}
+
+func (c *fakeFederationClient) add(trustZoneID, remoteTrustZoneID string) *federationpb.Federation {
+	c.nextID++
+	id := fmt.Sprintf("fed-%d", c.nextID)
+	federation := &federationpb.Federation{
+		Id:                &id,
+		TrustZoneId:       &trustZoneID,
+		RemoteTrustZoneId: &remoteTrustZoneID,
+	}
+	c.federations = append(c.federations, federation)
+	return federation
+}
+
+func (c *fakeFederationClient) CreateFederation(_ context.Context, federation *federationpb.Federation) (*federationpb.Federation, error) {
+	if federation.GetTrustZoneId() == c.failCreateFrom {
+		return nil, errors.New("create failed")
+	}
+	c.created = append(c.created, federation.GetTrustZoneId()+">"+federation.GetRemoteTrustZoneId())
+	return c.add(federation.GetTrustZoneId(), federation.GetRemoteTrustZoneId()), nil
+}
+
+func (c *fakeFederationClient) ListFederations(_ context.Context, filter *federationsvcpb.ListFederationsRequest_Filter) ([]*federationpb.Federation, error) {
+	var federations []*federationpb.Federation
+	for _, federation := range c.federations {
+		if filter.TrustZoneId == nil || federation.GetTrustZoneId() == *filter.TrustZoneId {
+			federations = append(federations, federation)
+		}
+	}
+	return federations, nil
+}
+
+func (c *fakeFederationClient) DestroyFederation(_ context.Context, id string) error {
+	if id == c.failDestroyID {
+		return errors.New("destroy failed")
+	}
+	for i, federation := range c.federations {
+		if federation.GetId() == id {
+			c.federations = append(c.federations[:i], c.federations[i+1:]...)
+			c.destroyed = append(c.destroyed, id)
+			return nil
+		}
+	}
+	return fmt.Errorf("federation %q not found", id)
+}
+
+// edges returns the edges of federations as "from>to" strings.
+func edges(federations []*federationpb.Federation) []string {
+	var edges []string
+	for _, federation := range federations {
+		edges = append(edges, federation.GetTrustZoneId()+">"+federation.GetRemoteTrustZoneId())
+	}
+	return edges
+}
+
+func TestListFederations(t *testing.T) {
+	client := &fakeFederationClient{}
+	client.add("tz-2", "tz-1")
+	client.add("tz-1", "tz-2")
+	client.add("tz-1", "tz-3")
+	client.add("tz-3", "tz-1")
+
+	federations, err := listFederations(context.Background(), client, []string{"tz-1", "tz-2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tz-1>tz-2", "tz-2>tz-1"}, edges(federations))
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		existing      [][2]string
+		scope         []string
+		members       []string
+		wantCreated   []string
+		wantDestroyed []string
+		wantEdges     []string
+	}{
+		{
+			name:        "new mesh",
+			scope:       []string{"tz-1", "tz-2", "tz-3"},
+			members:     []string{"tz-1", "tz-2", "tz-3"},
+			wantCreated: []string{"tz-1>tz-2", "tz-1>tz-3", "tz-2>tz-1", "tz-2>tz-3", "tz-3>tz-1", "tz-3>tz-2"},
+			wantEdges:   []string{"tz-1>tz-2", "tz-1>tz-3", "tz-2>tz-1", "tz-2>tz-3", "tz-3>tz-1", "tz-3>tz-2"},
+		},
+		{
+			name:        "trust zone added",
+			existing:    [][2]string{{"tz-1", "tz-2"}, {"tz-2", "tz-1"}},
+			scope:       []string{"tz-1", "tz-2", "tz-3"},
+			members:     []string{"tz-1", "tz-2", "tz-3"},
+			wantCreated: []string{"tz-1>tz-3", "tz-2>tz-3", "tz-3>tz-1", "tz-3>tz-2"},
+			wantEdges:   []string{"tz-1>tz-2", "tz-1>tz-3", "tz-2>tz-1", "tz-2>tz-3", "tz-3>tz-1", "tz-3>tz-2"},
+		},
+		{
+			name:          "trust zone removed",
+			existing:      [][2]string{{"tz-1", "tz-2"}, {"tz-2", "tz-1"}, {"tz-1", "tz-3"}, {"tz-3", "tz-1"}, {"tz-2", "tz-3"}, {"tz-3", "tz-2"}},
+			scope:         []string{"tz-1", "tz-2", "tz-3"},
+			members:       []string{"tz-1", "tz-2"},
+			wantDestroyed: []string{"fed-3", "fed-5", "fed-4", "fed-6"},
+			wantEdges:     []string{"tz-1>tz-2", "tz-2>tz-1"},
+		},
+		{
+			name:          "missing and duplicate edges",
+			existing:      [][2]string{{"tz-1", "tz-2"}, {"tz-1", "tz-2"}},
+			scope:         []string{"tz-1", "tz-2"},
+			members:       []string{"tz-1", "tz-2"},
+			wantCreated:   []string{"tz-2>tz-1"},
+			wantDestroyed: []string{"fed-2"},
+			wantEdges:     []string{"tz-1>tz-2", "tz-2>tz-1"},
+		},
+		{
+			name:          "destroyed",
+			existing:      [][2]string{{"tz-1", "tz-2"}, {"tz-2", "tz-1"}, {"tz-2", "tz-3"}},
+			scope:         []string{"tz-1", "tz-2"},
+			wantDestroyed: []string{"fed-1", "fed-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &fakeFederationClient{}
+			for _, e := range tt.existing {
+				client.add(e[0], e[1])
+			}
+
+			existing, err := listFederations(ctx, client, tt.scope)
+			require.NoError(t, err)
+
+			federations, err := reconcile(ctx, client, tt.members, existing)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantCreated, client.created)
+			assert.Equal(t, tt.wantDestroyed, client.destroyed)
+			assert.Equal(t, tt.wantEdges, edges(federations))
+		})
+	}
+}
+
+func TestManaged(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  [][2]string
+		members   []string
+		ids       []string
+		adopt     bool
+		wantEdges []string
+		wantErr   string
+	}{
+		{
+			name:      "created by the mesh",
+			existing:  [][2]string{{"tz-1", "tz-2"}, {"tz-2", "tz-1"}},
+			members:   []string{"tz-1", "tz-2"},
+			ids:       []string{"fed-1", "fed-2"},
+			wantEdges: []string{"tz-1>tz-2", "tz-2>tz-1"},
+		},
+		{
+			name:     "existing federation",
+			existing: [][2]string{{"tz-1", "tz-2"}},
+			members:  []string{"tz-1", "tz-2"},
+			wantErr:  `federations between the trust zones of the mesh already exist: "fed-1" from trust zone "tz-1" to "tz-2". Set adopt_existing to manage them as part of the mesh, or destroy them`,
+		},
+		{
+			name:      "existing federations adopted",
+			existing:  [][2]string{{"tz-1", "tz-2"}, {"tz-1", "tz-2"}},
+			members:   []string{"tz-1", "tz-2"},
+			adopt:     true,
+			wantEdges: []string{"tz-1>tz-2", "tz-1>tz-2"},
+		},
+		{
+			name:      "trust zone added with existing federation adopted",
+			existing:  [][2]string{{"tz-1", "tz-2"}, {"tz-2", "tz-1"}, {"tz-3", "tz-1"}},
+			members:   []string{"tz-1", "tz-2", "tz-3"},
+			ids:       []string{"fed-1", "fed-2"},
+			adopt:     true,
+			wantEdges: []string{"tz-1>tz-2", "tz-2>tz-1", "tz-3>tz-1"},
+		},
+		{
+			name:      "federations outside of the mesh",
+			existing:  [][2]string{{"tz-1", "tz-2"}, {"tz-2", "tz-1"}, {"tz-2", "tz-3"}},
+			members:   []string{"tz-1", "tz-2"},
+			ids:       []string{"fed-1", "fed-2"},
+			wantEdges: []string{"tz-1>tz-2", "tz-2>tz-1"},
+		},
+		{
+			name:     "duplicate federation created outside of the mesh",
+			existing: [][2]string{{"tz-1", "tz-2"}, {"tz-2", "tz-1"}, {"tz-1", "tz-2"}},
+			members:  []string{"tz-1", "tz-2"},
+			ids:      []string{"fed-1", "fed-2"},
+			wantErr:  `federations between the trust zones of the mesh already exist: "fed-3" from trust zone "tz-1" to "tz-2". Set adopt_existing to manage them as part of the mesh, or destroy them`,
+		},
+		{
+			name:      "destroyed",
+			existing:  [][2]string{{"tz-1", "tz-2"}, {"tz-2", "tz-1"}, {"tz-1", "tz-2"}},
+			ids:       []string{"fed-1", "fed-2"},
+			wantEdges: []string{"tz-1>tz-2", "tz-2>tz-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeFederationClient{}
+			for _, e := range tt.existing {
+				client.add(e[0], e[1])
+			}
+
+			federations, err := managed(tt.members, tt.ids, client.federations, tt.adopt)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEdges, edges(federations))
+		})
+	}
+}
+
+func TestReadFederations(t *testing.T) {
+	client := &fakeFederationClient{}
+	client.add("tz-1", "tz-2")
+	client.add("tz-2", "tz-1")
+	client.add("tz-1", "tz-2")
+	client.add("tz-2", "tz-3")
+
+	t.Run("federation created outside of the mesh", func(t *testing.T) {
+		federations, unmanaged, err := readFederations(context.Background(), client, []string{"tz-1", "tz-2"}, []string{"fed-1", "fed-2"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tz-1>tz-2", "tz-2>tz-1"}, edges(federations))
+		require.Len(t, unmanaged, 1)
+		assert.Equal(t, "fed-3", unmanaged[0].GetId())
+	})
+
+	t.Run("imported", func(t *testing.T) {
+		federations, unmanaged, err := readFederations(context.Background(), client, []string{"tz-1", "tz-2"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tz-1>tz-2", "tz-1>tz-2", "tz-2>tz-1"}, edges(federations))
+		assert.Empty(t, unmanaged)
+	})
+}
+
+func TestReconcilePartialFailure(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeFederationClient{failCreateFrom: "tz-3", failDestroyID: "fed-3"}
+	client.add("tz-1", "tz-2")
+	client.add("tz-2", "tz-1")
+	client.add("tz-1", "tz-4")
+	client.add("tz-4", "tz-1")
+
+	existing, err := listFederations(ctx, client, []string{"tz-1", "tz-2", "tz-3", "tz-4"})
+	require.NoError(t, err)
+
+	federations, err := reconcile(ctx, client, []string{"tz-1", "tz-2", "tz-3"}, existing)
+	assert.EqualError(t, err, `could not destroy federation "fed-3": destroy failed
+could not create federation from trust zone "tz-3" to "tz-1": create failed
+could not create federation from trust zone "tz-3" to "tz-2": create failed`)
+
+	assert.Equal(t, []string{"tz-1>tz-2", "tz-1>tz-3", "tz-1>tz-4", "tz-2>tz-1", "tz-2>tz-3"}, edges(federations))
+}
+
+func TestParseMeshID(t *testing.T) {
+	members, err := parseMeshID("tz-2, tz-1,tz-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tz-1", "tz-2"}, members)
+	assert.Equal(t, "tz-1,tz-2", meshID(members).ValueString())
+
+	_, err = parseMeshID("tz-1")
+	assert.EqualError(t, err, `invalid federation mesh ID "tz-1": expected the IDs of at least two trust zones separated by commas`)
+}
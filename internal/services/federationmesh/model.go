@@ -0,0 +1,28 @@
+package federationmesh
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type FederationMeshModel struct {
+	ID                   types.String `tfsdk:"id"`
+	TrustZoneIDs         types.Set    `tfsdk:"trust_zone_ids"`
+	Federations          types.List   `tfsdk:"federations"`
+	UnmanagedFederations types.List   `tfsdk:"unmanaged_federations"`
+	AdoptExisting        types.Bool   `tfsdk:"adopt_existing"`
+}
+
+type MeshFederationModel struct {
+	ID                types.String `tfsdk:"id"`
+	TrustZoneID       types.String `tfsdk:"trust_zone_id"`
+	RemoteTrustZoneID types.String `tfsdk:"remote_trust_zone_id"`
+}
+
+var meshFederationType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                   types.StringType,
+		"trust_zone_id":        types.StringType,
+		"remote_trust_zone_id": types.StringType,
+	},
+}
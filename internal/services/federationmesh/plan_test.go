@@ -0,0 +1,59 @@
+package federationmesh_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+)
+
+// meshState returns the state of a federation mesh over trustZoneIDs with
+// the given federations.
+func meshState(trustZoneIDs []any, federations ...any) map[string]any {
+	ids := make([]string, 0, len(trustZoneIDs))
+	for _, id := range trustZoneIDs {
+		ids = append(ids, id.(string))
+	}
+	slices.Sort(ids)
+
+	return map[string]any{
+		"id":             strings.Join(ids, ","),
+		"trust_zone_ids": trustZoneIDs,
+		"federations":    federations,
+	}
+}
+
+// withUnmanaged returns state with the given federations between its trust
+// zones that the mesh does not manage.
+func withUnmanaged(state map[string]any, federations ...any) map[string]any {
+	state["unmanaged_federations"] = federations
+	return state
+}
+
+func meshFederation(id, trustZoneID, remoteTrustZoneID string) map[string]any {
+	return map[string]any{"id": id, "trust_zone_id": trustZoneID, "remote_trust_zone_id": remoteTrustZoneID}
+}
+
+// TestPlanDrift checks that the federations of a mesh are left unknown when
+// the mesh has drifted, including when federations between its trust zones
+// were created outside of it.
+func TestPlanDrift(t *testing.T) {
+	providertest.RunPlanTests(t, "cofide_connect_federation_mesh", []providertest.PlanTest{
+		{
+			Name:        "federation deleted outside terraform",
+			State:       meshState([]any{"tz-1", "tz-2"}, meshFederation("fed-1", "tz-1", "tz-2")),
+			Config:      map[string]any{"trust_zone_ids": []any{"tz-1", "tz-2"}},
+			WantUnknown: []string{"federations"},
+		},
+		{
+			Name: "federation created outside of the mesh",
+			State: withUnmanaged(
+				meshState([]any{"tz-1", "tz-2"}, meshFederation("fed-1", "tz-1", "tz-2"), meshFederation("fed-2", "tz-2", "tz-1")),
+				meshFederation("fed-3", "tz-1", "tz-2"),
+			),
+			Config:      map[string]any{"trust_zone_ids": []any{"tz-1", "tz-2"}},
+			WantUnknown: []string{"federations"},
+		},
+	})
+}
@@ -0,0 +1,446 @@
+package federationmesh
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &FederationMeshResource{}
+	_ resource.ResourceWithImportState = &FederationMeshResource{}
+	_ resource.ResourceWithModifyPlan  = &FederationMeshResource{}
+)
+
+type FederationMeshResource struct {
+	client sdkclient.ClientSet
+}
+
+func NewResource() resource.Resource {
+	return &FederationMeshResource{}
+}
+
+func (r *FederationMeshResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connect_federation_mesh"
+	// The identity is derived from trust_zone_ids, which can be updated in
+	// place.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *FederationMeshResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdkclient.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected sdkclient.ClientSet, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FederationMeshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FederationMeshModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := trustZoneIDs(ctx, plan.TrustZoneIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.FederationV1Alpha1()
+
+	existing, err := listFederations(ctx, client, members)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating federation mesh",
+			fmt.Sprintf("Could not list existing federations: %s", err),
+		)
+		return
+	}
+
+	existing, err = managed(members, nil, existing, plan.AdoptExisting.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating federation mesh", err.Error())
+		return
+	}
+
+	federations, err := reconcile(ctx, client, members, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating federation mesh",
+			fmt.Sprintf("Could not create the federations of the mesh: %s", err),
+		)
+		// Federations that were created are kept in state, which Terraform
+		// marks as tainted so that the mesh is replaced on the next apply.
+		if len(federations) == 0 {
+			return
+		}
+	}
+
+	newState, diags := meshToModel(ctx, members, federations, nil)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	newState.AdoptExisting = plan.AdoptExisting
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+}
+
+func (r *FederationMeshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FederationMeshModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := stateTrustZoneIDs(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// After import the mesh has no federations in state yet, and adopts
+	// those between its trust zones.
+	var ids []string
+	if !state.Federations.IsNull() {
+		ids, diags = federationIDs(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	federations, unmanaged, err := readFederations(ctx, r.client.FederationV1Alpha1(), members, ids)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading federation mesh",
+			fmt.Sprintf("Could not read the federations of mesh %q: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	newState, diags := meshToModel(ctx, members, federations, unmanaged)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	newState.AdoptExisting = tftypes.BoolValue(state.AdoptExisting.ValueBool())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+}
+
+func (r *FederationMeshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state FederationMeshModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := trustZoneIDs(ctx, plan.TrustZoneIDs)
+	resp.Diagnostics.Append(diags...)
+	previous, diags := trustZoneIDs(ctx, state.TrustZoneIDs)
+	resp.Diagnostics.Append(diags...)
+	ids, diags := federationIDs(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.FederationV1Alpha1()
+	scope := union(previous, members)
+
+	existing, err := listFederations(ctx, client, scope)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating federation mesh",
+			fmt.Sprintf("Could not read the federations of mesh %q: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	existing, err = managed(members, ids, existing, plan.AdoptExisting.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating federation mesh", err.Error())
+		return
+	}
+
+	federations, err := reconcile(ctx, client, members, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating federation mesh",
+			fmt.Sprintf("Could not reconcile the federations of mesh %q: %s", state.ID.ValueString(), err),
+		)
+		// Removed trust zones stay in state until their federations have
+		// been destroyed, so that the next apply tries again.
+		members = scope
+	}
+
+	newState, diags := meshToModel(ctx, members, federations, nil)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	newState.AdoptExisting = plan.AdoptExisting
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+}
+
+func (r *FederationMeshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FederationMeshModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, diags := trustZoneIDs(ctx, state.TrustZoneIDs)
+	resp.Diagnostics.Append(diags...)
+	ids, diags := federationIDs(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.FederationV1Alpha1()
+
+	existing, err := listFederations(ctx, client, members)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting federation mesh",
+			fmt.Sprintf("Could not read the federations of mesh %q: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+	// Only the federations the mesh manages are destroyed.
+	existing, _ = managed(nil, ids, existing, false)
+
+	remaining, err := reconcile(ctx, client, nil, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting federation mesh",
+			fmt.Sprintf("Could not destroy the federations of mesh %q: %s", state.ID.ValueString(), err),
+		)
+
+		newState, diags := meshToModel(ctx, members, remaining, nil)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		newState.AdoptExisting = state.AdoptExisting
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+	}
+}
+
+// ModifyPlan plans the ID of the mesh from its trust zones, and plans an
+// update when the federations in state are not the full mesh over them, such
+// as when one was deleted outside of Terraform, or when other federations
+// between them were created outside of the mesh.
+func (r *FederationMeshResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan FederationMeshModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !isFullyKnown(plan.TrustZoneIDs) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), tftypes.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("federations"), tftypes.ListUnknown(meshFederationType))...)
+		return
+	}
+
+	members, diags := trustZoneIDs(ctx, plan.TrustZoneIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), meshID(members))...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state FederationMeshModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var current []MeshFederationModel
+	resp.Diagnostics.Append(state.Federations.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	federations := make([]*federationpb.Federation, 0, len(current))
+	for _, federation := range current {
+		federations = append(federations, &federationpb.Federation{
+			Id:                federation.ID.ValueStringPointer(),
+			TrustZoneId:       federation.TrustZoneID.ValueStringPointer(),
+			RemoteTrustZoneId: federation.RemoteTrustZoneID.ValueStringPointer(),
+		})
+	}
+
+	// Federations between the trust zones that the mesh does not manage are
+	// adopted or rejected by the update, after which none are left.
+	create, destroy := diff(members, federations)
+	planned := state.Federations
+	if len(create) > 0 || len(destroy) > 0 || len(state.UnmanagedFederations.Elements()) > 0 {
+		planned = tftypes.ListUnknown(meshFederationType)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("federations"), planned)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unmanaged_federations"), tftypes.ListValueMust(meshFederationType, nil))...)
+}
+
+func (r *FederationMeshResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		members, err := parseMeshID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing federation mesh", err.Error())
+			return
+		}
+		req.ID = meshID(members).ValueString()
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// meshToModel returns the model of the mesh over members, which manages
+// federations. unmanaged are the other federations between members.
+func meshToModel(ctx context.Context, members []string, federations, unmanaged []*federationpb.Federation) (FederationMeshModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	trustZoneIDs, d := tftypes.SetValueFrom(ctx, tftypes.StringType, members)
+	diags.Append(d...)
+
+	federationList, d := federationsToList(ctx, federations)
+	diags.Append(d...)
+	unmanagedList, d := federationsToList(ctx, unmanaged)
+	diags.Append(d...)
+
+	return FederationMeshModel{
+		ID:                   meshID(members),
+		TrustZoneIDs:         trustZoneIDs,
+		Federations:          federationList,
+		UnmanagedFederations: unmanagedList,
+	}, diags
+}
+
+func federationsToList(ctx context.Context, federations []*federationpb.Federation) (tftypes.List, diag.Diagnostics) {
+	models := make([]MeshFederationModel, 0, len(federations))
+	for _, federation := range federations {
+		models = append(models, MeshFederationModel{
+			ID:                tftypes.StringValue(federation.GetId()),
+			TrustZoneID:       tftypes.StringValue(federation.GetTrustZoneId()),
+			RemoteTrustZoneID: tftypes.StringValue(federation.GetRemoteTrustZoneId()),
+		})
+	}
+	return tftypes.ListValueFrom(ctx, meshFederationType, models)
+}
+
+// federationIDs returns the IDs of the federations of a mesh in state, which
+// are those the mesh manages.
+func federationIDs(ctx context.Context, state FederationMeshModel) ([]string, diag.Diagnostics) {
+	var federations []MeshFederationModel
+	diags := state.Federations.ElementsAs(ctx, &federations, false)
+
+	ids := make([]string, 0, len(federations))
+	for _, federation := range federations {
+		ids = append(ids, federation.ID.ValueString())
+	}
+	return ids, diags
+}
+
+// stateTrustZoneIDs returns the trust zones of a mesh in state. After import
+// only the ID is known, from which they are parsed.
+func stateTrustZoneIDs(ctx context.Context, state FederationMeshModel) ([]string, diag.Diagnostics) {
+	if !state.TrustZoneIDs.IsNull() {
+		return trustZoneIDs(ctx, state.TrustZoneIDs)
+	}
+
+	var diags diag.Diagnostics
+	members, err := parseMeshID(state.ID.ValueString())
+	if err != nil {
+		diags.AddError("Error reading federation mesh", err.Error())
+	}
+	return members, diags
+}
+
+func trustZoneIDs(ctx context.Context, set tftypes.Set) ([]string, diag.Diagnostics) {
+	var ids []string
+	diags := set.ElementsAs(ctx, &ids, false)
+	slices.Sort(ids)
+	return ids, diags
+}
+
+func isFullyKnown(set tftypes.Set) bool {
+	if set.IsUnknown() {
+		return false
+	}
+	for _, element := range set.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// meshID returns the ID of the mesh over the given trust zones.
+func meshID(trustZoneIDs []string) tftypes.String {
+	sorted := slices.Sorted(slices.Values(trustZoneIDs))
+	return tftypes.StringValue(strings.Join(sorted, ","))
+}
+
+// parseMeshID returns the trust zones of a mesh from its ID.
+func parseMeshID(id string) ([]string, error) {
+	var members []string
+	for _, trustZoneID := range strings.Split(id, ",") {
+		if trustZoneID = strings.TrimSpace(trustZoneID); trustZoneID != "" && !slices.Contains(members, trustZoneID) {
+			members = append(members, trustZoneID)
+		}
+	}
+	if len(members) < 2 {
+		return nil, fmt.Errorf("invalid federation mesh ID %q: expected the IDs of at least two trust zones separated by commas", id)
+	}
+
+	slices.Sort(members)
+	return members, nil
+}
+
+func union(a, b []string) []string {
+	result := slices.Concat(a, b)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
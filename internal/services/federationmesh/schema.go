@@ -0,0 +1,79 @@
+package federationmesh
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var meshFederationObject = schema.NestedAttributeObject{
+	Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Description: "The ID of the federation.",
+			Computed:    true,
+		},
+		"trust_zone_id": schema.StringAttribute{
+			Description: "The ID of the trust zone.",
+			Computed:    true,
+		},
+		"remote_trust_zone_id": schema.StringAttribute{
+			Description: "The ID of the remote trust zone.",
+			Computed:    true,
+		},
+	},
+}
+
+func ResourceSchema(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Manages a full mesh of Cofide Connect federations, with a federation in each direction between every pair of the given trust zones. " +
+			"Adding or removing a trust zone only creates or destroys the federations to and from it. " +
+			"The mesh manages only the federations it created or adopted: creating it fails if federations between the trust zones already exist, unless `adopt_existing` is set, and destroying it leaves other federations in place. " +
+			"Federations created between the trust zones outside of the mesh are reported in `unmanaged_federations`. " +
+			"Missing or duplicate federations of the mesh are reconciled on the next apply. " +
+			"Import with the trust zone IDs separated by commas, which adopts the federations between them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the mesh: the sorted IDs of its trust zones, separated by commas.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"trust_zone_ids": schema.SetAttribute{
+				Description: "The IDs of the trust zones to federate with each other.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(2),
+				},
+			},
+			"federations": schema.ListNestedAttribute{
+				Description:  "The federations of the mesh, sorted by trust zone and remote trust zone.",
+				Computed:     true,
+				NestedObject: meshFederationObject,
+			},
+			"unmanaged_federations": schema.ListNestedAttribute{
+				Description:  "Federations between the trust zones of the mesh that it does not manage, such as ones created outside of Terraform, sorted by trust zone and remote trust zone. Applying fails while any exist, unless `adopt_existing` is set.",
+				Computed:     true,
+				NestedObject: meshFederationObject,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether creating or updating the mesh adopts existing federations between its trust zones instead of failing. Adopted federations are destroyed with the mesh. Changing it does not change any federation. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *FederationMeshResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = ResourceSchema(ctx)
+}
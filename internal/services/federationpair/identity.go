@@ -0,0 +1,46 @@
+package federationpair
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = (*FederationPairResource)(nil)
+
+// FederationPairIdentityModel is the resource identity of a federation pair,
+// which is the identity of its federation from trust_zone_id.
+type FederationPairIdentityModel struct {
+	OrgID       types.String `tfsdk:"org_id"`
+	TrustZoneID types.String `tfsdk:"trust_zone_id"`
+	ID          types.String `tfsdk:"id"`
+}
+
+func newIdentityModel(m FederationPairModel) FederationPairIdentityModel {
+	return FederationPairIdentityModel{
+		OrgID:       m.OrgID,
+		TrustZoneID: m.TrustZoneID,
+		ID:          m.ID,
+	}
+}
+
+func (r *FederationPairResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"org_id": identityschema.StringAttribute{
+				Description:       "The ID of the organization.",
+				OptionalForImport: true,
+			},
+			"trust_zone_id": identityschema.StringAttribute{
+				Description:       "The ID of one of the federated trust zones.",
+				OptionalForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the federation from `trust_zone_id` to `remote_trust_zone_id`.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package federationpair
+
+import (
+	"context"
+	"fmt"
+
+	federationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/federation_service/v1alpha1"
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+)
+
+var (
+	_ list.ListResource              = &FederationPairResource{}
+	_ list.ListResourceWithConfigure = &FederationPairResource{}
+)
+
+// NewListResource returns the list resource for federation pairs, which lets
+// `terraform query` enumerate existing bidirectional federations for import.
+func NewListResource() list.ListResource {
+	return &FederationPairResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists pairs of Cofide Connect federations in both directions between two trust zones.",
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: "Filter by organization ID.",
+				Optional:    true,
+			},
+			"trust_zone_id": listschema.StringAttribute{
+				Description: "Filter by the ID of one of the federated trust zones.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *FederationPairResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (r *FederationPairResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config FederationPairListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Federations to and from the trust zone are both needed, so only the
+	// organization is filtered on by the API.
+	federations, err := r.client.FederationV1Alpha1().ListFederations(ctx, &federationsvcpb.ListFederationsRequest_Filter{
+		OrgId: config.OrgID.ValueStringPointer(),
+	})
+	if err != nil {
+		diags.AddError(
+			"Error listing federation pairs",
+			fmt.Sprintf("Could not list federations: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	pairs := pairFederations(federations, config.TrustZoneID.ValueString())
+	stream.Results = util.ListResults(ctx, req, pairs, func(pair [2]*federationpb.Federation) (util.ListResult, diag.Diagnostics) {
+		model := pairToModel(FederationPairModel{}, pair[0], pair[1])
+		return util.ListResult{
+			DisplayName: model.ID.ValueString(),
+			Resource:    model,
+			Identity:    newIdentityModel(model),
+		}, nil
+	})
+}
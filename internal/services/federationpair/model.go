@@ -0,0 +1,19 @@
+package federationpair
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type FederationPairModel struct {
+	ID                  types.String `tfsdk:"id"`
+	OrgID               types.String `tfsdk:"org_id"`
+	TrustZoneID         types.String `tfsdk:"trust_zone_id"`
+	RemoteTrustZoneID   types.String `tfsdk:"remote_trust_zone_id"`
+	FederationID        types.String `tfsdk:"federation_id"`
+	ReverseFederationID types.String `tfsdk:"reverse_federation_id"`
+}
+
+// FederationPairListModel is the configuration of the federation pair list
+// resource.
+type FederationPairListModel struct {
+	OrgID       types.String `tfsdk:"org_id"`
+	TrustZoneID types.String `tfsdk:"trust_zone_id"`
+}
@@ -0,0 +1,137 @@
+package federationpair
+
+import (
+	"context"
+	"fmt"
+
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// federationClient is the part of the federation API used to create and
+// destroy a pair.
+type federationClient interface {
+	CreateFederation(ctx context.Context, federation *federationpb.Federation) (*federationpb.Federation, error)
+	DestroyFederation(ctx context.Context, id string) error
+}
+
+// createPair creates the federations from trustZoneID to remoteTrustZoneID
+// and back. If the second federation cannot be created, the first is
+// destroyed again.
+func createPair(ctx context.Context, client federationClient, trustZoneID, remoteTrustZoneID string) (forward, reverse *federationpb.Federation, err error) {
+	forward, err = createFederation(ctx, client, trustZoneID, remoteTrustZoneID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reverse, err = createFederation(ctx, client, remoteTrustZoneID, trustZoneID)
+	if err != nil {
+		if rollbackErr := destroyFederation(ctx, client, forward.GetId()); rollbackErr != nil {
+			return nil, nil, fmt.Errorf("%w; rolling back also failed, so federation %q must be deleted manually: %w", err, forward.GetId(), rollbackErr)
+		}
+		return nil, nil, err
+	}
+
+	return forward, reverse, nil
+}
+
+// destroyPair destroys both federations of a pair. If the reverse federation
+// cannot be destroyed, the forward federation is created again so that the
+// pair stays intact, and the returned model holds its new ID.
+func destroyPair(ctx context.Context, client federationClient, pair FederationPairModel) (FederationPairModel, error) {
+	if err := destroyFederation(ctx, client, pair.FederationID.ValueString()); err != nil {
+		return pair, err
+	}
+
+	err := destroyFederation(ctx, client, pair.ReverseFederationID.ValueString())
+	if err == nil || pair.FederationID.IsNull() {
+		return pair, err
+	}
+
+	forward, restoreErr := createFederation(ctx, client, pair.TrustZoneID.ValueString(), pair.RemoteTrustZoneID.ValueString())
+	if restoreErr != nil {
+		pair.FederationID = tftypes.StringNull()
+		return pair, fmt.Errorf("%w; restoring the federation from trust zone %q to %q also failed: %w", err, pair.TrustZoneID.ValueString(), pair.RemoteTrustZoneID.ValueString(), restoreErr)
+	}
+
+	pair.ID = tftypes.StringValue(forward.GetId())
+	pair.FederationID = tftypes.StringValue(forward.GetId())
+	return pair, err
+}
+
+func createFederation(ctx context.Context, client federationClient, trustZoneID, remoteTrustZoneID string) (*federationpb.Federation, error) {
+	federation, err := client.CreateFederation(ctx, &federationpb.Federation{
+		TrustZoneId:       &trustZoneID,
+		RemoteTrustZoneId: &remoteTrustZoneID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create federation from trust zone %q to %q: %w", trustZoneID, remoteTrustZoneID, err)
+	}
+	return federation, nil
+}
+
+// destroyFederation destroys the federation with the given ID, if any. A
+// federation that no longer exists is not an error.
+func destroyFederation(ctx context.Context, client federationClient, id string) error {
+	if id == "" {
+		return nil
+	}
+	if err := client.DestroyFederation(ctx, id); err != nil && status.Code(err) != codes.NotFound {
+		return fmt.Errorf("could not destroy federation %q: %w", id, err)
+	}
+	return nil
+}
+
+// pairToModel returns the model of a pair from its federations, either of
+// which may be nil if it no longer exists. Attributes that cannot be derived
+// from the federations are taken from prior.
+func pairToModel(prior FederationPairModel, forward, reverse *federationpb.Federation) FederationPairModel {
+	model := prior
+	model.FederationID = tftypes.StringNull()
+	model.ReverseFederationID = tftypes.StringNull()
+
+	if reverse != nil {
+		model.OrgID = tftypes.StringValue(reverse.GetOrgId())
+		model.TrustZoneID = tftypes.StringValue(reverse.GetRemoteTrustZoneId())
+		model.RemoteTrustZoneID = tftypes.StringValue(reverse.GetTrustZoneId())
+		model.ReverseFederationID = tftypes.StringValue(reverse.GetId())
+	}
+	if forward != nil {
+		model.ID = tftypes.StringValue(forward.GetId())
+		model.OrgID = tftypes.StringValue(forward.GetOrgId())
+		model.TrustZoneID = tftypes.StringValue(forward.GetTrustZoneId())
+		model.RemoteTrustZoneID = tftypes.StringValue(forward.GetRemoteTrustZoneId())
+		model.FederationID = tftypes.StringValue(forward.GetId())
+	}
+
+	return model
+}
+
+// pairFederations returns the pairs of federations in both directions between
+// two trust zones. If trustZoneID is set, only pairs involving it are
+// returned, with the federation from it first; otherwise the federation from
+// the trust zone with the lower ID comes first.
+func pairFederations(federations []*federationpb.Federation, trustZoneID string) [][2]*federationpb.Federation {
+	byDirection := make(map[[2]string]*federationpb.Federation, len(federations))
+	for _, federation := range federations {
+		byDirection[[2]string{federation.GetTrustZoneId(), federation.GetRemoteTrustZoneId()}] = federation
+	}
+
+	var pairs [][2]*federationpb.Federation
+	for _, federation := range federations {
+		from, to := federation.GetTrustZoneId(), federation.GetRemoteTrustZoneId()
+
+		reverse, ok := byDirection[[2]string{to, from}]
+		if !ok || from == to {
+			continue
+		}
+		if trustZoneID != "" && from != trustZoneID || trustZoneID == "" && from > to {
+			continue
+		}
+
+		pairs = append(pairs, [2]*federationpb.Federation{federation, reverse})
+	}
+	return pairs
+}
@@ -0,0 +1,225 @@
+package federationpair
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeFederationClient stores federations in memory. Creating a federation
+// to failCreateTo and destroying failDestroyID fail.
+type fakeFederationClient struct {
+	federations   map[string]*federationpb.Federation
+	nextID        int
+	failCreateTo  string
+	failDestroyID string
+}
+
+func newFakeFederationClient() *fakeFederationClient {
+	return &fakeFederationClient{federations: map[string]*federationpb.Federation{}}
+}
+
+func (c *fakeFederationClient) CreateFederation(_ context.Context, federation *federationpb.Federation) (*federationpb.Federation, error) {
+	if federation.GetRemoteTrustZoneId() == c.failCreateTo {
+		return nil, errors.New("create failed")
+	}
+
+	c.nextID++
+	id := fmt.Sprintf("fed-%d", c.nextID)
+	created := &federationpb.Federation{
+		Id:                &id,
+		TrustZoneId:       federation.TrustZoneId,
+		RemoteTrustZoneId: federation.RemoteTrustZoneId,
+	}
+	c.federations[id] = created
+	return created, nil
+}
+
+func (c *fakeFederationClient) DestroyFederation(_ context.Context, id string) error {
+	if id == c.failDestroyID {
+		return errors.New("destroy failed")
+	}
+	if _, ok := c.federations[id]; !ok {
+		return status.Error(codes.NotFound, "federation not found")
+	}
+	delete(c.federations, id)
+	return nil
+}
+
+func TestCreatePair(t *testing.T) {
+	client := newFakeFederationClient()
+
+	forward, reverse, err := createPair(context.Background(), client, "tz-1", "tz-2")
+	require.NoError(t, err)
+
+	assert.Equal(t, "tz-1", forward.GetTrustZoneId())
+	assert.Equal(t, "tz-2", forward.GetRemoteTrustZoneId())
+	assert.Equal(t, "tz-2", reverse.GetTrustZoneId())
+	assert.Equal(t, "tz-1", reverse.GetRemoteTrustZoneId())
+	assert.Len(t, client.federations, 2)
+}
+
+func TestCreatePairRollsBack(t *testing.T) {
+	client := newFakeFederationClient()
+	client.failCreateTo = "tz-1"
+
+	_, _, err := createPair(context.Background(), client, "tz-1", "tz-2")
+	assert.EqualError(t, err, `could not create federation from trust zone "tz-2" to "tz-1": create failed`)
+	assert.Empty(t, client.federations, "the federation from tz-1 to tz-2 should have been destroyed")
+}
+
+func TestCreatePairRollbackFails(t *testing.T) {
+	client := newFakeFederationClient()
+	client.failCreateTo = "tz-1"
+	client.failDestroyID = "fed-1"
+
+	_, _, err := createPair(context.Background(), client, "tz-1", "tz-2")
+	assert.EqualError(t, err, `could not create federation from trust zone "tz-2" to "tz-1": create failed; rolling back also failed, so federation "fed-1" must be deleted manually: could not destroy federation "fed-1": destroy failed`)
+	assert.Contains(t, client.federations, "fed-1")
+}
+
+func TestDestroyPair(t *testing.T) {
+	tests := []struct {
+		name          string
+		failDestroyID string
+		// missing federations have already been deleted outside of Terraform.
+		missing     []string
+		wantErr     string
+		wantForward string
+		wantRemain  []string
+	}{
+		{
+			name:        "both directions",
+			wantForward: "fed-1",
+		},
+		{
+			name:        "forward already deleted",
+			missing:     []string{"fed-1"},
+			wantForward: "fed-1",
+		},
+		{
+			name:          "forward fails",
+			failDestroyID: "fed-1",
+			wantErr:       `could not destroy federation "fed-1": destroy failed`,
+			wantForward:   "fed-1",
+			wantRemain:    []string{"fed-1", "fed-2"},
+		},
+		{
+			name:          "reverse fails and forward is restored",
+			failDestroyID: "fed-2",
+			wantErr:       `could not destroy federation "fed-2": destroy failed`,
+			wantForward:   "fed-3",
+			wantRemain:    []string{"fed-2", "fed-3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newFakeFederationClient()
+
+			forward, reverse, err := createPair(ctx, client, "tz-1", "tz-2")
+			require.NoError(t, err)
+			for _, id := range tt.missing {
+				delete(client.federations, id)
+			}
+			client.failDestroyID = tt.failDestroyID
+
+			pair := pairToModel(FederationPairModel{}, forward, reverse)
+			remaining, err := destroyPair(ctx, client, pair)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tftypes.StringValue(tt.wantForward), remaining.ID)
+			assert.Equal(t, tftypes.StringValue(tt.wantForward), remaining.FederationID)
+			assert.ElementsMatch(t, tt.wantRemain, keys(client.federations))
+		})
+	}
+}
+
+func TestDestroyPairRestoreFails(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeFederationClient()
+
+	forward, reverse, err := createPair(ctx, client, "tz-1", "tz-2")
+	require.NoError(t, err)
+	client.failDestroyID = "fed-2"
+	client.failCreateTo = "tz-2"
+
+	remaining, err := destroyPair(ctx, client, pairToModel(FederationPairModel{}, forward, reverse))
+	assert.EqualError(t, err, `could not destroy federation "fed-2": destroy failed; restoring the federation from trust zone "tz-1" to "tz-2" also failed: could not create federation from trust zone "tz-1" to "tz-2": create failed`)
+	assert.True(t, remaining.FederationID.IsNull())
+	assert.Equal(t, tftypes.StringValue("fed-2"), remaining.ReverseFederationID)
+}
+
+func TestPairToModel(t *testing.T) {
+	forward := newFederation("fed-1", "tz-1", "tz-2")
+	reverse := newFederation("fed-2", "tz-2", "tz-1")
+	prior := FederationPairModel{
+		ID:                  tftypes.StringValue("fed-1"),
+		OrgID:               tftypes.StringValue(""),
+		TrustZoneID:         tftypes.StringValue("tz-1"),
+		RemoteTrustZoneID:   tftypes.StringValue("tz-2"),
+		FederationID:        tftypes.StringValue("fed-1"),
+		ReverseFederationID: tftypes.StringValue("fed-2"),
+	}
+
+	assert.Equal(t, prior, pairToModel(FederationPairModel{}, forward, reverse))
+
+	withoutReverse := prior
+	withoutReverse.ReverseFederationID = tftypes.StringNull()
+	assert.Equal(t, withoutReverse, pairToModel(prior, forward, nil))
+
+	withoutForward := prior
+	withoutForward.FederationID = tftypes.StringNull()
+	assert.Equal(t, withoutForward, pairToModel(prior, nil, reverse))
+}
+
+func TestPairFederations(t *testing.T) {
+	federations := []*federationpb.Federation{
+		newFederation("fed-1", "tz-2", "tz-1"),
+		newFederation("fed-2", "tz-1", "tz-2"),
+		newFederation("fed-3", "tz-1", "tz-3"),
+		newFederation("fed-4", "tz-3", "tz-2"),
+		newFederation("fed-5", "tz-2", "tz-3"),
+	}
+
+	ids := func(pairs [][2]*federationpb.Federation) [][2]string {
+		var ids [][2]string
+		for _, pair := range pairs {
+			ids = append(ids, [2]string{pair[0].GetId(), pair[1].GetId()})
+		}
+		return ids
+	}
+
+	assert.Equal(t, [][2]string{{"fed-2", "fed-1"}, {"fed-5", "fed-4"}}, ids(pairFederations(federations, "")))
+	assert.Equal(t, [][2]string{{"fed-1", "fed-2"}, {"fed-5", "fed-4"}}, ids(pairFederations(federations, "tz-2")))
+	assert.Empty(t, pairFederations(federations, "tz-4"))
+}
+
+func newFederation(id, trustZoneID, remoteTrustZoneID string) *federationpb.Federation {
+	return &federationpb.Federation{
+		Id:                &id,
+		TrustZoneId:       &trustZoneID,
+		RemoteTrustZoneId: &remoteTrustZoneID,
+	}
+}
+
+func keys[V any](m map[string]V) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,21 @@
+package federationpair_test
+
+import (
+	"testing"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+)
+
+// TestPlanReverseFederationDeleted checks that losing the reverse federation
+// outside Terraform forces replacement.
+func TestPlanReverseFederationDeleted(t *testing.T) {
+	providertest.RunPlanTests(t, "cofide_connect_federation_pair", []providertest.PlanTest{
+		{
+			Name:        "reverse federation deleted",
+			State:       map[string]any{"id": "fed-1", "org_id": "org-1", "trust_zone_id": "tz-1", "remote_trust_zone_id": "tz-2", "federation_id": "fed-1"},
+			Config:      map[string]any{"trust_zone_id": "tz-1", "remote_trust_zone_id": "tz-2"},
+			WantReplace: []string{"reverse_federation_id"},
+			WantUnknown: []string{"reverse_federation_id"},
+		},
+	})
+}
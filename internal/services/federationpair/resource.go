@@ -0,0 +1,233 @@
+package federationpair
+
+import (
+	"context"
+	"fmt"
+
+	federationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/federation_service/v1alpha1"
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ resource.Resource                   = &FederationPairResource{}
+	_ resource.ResourceWithImportState    = &FederationPairResource{}
+	_ resource.ResourceWithValidateConfig = &FederationPairResource{}
+	_ resource.ResourceWithModifyPlan     = &FederationPairResource{}
+)
+
+type FederationPairResource struct {
+	client sdkclient.ClientSet
+}
+
+func NewResource() resource.Resource {
+	return &FederationPairResource{}
+}
+
+func (r *FederationPairResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connect_federation_pair"
+}
+
+func (r *FederationPairResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdkclient.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected sdkclient.ClientSet, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *FederationPairResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan FederationPairModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	forward, reverse, err := createPair(ctx, r.client.FederationV1Alpha1(), plan.TrustZoneID.ValueString(), plan.RemoteTrustZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating federation pair",
+			fmt.Sprintf("Could not create federation pair: %s", err),
+		)
+		return
+	}
+
+	state := pairToModel(plan, forward, reverse)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state))...)
+}
+
+func (r *FederationPairResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state FederationPairModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	forward, err := r.getFederation(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading federation pair",
+			fmt.Sprintf("Could not read federation %q: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	var reverse *federationpb.Federation
+	switch {
+	case util.IsStringAttributeNonEmpty(state.ReverseFederationID):
+		reverse, err = r.getFederation(ctx, state.ReverseFederationID.ValueString())
+	case forward != nil:
+		// After import only the ID of the forward federation is known.
+		reverse, err = r.findFederation(ctx, forward.GetRemoteTrustZoneId(), forward.GetTrustZoneId())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading federation pair",
+			fmt.Sprintf("Could not read the reverse federation of %q: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if forward == nil && reverse == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// If only one direction remains, it is kept in state without the other's
+	// ID, and ModifyPlan replaces the pair.
+	newState := pairToModel(state, forward, reverse)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+}
+
+func (r *FederationPairResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError(
+		"Federation Pair Update Not Supported",
+		"The Connect API does not support updating federations.",
+	)
+}
+
+func (r *FederationPairResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state FederationPairModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remaining, err := destroyPair(ctx, r.client.FederationV1Alpha1(), state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting federation pair",
+			fmt.Sprintf("Could not delete federation pair: %s", err),
+		)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &remaining)...)
+	}
+}
+
+// ModifyPlan replaces a pair of which one federation has been deleted outside
+// of Terraform, since the remaining one cannot be completed in place.
+func (r *FederationPairResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state FederationPairModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attribute, id := range map[string]tftypes.String{
+		"federation_id":         state.FederationID,
+		"reverse_federation_id": state.ReverseFederationID,
+	} {
+		if id.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attribute), tftypes.StringUnknown())...)
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root(attribute))
+		}
+	}
+}
+
+func (r *FederationPairResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		id, err := importid.Federation(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing federation pair", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+func (r *FederationPairResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data FederationPairModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if util.IsStringAttributeNonEmpty(data.TrustZoneID) && data.TrustZoneID.Equal(data.RemoteTrustZoneID) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("remote_trust_zone_id"),
+			"Invalid federation pair",
+			"A trust zone cannot be federated with itself.",
+		)
+	}
+}
+
+// getFederation returns the federation with the given ID, or nil if it does
+// not exist.
+func (r *FederationPairResource) getFederation(ctx context.Context, id string) (*federationpb.Federation, error) {
+	federation, err := r.client.FederationV1Alpha1().GetFederation(ctx, id)
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	return federation, err
+}
+
+// findFederation returns the federation from trustZoneID to
+// remoteTrustZoneID, or nil if there is none.
+func (r *FederationPairResource) findFederation(ctx context.Context, trustZoneID, remoteTrustZoneID string) (*federationpb.Federation, error) {
+	federations, err := r.client.FederationV1Alpha1().ListFederations(ctx, &federationsvcpb.ListFederationsRequest_Filter{
+		TrustZoneId:       &trustZoneID,
+		RemoteTrustZoneId: &remoteTrustZoneID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(federations) {
+	case 0:
+		return nil, nil
+	case 1:
+		return federations[0], nil
+	default:
+		return nil, fmt.Errorf("found %d federations from trust zone %q to %q", len(federations), trustZoneID, remoteTrustZoneID)
+	}
+}
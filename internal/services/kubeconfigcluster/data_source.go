@@ -0,0 +1,94 @@
+package kubeconfigcluster
+
+import (
+	"context"
+
+	"github.com/cofide/terraform-provider-cofide/internal/kubeconfig"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &KubeconfigClusterDataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &KubeconfigClusterDataSource{}
+}
+
+// KubeconfigClusterDataSource defines the data source implementation. It
+// reads local files only, so needs no client.
+type KubeconfigClusterDataSource struct{}
+
+func (d *KubeconfigClusterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connect_kubeconfig_cluster"
+}
+
+func (d *KubeconfigClusterDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = getKubeconfigClusterDataSourceSchema()
+}
+
+func (d *KubeconfigClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config KubeconfigClusterModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	paths := []string{config.Path.ValueString()}
+	if config.Path.IsNull() {
+		var err error
+		paths, err = kubeconfig.DefaultPaths()
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading kubeconfig", err.Error())
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Kubeconfig cluster datasource: reading kubeconfig", map[string]any{
+		"paths":   paths,
+		"context": config.Context.ValueString(),
+	})
+
+	kubeConfig, err := kubeconfig.Load(paths)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading kubeconfig", err.Error())
+		return
+	}
+
+	kubernetesContext := config.Context.ValueString()
+	if kubernetesContext == "" {
+		kubernetesContext = kubeConfig.CurrentContext
+	}
+	clusterName, cluster, err := kubeConfig.Cluster(kubernetesContext)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading kubeconfig", err.Error())
+		return
+	}
+
+	state := KubeconfigClusterModel{
+		Path:                   config.Path,
+		Context:                config.Context,
+		KubernetesContext:      tftypes.StringValue(kubernetesContext),
+		ClusterName:            tftypes.StringValue(clusterName),
+		ApiServerURL:           stringValueOrNull(cluster.Server),
+		ApiServerCACert:        util.CACertificateFromBytes(cluster.CertificateAuthority),
+		ApiServerTLSServerName: stringValueOrNull(cluster.TLSServerName),
+		ApiServerProxyURL:      stringValueOrNull(cluster.ProxyURL),
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// stringValueOrNull returns null for settings missing from the kubeconfig,
+// so that they leave the corresponding cluster attributes unset.
+func stringValueOrNull(s string) tftypes.String {
+	if s == "" {
+		return tftypes.StringNull()
+	}
+	return tftypes.StringValue(s)
+}
@@ -0,0 +1,51 @@
+package kubeconfigcluster
+
+import (
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+func getKubeconfigClusterDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reads the connection settings of a Kubernetes cluster from a local kubeconfig file, " +
+			"for use in a `cofide_connect_cluster` resource. Only cluster settings are read; users and credentials are ignored.",
+
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path to the kubeconfig file. A leading `~` is expanded to the home directory, as in the entries of `KUBECONFIG`. " +
+					"Defaults to the files listed in `KUBECONFIG`, merged as by kubectl, or else `~/.kube/config`.",
+				Optional: true,
+			},
+			"context": schema.StringAttribute{
+				MarkdownDescription: "The kubeconfig context to read. Defaults to the current context.",
+				Optional:            true,
+			},
+			"kubernetes_context": schema.StringAttribute{
+				MarkdownDescription: "The kubeconfig context read, for the `kubernetes_context` of a cluster.",
+				Computed:            true,
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the kubeconfig cluster of the context.",
+				Computed:            true,
+			},
+			"api_server_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the cluster's API server, from `server`.",
+				Computed:            true,
+			},
+			"api_server_ca_cert": schema.StringAttribute{
+				MarkdownDescription: "Base64-encoded PEM CA certificate of the cluster's API server, from `certificate-authority-data` or the `certificate-authority` file. Null if neither is set.",
+				Computed:            true,
+				CustomType:          util.CACertificateType{},
+			},
+			"api_server_tls_server_name": schema.StringAttribute{
+				MarkdownDescription: "Alternative TLS server name to verify the API server certificate against, from `tls-server-name`.",
+				Computed:            true,
+			},
+			"api_server_proxy_url": schema.StringAttribute{
+				MarkdownDescription: "Proxy URL for the cluster's API server, from `proxy-url`.",
+				Computed:            true,
+			},
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package kubeconfigcluster_test
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKubeconfigClusterDataSource reads a kubeconfig through the provider
+// server, as Terraform would.
+func TestKubeconfigClusterDataSource(t *testing.T) {
+	ca := providertest.NewCertificate(t, "api-server-ca", true, time.Now().Add(time.Hour))
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(`
+current-context: kind-local1
+contexts:
+  - name: kind-local1
+    context:
+      cluster: kind-local1
+  - name: remote
+    context:
+      cluster: remote
+clusters:
+  - name: kind-local1
+    cluster:
+      server: https://127.0.0.1:6443
+  - name: remote
+    cluster:
+      server: https://remote.example.com
+      certificate-authority-data: `+base64.StdEncoding.EncodeToString([]byte(ca))+`
+      tls-server-name: kubernetes
+      proxy-url: http://proxy:3128
+`), 0o600))
+
+	server := providertest.NewServer(t)
+	typ := server.DataSourceSchema(t, "cofide_connect_kubeconfig_cluster").ValueType()
+
+	tests := []struct {
+		name       string
+		config     map[string]any
+		want       map[string]any
+		wantDetail string
+	}{
+		{
+			name:   "current context",
+			config: map[string]any{"path": kubeconfigPath},
+			want: map[string]any{
+				"path":               kubeconfigPath,
+				"kubernetes_context": "kind-local1",
+				"cluster_name":       "kind-local1",
+				"api_server_url":     "https://127.0.0.1:6443",
+			},
+		},
+		{
+			name:   "context",
+			config: map[string]any{"path": kubeconfigPath, "context": "remote"},
+			want: map[string]any{
+				"path":                       kubeconfigPath,
+				"context":                    "remote",
+				"kubernetes_context":         "remote",
+				"cluster_name":               "remote",
+				"api_server_url":             "https://remote.example.com",
+				"api_server_ca_cert":         base64.StdEncoding.EncodeToString([]byte(ca)),
+				"api_server_tls_server_name": "kubernetes",
+				"api_server_proxy_url":       "http://proxy:3128",
+			},
+		},
+		{
+			name:       "missing context",
+			config:     map[string]any{"path": kubeconfigPath, "context": "absent"},
+			wantDetail: `context "absent" not found in kubeconfig`,
+		},
+		{
+			name:       "missing file",
+			config:     map[string]any{"path": kubeconfigPath + ".missing"},
+			wantDetail: "could not read kubeconfig",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+				TypeName: "cofide_connect_kubeconfig_cluster",
+				Config:   providertest.DynamicValue(t, typ, providertest.NewValue(t, typ, tt.config)),
+			})
+			require.NoError(t, err)
+			if tt.wantDetail != "" {
+				require.NotEmpty(t, resp.Diagnostics)
+				assert.Equal(t, "Error reading kubeconfig", resp.Diagnostics[0].Summary)
+				assert.Contains(t, resp.Diagnostics[0].Detail, tt.wantDetail)
+				return
+			}
+			require.Empty(t, resp.Diagnostics)
+
+			state, err := resp.State.Unmarshal(typ)
+			require.NoError(t, err)
+			assert.True(t, providertest.NewValue(t, typ, tt.want).Equal(state), "got %v", state)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package kubeconfigcluster
+
+import (
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// KubeconfigClusterModel describes the data source data model.
+type KubeconfigClusterModel struct {
+	Path                   tftypes.String     `tfsdk:"path"`
+	Context                tftypes.String     `tfsdk:"context"`
+	KubernetesContext      tftypes.String     `tfsdk:"kubernetes_context"`
+	ClusterName            tftypes.String     `tfsdk:"cluster_name"`
+	ApiServerURL           tftypes.String     `tfsdk:"api_server_url"`
+	ApiServerCACert        util.CACertificate `tfsdk:"api_server_ca_cert"`
+	ApiServerTLSServerName tftypes.String     `tfsdk:"api_server_tls_server_name"`
+	ApiServerProxyURL      tftypes.String     `tfsdk:"api_server_proxy_url"`
+}
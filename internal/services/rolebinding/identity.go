@@ -0,0 +1,33 @@
+package rolebinding
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = (*RoleBindingResource)(nil)
+
+// RoleBindingIdentityModel is the resource identity of a role binding.
+type RoleBindingIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func newIdentityModel(m RoleBindingModel) RoleBindingIdentityModel {
+	return RoleBindingIdentityModel{
+		ID: m.ID,
+	}
+}
+
+func (r *RoleBindingResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the role binding.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
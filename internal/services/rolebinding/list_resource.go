@@ -0,0 +1,82 @@
+package rolebinding
+
+import (
+	"context"
+	"fmt"
+
+	rolebindingsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/role_binding_service/v1alpha1"
+	rolebindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/role_binding/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+)
+
+var (
+	_ list.ListResource              = &RoleBindingResource{}
+	_ list.ListResourceWithConfigure = &RoleBindingResource{}
+)
+
+// NewListResource returns the list resource for role bindings, which lets
+// `terraform query` enumerate existing role bindings for import.
+func NewListResource() list.ListResource {
+	return &RoleBindingResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists Cofide Connect role bindings.",
+		Attributes: map[string]listschema.Attribute{
+			"role_id": listschema.StringAttribute{
+				Description: "Filter by role ID.",
+				Optional:    true,
+			},
+			"resource_type": listschema.StringAttribute{
+				Description: "Filter by the type of the bound resource, e.g. TrustZone, Cluster.",
+				Optional:    true,
+			},
+			"resource_id": listschema.StringAttribute{
+				Description: "Filter by the ID of the bound resource.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *RoleBindingResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (r *RoleBindingResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config RoleBindingListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &rolebindingsvcpb.ListRoleBindingsRequest_Filter{
+		RoleId:       config.RoleID.ValueStringPointer(),
+		ResourceType: config.ResourceType.ValueStringPointer(),
+		ResourceId:   config.ResourceID.ValueStringPointer(),
+	}
+	bindings, err := r.client.RoleBindingV1Alpha1().ListRoleBindings(ctx, filter)
+	if err != nil {
+		diags.AddError(
+			"Error listing role bindings",
+			fmt.Sprintf("Could not list role bindings: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.ListResults(ctx, req, bindings, func(binding *rolebindingpb.RoleBinding) (util.ListResult, diag.Diagnostics) {
+		model := protoToModel(binding)
+		return util.ListResult{
+			DisplayName: binding.GetId(),
+			Resource:    model,
+			Identity:    newIdentityModel(model),
+		}, nil
+	})
+}
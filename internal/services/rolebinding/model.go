@@ -22,3 +22,10 @@ type ResourceModel struct {
 	Type tftypes.String `tfsdk:"type"`
 	ID   tftypes.String `tfsdk:"id"`
 }
+
+// RoleBindingListModel is the configuration of the role binding list resource.
+type RoleBindingListModel struct {
+	RoleID       tftypes.String `tfsdk:"role_id"`
+	ResourceType tftypes.String `tfsdk:"resource_type"`
+	ResourceID   tftypes.String `tfsdk:"resource_id"`
+}
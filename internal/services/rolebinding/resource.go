@@ -66,6 +66,7 @@ func (r *RoleBindingResource) Create(ctx context.Context, req resource.CreateReq
 
 	state := protoToModel(createResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state))...)
 }
 
 func (r *RoleBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -100,6 +101,7 @@ func (r *RoleBindingResource) Read(ctx context.Context, req resource.ReadRequest
 
 	newState := protoToModel(getResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
 }
 
 func (r *RoleBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -110,8 +112,13 @@ func (r *RoleBindingResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	protoBinding := modelToProto(plan)
-	updateResp, err := r.client.RoleBindingV1Alpha1().UpdateRoleBinding(ctx, protoBinding)
+	var state RoleBindingModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateResp, err := updateRoleBinding(ctx, r.client.RoleBindingV1Alpha1(), modelToProto(plan), state)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating role binding",
@@ -122,6 +129,7 @@ func (r *RoleBindingResource) Update(ctx context.Context, req resource.UpdateReq
 
 	newState := protoToModel(updateResp)
 	resp.Diagnostics.Append(resp.State.Set(ctx, newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
 }
 
 func (r *RoleBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -145,7 +153,7 @@ func (r *RoleBindingResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *RoleBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 func (r *RoleBindingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
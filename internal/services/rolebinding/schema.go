@@ -1,27 +1,43 @@
 package rolebinding
 
 import (
+	"context"
+
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 )
 
-var _ resource.ResourceWithConfigValidators = (*RoleBindingResource)(nil)
+var (
+	_ resource.ResourceWithConfigValidators = (*RoleBindingResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*RoleBindingResource)(nil)
+)
 
 func resourceSchema() schema.Schema {
 	return schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a Cofide Connect role binding. Grants a user or group a role on a specific resource. Exactly one of `user` or `group` must be provided.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The ID of the role binding.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"role_id": schema.StringAttribute{
 				Description: "The ID of the role.",
 				Required:    true,
 			},
 			"user": schema.SingleNestedAttribute{
-				Description: "The user principal for the role binding. Exactly one of `user` or `group` must be provided.",
+				Description: "The user principal for the role binding. Exactly one of `user` or `group` must be provided. Cannot be changed after creation.",
 				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"subject": schema.StringAttribute{
 						Description: "The subject identifier of the user (typically an email address or user ID).",
@@ -30,8 +46,11 @@ func resourceSchema() schema.Schema {
 				},
 			},
 			"group": schema.SingleNestedAttribute{
-				Description: "The group principal for the role binding. Exactly one of `user` or `group` must be provided.",
+				Description: "The group principal for the role binding. Exactly one of `user` or `group` must be provided. Cannot be changed after creation.",
 				Optional:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"claim_value": schema.StringAttribute{
 						Description: "The value of the group claim from the identity provider.",
@@ -40,8 +59,11 @@ func resourceSchema() schema.Schema {
 				},
 			},
 			"resource": schema.SingleNestedAttribute{
-				Description: "The resource for the role binding.",
+				Description: "The resource for the role binding. Cannot be changed after creation.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
 				Attributes: map[string]schema.Attribute{
 					"type": schema.StringAttribute{
 						Description: "The type of the resource to bind the role to. e.g. TrustZone, Cluster",
@@ -56,3 +78,10 @@ func resourceSchema() schema.Schema {
 		},
 	}
 }
+
+func (r *RoleBindingResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 1 made user, group and resource force replacement.
+		0: util.UnchangedStateUpgrader(resourceSchema()),
+	}
+}
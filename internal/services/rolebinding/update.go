@@ -0,0 +1,25 @@
+package rolebinding
+
+import (
+	"context"
+
+	rolebindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/role_binding/v1alpha1"
+)
+
+// roleBindingClient is the part of the role binding API used to update a role
+// binding.
+type roleBindingClient interface {
+	UpdateRoleBinding(ctx context.Context, binding *rolebindingpb.RoleBinding) (*rolebindingpb.RoleBinding, error)
+}
+
+// updateRoleBinding updates the role binding in state to binding. The
+// principal and resource cannot be changed after creation and are sent as
+// they are in state, as a change to them replaces the binding rather than
+// updating it.
+func updateRoleBinding(ctx context.Context, client roleBindingClient, binding *rolebindingpb.RoleBinding, state RoleBindingModel) (*rolebindingpb.RoleBinding, error) {
+	stored := modelToProto(state)
+	binding.Id = stored.Id
+	binding.Principal = stored.Principal
+	binding.Resource = stored.Resource
+	return client.UpdateRoleBinding(ctx, binding)
+}
@@ -0,0 +1,47 @@
+package rolebinding
+
+import (
+	"context"
+	"testing"
+
+	rolebindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/role_binding/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRoleBindingClient records the updates made.
+type fakeRoleBindingClient struct {
+	updated []*rolebindingpb.RoleBinding
+}
+
+func (c *fakeRoleBindingClient) UpdateRoleBinding(_ context.Context, binding *rolebindingpb.RoleBinding) (*rolebindingpb.RoleBinding, error) {
+	c.updated = append(c.updated, binding)
+	return binding, nil
+}
+
+func TestUpdateRoleBindingKeepsPrincipalAndResource(t *testing.T) {
+	client := &fakeRoleBindingClient{}
+	state := RoleBindingModel{
+		ID:       tftypes.StringValue("rb-1"),
+		RoleID:   tftypes.StringValue("viewer"),
+		User:     &UserModel{Subject: tftypes.StringValue("alice@example.com")},
+		Resource: ResourceModel{Type: tftypes.StringValue("TrustZone"), ID: tftypes.StringValue("tz-1")},
+	}
+	plan := RoleBindingModel{
+		RoleID:   tftypes.StringValue("editor"),
+		Group:    &GroupModel{ClaimValue: tftypes.StringValue("admins")},
+		Resource: ResourceModel{Type: tftypes.StringValue("TrustZone"), ID: tftypes.StringValue("tz-2")},
+	}
+
+	_, err := updateRoleBinding(context.Background(), client, modelToProto(plan), state)
+	require.NoError(t, err)
+
+	require.Len(t, client.updated, 1)
+	updated := client.updated[0]
+	assert.Equal(t, "rb-1", updated.GetId())
+	assert.Equal(t, "editor", updated.GetRoleId())
+	assert.Equal(t, "alice@example.com", updated.GetUser().GetSubject())
+	assert.Nil(t, updated.GetGroup())
+	assert.Equal(t, "tz-1", updated.GetResource().GetId())
+}
@@ -0,0 +1,170 @@
+package rolebindingsexclusive
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	rolebindingsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/role_binding_service/v1alpha1"
+	rolebindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/role_binding/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// roleBindingClient is the part of the role binding API used to reconcile
+// the principals holding a role.
+type roleBindingClient interface {
+	CreateRoleBinding(ctx context.Context, binding *rolebindingpb.RoleBinding) (*rolebindingpb.RoleBinding, error)
+	ListRoleBindings(ctx context.Context, filter *rolebindingsvcpb.ListRoleBindingsRequest_Filter) ([]*rolebindingpb.RoleBinding, error)
+	DestroyRoleBinding(ctx context.Context, id string) error
+}
+
+// principal is a user or a group. Exactly one of its fields is set.
+type principal struct {
+	user  string
+	group string
+}
+
+func (p principal) String() string {
+	if p.group != "" {
+		return fmt.Sprintf("group %q", p.group)
+	}
+	return fmt.Sprintf("user %q", p.user)
+}
+
+func principalOf(binding *rolebindingpb.RoleBinding) principal {
+	return principal{
+		user:  binding.GetUser().GetSubject(),
+		group: binding.GetGroup().GetClaimValue(),
+	}
+}
+
+// principals returns the principals for the given user subjects and group
+// claim values.
+func principals(users, groups []string) []principal {
+	result := make([]principal, 0, len(users)+len(groups))
+	for _, user := range users {
+		result = append(result, principal{user: user})
+	}
+	for _, group := range groups {
+		result = append(result, principal{group: group})
+	}
+	return result
+}
+
+// listBindings returns the bindings of roleID on resource, sorted by
+// principal and ID.
+func listBindings(ctx context.Context, client roleBindingClient, resource *rolebindingpb.Resource, roleID string) ([]*rolebindingpb.RoleBinding, error) {
+	listed, err := client.ListRoleBindings(ctx, &rolebindingsvcpb.ListRoleBindingsRequest_Filter{
+		RoleId:       &roleID,
+		ResourceType: &resource.Type,
+		ResourceId:   &resource.Id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list role bindings: %w", err)
+	}
+
+	bindings := slices.DeleteFunc(slices.Clone(listed), func(binding *rolebindingpb.RoleBinding) bool {
+		return binding.GetRoleId() != roleID ||
+			binding.GetResource().GetType() != resource.GetType() ||
+			binding.GetResource().GetId() != resource.GetId()
+	})
+	sortBindings(bindings)
+	return bindings, nil
+}
+
+// diff returns the principals that are missing a binding, and the bindings
+// that are not for one of principals, including duplicates.
+func diff(principals []principal, bindings []*rolebindingpb.RoleBinding) (create []principal, destroy []*rolebindingpb.RoleBinding) {
+	seen := make(map[principal]bool, len(bindings))
+	for _, binding := range bindings {
+		p := principalOf(binding)
+		if seen[p] || !slices.Contains(principals, p) {
+			destroy = append(destroy, binding)
+			continue
+		}
+		seen[p] = true
+	}
+
+	for _, p := range principals {
+		if !seen[p] {
+			create = append(create, p)
+		}
+	}
+	return create, destroy
+}
+
+// reconcile makes principals exactly the principals holding roleID on
+// resource, given its existing bindings. Bindings are created before any are
+// destroyed, so that replacing one principal with another never leaves the
+// resource without either.
+//
+// Every change is attempted even if some fail. The bindings afterwards are
+// returned along with the failures.
+func reconcile(ctx context.Context, client roleBindingClient, resource *rolebindingpb.Resource, roleID string, principals []principal, bindings []*rolebindingpb.RoleBinding) ([]*rolebindingpb.RoleBinding, error) {
+	bindings = slices.Clone(bindings)
+	create, destroy := diff(principals, bindings)
+
+	var errs []error
+	for _, p := range create {
+		binding := &rolebindingpb.RoleBinding{
+			RoleId:   roleID,
+			Resource: &rolebindingpb.Resource{Type: resource.GetType(), Id: resource.GetId()},
+		}
+		if p.group != "" {
+			binding.Principal = &rolebindingpb.RoleBinding_Group{Group: &rolebindingpb.Group{ClaimValue: p.group}}
+		} else {
+			binding.Principal = &rolebindingpb.RoleBinding_User{User: &rolebindingpb.User{Subject: p.user}}
+		}
+
+		created, err := client.CreateRoleBinding(ctx, binding)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not bind %s: %w", p, err))
+			continue
+		}
+		bindings = append(bindings, created)
+	}
+
+	for _, binding := range destroy {
+		err := client.DestroyRoleBinding(ctx, binding.GetId())
+		if err != nil && status.Code(err) != codes.NotFound {
+			errs = append(errs, fmt.Errorf("could not revoke role binding %q of %s: %w", binding.GetId(), principalOf(binding), err))
+			continue
+		}
+		bindings = slices.DeleteFunc(bindings, func(b *rolebindingpb.RoleBinding) bool {
+			return b.GetId() == binding.GetId()
+		})
+	}
+
+	sortBindings(bindings)
+	return bindings, errors.Join(errs...)
+}
+
+func sortBindings(bindings []*rolebindingpb.RoleBinding) {
+	slices.SortFunc(bindings, func(a, b *rolebindingpb.RoleBinding) int {
+		pa, pb := principalOf(a), principalOf(b)
+		return cmp.Or(
+			cmp.Compare(pa.group, pb.group),
+			cmp.Compare(pa.user, pb.user),
+			cmp.Compare(a.GetId(), b.GetId()),
+		)
+	})
+}
+
+// bindingsID returns the ID of the role bindings of roleID on a resource.
+func bindingsID(resourceType, resourceID, roleID string) string {
+	return strings.Join([]string{resourceType, resourceID, roleID}, "/")
+}
+
+// parseBindingsID returns the resource type, resource ID and role ID from
+// the ID of role bindings.
+func parseBindingsID(id string) (resourceType, resourceID, roleID string, err error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return "", "", "", fmt.Errorf("invalid role bindings ID %q: expected <resource_type>/<resource_id>/<role_id>", id)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
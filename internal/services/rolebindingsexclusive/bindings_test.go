@@ -0,0 +1,176 @@
+package rolebindingsexclusive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	rolebindingsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/role_binding_service/v1alpha1"
+	rolebindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/role_binding/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRoleBindingClient stores role bindings in memory. Binding failPrincipal
+// and destroying failDestroyID fail.
+type fakeRoleBindingClient struct {
+	bindings      []*rolebindingpb.RoleBinding
+	nextID        int
+	failPrincipal principal
+	failDestroyID string
+}
+
+func (c *fakeRoleBindingClient) add(roleID, resourceID string, p principal) {
+	binding := &rolebindingpb.RoleBinding{
+		RoleId:   roleID,
+		Resource: &rolebindingpb.Resource{Type: "TrustZone", Id: resourceID},
+	}
+	if p.group != "" {
+		binding.Principal = &rolebindingpb.RoleBinding_Group{Group: &rolebindingpb.Group{ClaimValue: p.group}}
+	} else {
+		binding.Principal = &rolebindingpb.RoleBinding_User{User: &rolebindingpb.User{Subject: p.user}}
+	}
+	_, _ = c.CreateRoleBinding(context.Background(), binding)
+}
+
+func (c *fakeRoleBindingClient) CreateRoleBinding(_ context.Context, binding *rolebindingpb.RoleBinding) (*rolebindingpb.RoleBinding, error) {
+	if principalOf(binding) == c.failPrincipal {
+		return nil, errors.New("create failed")
+	}
+	c.nextID++
+	created := &rolebindingpb.RoleBinding{
+		Id:        fmt.Sprintf("rb-%d", c.nextID),
+		RoleId:    binding.RoleId,
+		Resource:  binding.Resource,
+		Principal: binding.Principal,
+	}
+	c.bindings = append(c.bindings, created)
+	return created, nil
+}
+
+func (c *fakeRoleBindingClient) ListRoleBindings(_ context.Context, filter *rolebindingsvcpb.ListRoleBindingsRequest_Filter) ([]*rolebindingpb.RoleBinding, error) {
+	var bindings []*rolebindingpb.RoleBinding
+	for _, binding := range c.bindings {
+		if binding.GetRoleId() == *filter.RoleId && binding.GetResource().GetId() == *filter.ResourceId {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
+
+func (c *fakeRoleBindingClient) DestroyRoleBinding(_ context.Context, id string) error {
+	if id == c.failDestroyID {
+		return errors.New("destroy failed")
+	}
+	for i, binding := range c.bindings {
+		if binding.GetId() == id {
+			c.bindings = append(c.bindings[:i], c.bindings[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("role binding %q not found", id)
+}
+
+var trustZone = &rolebindingpb.Resource{Type: "TrustZone", Id: "tz-1"}
+
+// holders returns the principals of bindings.
+func holders(bindings []*rolebindingpb.RoleBinding) []string {
+	var result []string
+	for _, binding := range bindings {
+		result = append(result, principalOf(binding).String())
+	}
+	return result
+}
+
+func TestReconcile(t *testing.T) {
+	alice := principal{user: "alice"}
+	bob := principal{user: "bob"}
+	admins := principal{group: "admins"}
+
+	tests := []struct {
+		name        string
+		existing    []principal
+		desired     []principal
+		wantHolders []string
+	}{
+		{
+			name:        "new bindings",
+			desired:     []principal{alice, admins},
+			wantHolders: []string{`user "alice"`, `group "admins"`},
+		},
+		{
+			name:        "unmanaged binding revoked",
+			existing:    []principal{alice, bob},
+			desired:     []principal{alice},
+			wantHolders: []string{`user "alice"`},
+		},
+		{
+			name:        "principal replaced",
+			existing:    []principal{alice},
+			desired:     []principal{bob},
+			wantHolders: []string{`user "bob"`},
+		},
+		{
+			name:        "duplicate binding revoked",
+			existing:    []principal{admins, admins},
+			desired:     []principal{admins},
+			wantHolders: []string{`group "admins"`},
+		},
+		{
+			name:     "all revoked",
+			existing: []principal{alice, admins},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &fakeRoleBindingClient{}
+			for _, p := range tt.existing {
+				client.add("viewer", "tz-1", p)
+			}
+			// Bindings of another role or on another resource are left alone.
+			client.add("editor", "tz-1", bob)
+			client.add("viewer", "tz-2", bob)
+
+			existing, err := listBindings(ctx, client, trustZone, "viewer")
+			require.NoError(t, err)
+
+			bindings, err := reconcile(ctx, client, trustZone, "viewer", tt.desired, existing)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHolders, holders(bindings))
+
+			listed, err := listBindings(ctx, client, trustZone, "viewer")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHolders, holders(listed))
+			assert.Len(t, client.bindings, len(tt.wantHolders)+2)
+		})
+	}
+}
+
+func TestReconcilePartialFailure(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeRoleBindingClient{}
+	client.add("viewer", "tz-1", principal{user: "alice"})
+	client.add("viewer", "tz-1", principal{user: "bob"})
+	client.failPrincipal = principal{group: "admins"}
+	client.failDestroyID = "rb-2"
+
+	existing, err := listBindings(ctx, client, trustZone, "viewer")
+	require.NoError(t, err)
+
+	bindings, err := reconcile(ctx, client, trustZone, "viewer", principals(nil, []string{"admins", "devs"}), existing)
+	assert.EqualError(t, err, `could not bind group "admins": create failed
+could not revoke role binding "rb-2" of user "bob": destroy failed`)
+	assert.Equal(t, []string{`user "bob"`, `group "devs"`}, holders(bindings))
+}
+
+func TestParseBindingsID(t *testing.T) {
+	resourceType, resourceID, roleID, err := parseBindingsID(bindingsID("TrustZone", "tz-1", "roles/viewer"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TrustZone", "tz-1", "roles/viewer"}, []string{resourceType, resourceID, roleID})
+
+	_, _, _, err = parseBindingsID("TrustZone/tz-1")
+	assert.EqualError(t, err, `invalid role bindings ID "TrustZone/tz-1": expected <resource_type>/<resource_id>/<role_id>`)
+}
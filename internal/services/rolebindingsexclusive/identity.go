@@ -0,0 +1,35 @@
+package rolebindingsexclusive
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = (*RoleBindingsExclusiveResource)(nil)
+
+// RoleBindingsExclusiveIdentityModel is the resource identity of the
+// principals holding a role on a resource, identified by the resource and
+// role.
+type RoleBindingsExclusiveIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func newIdentityModel(m RoleBindingsExclusiveModel) RoleBindingsExclusiveIdentityModel {
+	return RoleBindingsExclusiveIdentityModel{
+		ID: m.ID,
+	}
+}
+
+func (r *RoleBindingsExclusiveResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the role bindings, in the form `<resource_type>/<resource_id>/<role_id>`.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package rolebindingsexclusive
+
+import tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+
+type RoleBindingsExclusiveModel struct {
+	ID       tftypes.String `tfsdk:"id"`
+	RoleID   tftypes.String `tfsdk:"role_id"`
+	Resource *ResourceModel `tfsdk:"resource"`
+	Users    tftypes.Set    `tfsdk:"users"`
+	Groups   tftypes.Set    `tfsdk:"groups"`
+}
+
+type ResourceModel struct {
+	Type tftypes.String `tfsdk:"type"`
+	ID   tftypes.String `tfsdk:"id"`
+}
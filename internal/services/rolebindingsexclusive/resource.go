@@ -0,0 +1,283 @@
+package rolebindingsexclusive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	rolebindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/role_binding/v1alpha1"
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &RoleBindingsExclusiveResource{}
+	_ resource.ResourceWithImportState = &RoleBindingsExclusiveResource{}
+)
+
+type RoleBindingsExclusiveResource struct {
+	client sdkclient.ClientSet
+}
+
+func NewResource() resource.Resource {
+	return &RoleBindingsExclusiveResource{}
+}
+
+func (r *RoleBindingsExclusiveResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connect_role_bindings_exclusive"
+}
+
+func (r *RoleBindingsExclusiveResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(sdkclient.ClientSet)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected sdkclient.ClientSet, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RoleBindingsExclusiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan RoleBindingsExclusiveModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := planPrincipals(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.RoleBindingV1Alpha1()
+	target := resourceProto(plan)
+	roleID := plan.RoleID.ValueString()
+
+	existing, err := listBindings(ctx, client, target, roleID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating role bindings",
+			fmt.Sprintf("Could not read the existing role bindings: %s", err),
+		)
+		return
+	}
+
+	bindings, err := reconcile(ctx, client, target, roleID, desired, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating role bindings",
+			fmt.Sprintf("Could not reconcile the role bindings: %s", err),
+		)
+		// The bindings are kept in state, which Terraform marks as tainted
+		// so that they are replaced on the next apply.
+		if len(bindings) == 0 {
+			return
+		}
+	}
+
+	newState, diags := bindingsToModel(ctx, plan, bindings)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+}
+
+func (r *RoleBindingsExclusiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state RoleBindingsExclusiveModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// After import only the ID is known.
+	if state.Resource == nil {
+		resourceType, resourceID, roleID, err := parseBindingsID(state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading role bindings", err.Error())
+			return
+		}
+		state.Resource = &ResourceModel{
+			Type: tftypes.StringValue(resourceType),
+			ID:   tftypes.StringValue(resourceID),
+		}
+		state.RoleID = tftypes.StringValue(roleID)
+	}
+
+	bindings, err := listBindings(ctx, r.client.RoleBindingV1Alpha1(), resourceProto(state), state.RoleID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role bindings",
+			fmt.Sprintf("Could not read role bindings %q: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	newState, diags := bindingsToModel(ctx, state, bindings)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+}
+
+func (r *RoleBindingsExclusiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RoleBindingsExclusiveModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := planPrincipals(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.RoleBindingV1Alpha1()
+	target := resourceProto(plan)
+	roleID := plan.RoleID.ValueString()
+
+	existing, err := listBindings(ctx, client, target, roleID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating role bindings",
+			fmt.Sprintf("Could not read role bindings %q: %s", plan.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// On failure the state records the bindings that exist, so that the
+	// next plan shows the changes that are still needed.
+	bindings, err := reconcile(ctx, client, target, roleID, desired, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating role bindings",
+			fmt.Sprintf("Could not reconcile role bindings %q: %s", plan.ID.ValueString(), err),
+		)
+	}
+
+	newState, diags := bindingsToModel(ctx, plan, bindings)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+}
+
+func (r *RoleBindingsExclusiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state RoleBindingsExclusiveModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.RoleBindingV1Alpha1()
+	target := resourceProto(state)
+	roleID := state.RoleID.ValueString()
+
+	existing, err := listBindings(ctx, client, target, roleID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting role bindings",
+			fmt.Sprintf("Could not read role bindings %q: %s", state.ID.ValueString(), err),
+		)
+		return
+	}
+
+	remaining, err := reconcile(ctx, client, target, roleID, nil, existing)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting role bindings",
+			fmt.Sprintf("Could not revoke role bindings %q: %s", state.ID.ValueString(), err),
+		)
+
+		newState, diags := bindingsToModel(ctx, state, remaining)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState))...)
+	}
+}
+
+func (r *RoleBindingsExclusiveResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != "" {
+		if _, _, _, err := parseBindingsID(req.ID); err != nil {
+			resp.Diagnostics.AddError("Error importing role bindings", err.Error())
+			return
+		}
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+func resourceProto(model RoleBindingsExclusiveModel) *rolebindingpb.Resource {
+	return &rolebindingpb.Resource{
+		Type: model.Resource.Type.ValueString(),
+		Id:   model.Resource.ID.ValueString(),
+	}
+}
+
+// planPrincipals returns the principals that should hold the role.
+func planPrincipals(ctx context.Context, plan RoleBindingsExclusiveModel) ([]principal, diag.Diagnostics) {
+	var users, groups []string
+
+	diags := plan.Users.ElementsAs(ctx, &users, false)
+	diags.Append(plan.Groups.ElementsAs(ctx, &groups, false)...)
+	return principals(users, groups), diags
+}
+
+// bindingsToModel returns prior with the principals of bindings.
+func bindingsToModel(ctx context.Context, prior RoleBindingsExclusiveModel, bindings []*rolebindingpb.RoleBinding) (RoleBindingsExclusiveModel, diag.Diagnostics) {
+	users := []string{}
+	groups := []string{}
+	for _, binding := range bindings {
+		if p := principalOf(binding); p.group != "" {
+			groups = append(groups, p.group)
+		} else {
+			users = append(users, p.user)
+		}
+	}
+
+	// Bindings are sorted by principal, so duplicates left by a failed
+	// revocation are adjacent.
+	users = slices.Compact(users)
+	groups = slices.Compact(groups)
+
+	var diags diag.Diagnostics
+	model := prior
+	model.ID = tftypes.StringValue(bindingsID(prior.Resource.Type.ValueString(), prior.Resource.ID.ValueString(), prior.RoleID.ValueString()))
+
+	var d diag.Diagnostics
+	model.Users, d = tftypes.SetValueFrom(ctx, tftypes.StringType, users)
+	diags.Append(d...)
+	model.Groups, d = tftypes.SetValueFrom(ctx, tftypes.StringType, groups)
+	diags.Append(d...)
+
+	return model, diags
+}
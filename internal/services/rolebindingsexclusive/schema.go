@@ -0,0 +1,77 @@
+package rolebindingsexclusive
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func ResourceSchema(_ context.Context) schema.Schema {
+	emptySet := types.SetValueMust(types.StringType, []attr.Value{})
+
+	return schema.Schema{
+		MarkdownDescription: "Authoritatively manages the users and groups holding a Cofide Connect role on a resource. " +
+			"Role bindings of the role on the resource for any other principal, including ones granted outside of Terraform, are revoked, " +
+			"and destroying this resource revokes the role from every principal. " +
+			"Do not use it together with `cofide_connect_role_binding` for the same role and resource. " +
+			"Import with `<resource_type>/<resource_id>/<role_id>`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the role bindings, in the form `<resource_type>/<resource_id>/<role_id>`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				Description: "The ID of the role. Cannot be changed after creation.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource": schema.SingleNestedAttribute{
+				Description: "The resource the role is held on. Cannot be changed after creation.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Description: "The type of the resource. e.g. TrustZone, Cluster",
+						Required:    true,
+					},
+					"id": schema.StringAttribute{
+						Description: "The ID of the resource.",
+						Required:    true,
+					},
+				},
+			},
+			"users": schema.SetAttribute{
+				Description: "The subject identifiers of the users holding the role (typically email addresses or user IDs). Defaults to none.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(emptySet),
+			},
+			"groups": schema.SetAttribute{
+				Description: "The group claim values from the identity provider of the groups holding the role. Defaults to none.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(emptySet),
+			},
+		},
+	}
+}
+
+func (r *RoleBindingsExclusiveResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = ResourceSchema(ctx)
+}
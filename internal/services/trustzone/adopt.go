@@ -0,0 +1,52 @@
+package trustzone
+
+import (
+	"context"
+	"fmt"
+
+	trustzonesvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_service/v1alpha1"
+	trustzonepb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"google.golang.org/protobuf/proto"
+)
+
+// trustZoneClient is the part of the trust zone API used to adopt an
+// existing trust zone and to update one.
+type trustZoneClient interface {
+	ListTrustZones(ctx context.Context, filter *trustzonesvcpb.ListTrustZonesRequest_Filter) ([]*trustzonepb.TrustZone, error)
+	UpdateTrustZone(ctx context.Context, trustZone *trustzonepb.TrustZone) (*trustzonepb.TrustZone, error)
+}
+
+// adoptTrustZone finds the existing trust zone with the name and organization
+// of trustZone, which could not be created because it already exists, and
+// updates it to match trustZone.
+func adoptTrustZone(ctx context.Context, client trustZoneClient, trustZone *trustzonepb.TrustZone) (*trustzonepb.TrustZone, error) {
+	name := trustZone.GetName()
+	trustZones, err := client.ListTrustZones(ctx, &trustzonesvcpb.ListTrustZonesRequest_Filter{
+		Name:  &name,
+		OrgId: trustZone.OrgId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list trust zones to adopt: %w", err)
+	}
+
+	existing, err := util.AdoptionCandidate("trust zone", fmt.Sprintf("named %q", name), trustZones, func(tz *trustzonepb.TrustZone) bool {
+		return tz.GetName() == name && (trustZone.OrgId == nil || tz.GetOrgId() == trustZone.GetOrgId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.GetIsManagementZone() != trustZone.GetIsManagementZone() {
+		return nil, fmt.Errorf("the existing trust zone %q (%s) has is_management_zone %t, which cannot be changed", name, existing.GetId(), existing.GetIsManagementZone())
+	}
+
+	adopted := proto.Clone(trustZone).(*trustzonepb.TrustZone)
+	adopted.Id = existing.Id
+	adopted.OrgId = existing.OrgId
+	updated, err := client.UpdateTrustZone(ctx, adopted)
+	if err != nil {
+		return nil, fmt.Errorf("could not update the existing trust zone %q (%s): %w", name, existing.GetId(), err)
+	}
+	return updated, nil
+}
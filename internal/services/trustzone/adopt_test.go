@@ -0,0 +1,71 @@
+package trustzone
+
+import (
+	"context"
+	"testing"
+
+	trustzonesvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_service/v1alpha1"
+	trustzonepb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTrustZoneClient lists fixed trust zones and records the updates made.
+type fakeTrustZoneClient struct {
+	trustZones []*trustzonepb.TrustZone
+	updated    []*trustzonepb.TrustZone
+}
+
+func (c *fakeTrustZoneClient) ListTrustZones(_ context.Context, filter *trustzonesvcpb.ListTrustZonesRequest_Filter) ([]*trustzonepb.TrustZone, error) {
+	var trustZones []*trustzonepb.TrustZone
+	for _, trustZone := range c.trustZones {
+		if filter.OrgId == nil || trustZone.GetOrgId() == *filter.OrgId {
+			trustZones = append(trustZones, trustZone)
+		}
+	}
+	return trustZones, nil
+}
+
+func (c *fakeTrustZoneClient) UpdateTrustZone(_ context.Context, trustZone *trustzonepb.TrustZone) (*trustzonepb.TrustZone, error) {
+	c.updated = append(c.updated, trustZone)
+	return trustZone, nil
+}
+
+func TestAdoptTrustZone(t *testing.T) {
+	client := &fakeTrustZoneClient{trustZones: []*trustzonepb.TrustZone{
+		{Id: ptr("tz-1"), OrgId: ptr("org-1"), Name: "prod", TrustDomain: "old.example.com"},
+		{Id: ptr("tz-2"), OrgId: ptr("org-2"), Name: "prod", TrustDomain: "other.example.com"},
+		{Id: ptr("tz-3"), OrgId: ptr("org-1"), Name: "dev", TrustDomain: "dev.example.com"},
+	}}
+
+	adopted, err := adoptTrustZone(context.Background(), client, &trustzonepb.TrustZone{
+		OrgId:       ptr("org-1"),
+		Name:        "prod",
+		TrustDomain: "prod.example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tz-1", adopted.GetId())
+	assert.Equal(t, "prod.example.com", adopted.GetTrustDomain())
+	assert.Len(t, client.updated, 1)
+}
+
+func TestAdoptTrustZoneAmbiguous(t *testing.T) {
+	client := &fakeTrustZoneClient{trustZones: []*trustzonepb.TrustZone{
+		{Id: ptr("tz-1"), OrgId: ptr("org-1"), Name: "prod"},
+		{Id: ptr("tz-2"), OrgId: ptr("org-2"), Name: "prod"},
+	}}
+
+	_, err := adoptTrustZone(context.Background(), client, &trustzonepb.TrustZone{Name: "prod"})
+	assert.EqualError(t, err, `trust zone named "prod" is ambiguous: found 2 matches (IDs: tz-1, tz-2); import the one to manage by ID instead`)
+	assert.Empty(t, client.updated)
+}
+
+func TestAdoptTrustZoneManagementZoneMismatch(t *testing.T) {
+	client := &fakeTrustZoneClient{trustZones: []*trustzonepb.TrustZone{
+		{Id: ptr("tz-1"), OrgId: ptr("org-1"), Name: "prod", IsManagementZone: true},
+	}}
+
+	_, err := adoptTrustZone(context.Background(), client, &trustzonepb.TrustZone{Name: "prod"})
+	assert.EqualError(t, err, `the existing trust zone "prod" (tz-1) has is_management_zone true, which cannot be changed`)
+	assert.Empty(t, client.updated)
+}
@@ -0,0 +1,19 @@
+package trustzone
+
+import (
+	trustzonepb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func protoToModel(trustZone *trustzonepb.TrustZone) TrustZoneModel {
+	return TrustZoneModel{
+		ID:                    tftypes.StringValue(trustZone.GetId()),
+		Name:                  tftypes.StringValue(trustZone.GetName()),
+		TrustDomain:           tftypes.StringValue(trustZone.GetTrustDomain()),
+		OrgID:                 tftypes.StringValue(trustZone.GetOrgId()),
+		IsManagementZone:      tftypes.BoolValue(trustZone.GetIsManagementZone()),
+		BundleEndpointURL:     tftypes.StringValue(trustZone.GetBundleEndpointUrl()),
+		BundleEndpointProfile: tftypes.StringValue(trustZone.GetBundleEndpointProfile().String()),
+		JWTIssuer:             tftypes.StringValue(trustZone.GetJwtIssuer()),
+	}
+}
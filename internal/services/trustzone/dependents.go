@@ -0,0 +1,213 @@
+package trustzone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	apbindingsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/ap_binding_service/v1alpha1"
+	clustersvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/cluster_service/v1alpha1"
+	exchangepolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/exchange_policy_service/v1alpha1"
+	federationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/federation_service/v1alpha1"
+	trustzoneserversvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_server_service/v1alpha1"
+	exchangepolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/exchange_policy/v1alpha1"
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
+	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type federationClient interface {
+	ListFederations(ctx context.Context, filter *federationsvcpb.ListFederationsRequest_Filter) ([]*federationpb.Federation, error)
+	DestroyFederation(ctx context.Context, id string) error
+}
+
+type apBindingClient interface {
+	ListAPBindings(ctx context.Context, filter *apbindingsvcpb.ListAPBindingsRequest_Filter) ([]*apbindingpb.APBinding, error)
+	DestroyAPBinding(ctx context.Context, id string) error
+}
+
+type exchangePolicyClient interface {
+	ListExchangePolicies(ctx context.Context, filter *exchangepolicysvcpb.ListExchangePoliciesRequest_Filter) ([]*exchangepolicypb.ExchangePolicy, error)
+	DestroyExchangePolicy(ctx context.Context, id string) error
+}
+
+type trustZoneServerClient interface {
+	ListTrustZoneServers(ctx context.Context, filter *trustzoneserversvcpb.ListTrustZoneServersRequest_Filter) ([]*trustzoneserverpb.TrustZoneServer, error)
+	DestroyTrustZoneServer(ctx context.Context, id string) error
+}
+
+type clusterClient interface {
+	ListClusters(ctx context.Context, filter *clustersvcpb.ListClustersRequest_Filter) ([]*clusterpb.Cluster, error)
+	DestroyCluster(ctx context.Context, id string) error
+}
+
+// dependentClients are the parts of the API used to find and delete the
+// objects that reference a trust zone.
+type dependentClients struct {
+	federations      federationClient
+	apBindings       apBindingClient
+	exchangePolicies exchangePolicyClient
+	trustZoneServers trustZoneServerClient
+	clusters         clusterClient
+}
+
+func newDependentClients(client sdkclient.ClientSet) dependentClients {
+	return dependentClients{
+		federations:      client.FederationV1Alpha1(),
+		apBindings:       client.APBindingV1Alpha1(),
+		exchangePolicies: client.ExchangePolicyV1Alpha1(),
+		trustZoneServers: client.TrustZoneServerV1Alpha1(),
+		clusters:         client.ClusterV1Alpha1(),
+	}
+}
+
+// dependent is an object that references a trust zone and so must be deleted
+// before it.
+type dependent struct {
+	// description names the object in diagnostics, for example
+	// `cluster "prod" (c-1)`.
+	description string
+	destroy     func(ctx context.Context) error
+	// protected is true for trust zone servers and clusters. Their
+	// resources have deletion_protection and retain_on_delete, which are
+	// kept in the state of those resources and cannot be checked here, so
+	// they are never deleted by cascade.
+	protected bool
+}
+
+// listDependents returns the objects that reference the trust zone
+// trustZoneID, in the order they can be deleted: federations to or from it,
+// attestation policy bindings, exchange policies, trust zone servers and
+// finally clusters.
+func listDependents(ctx context.Context, clients dependentClients, trustZoneID string) ([]dependent, error) {
+	var dependents []dependent
+
+	seen := map[string]bool{}
+	for _, filter := range []*federationsvcpb.ListFederationsRequest_Filter{
+		{TrustZoneId: &trustZoneID},
+		{RemoteTrustZoneId: &trustZoneID},
+	} {
+		federations, err := clients.federations.ListFederations(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("could not list federations: %w", err)
+		}
+		for _, federation := range federations {
+			if seen[federation.GetId()] {
+				continue
+			}
+			seen[federation.GetId()] = true
+
+			id := federation.GetId()
+			dependents = append(dependents, dependent{
+				description: fmt.Sprintf("federation %s from trust zone %s to %s", id, federation.GetTrustZoneId(), federation.GetRemoteTrustZoneId()),
+				destroy: func(ctx context.Context) error {
+					return clients.federations.DestroyFederation(ctx, id)
+				},
+			})
+		}
+	}
+
+	bindings, err := clients.apBindings.ListAPBindings(ctx, &apbindingsvcpb.ListAPBindingsRequest_Filter{
+		TrustZoneId: &trustZoneID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list attestation policy bindings: %w", err)
+	}
+	for _, binding := range bindings {
+		id := binding.GetId()
+		dependents = append(dependents, dependent{
+			description: fmt.Sprintf("attestation policy binding %s of policy %s", id, binding.GetPolicyId()),
+			destroy: func(ctx context.Context) error {
+				return clients.apBindings.DestroyAPBinding(ctx, id)
+			},
+		})
+	}
+
+	policies, err := clients.exchangePolicies.ListExchangePolicies(ctx, &exchangepolicysvcpb.ListExchangePoliciesRequest_Filter{
+		TrustZoneId: trustZoneID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list exchange policies: %w", err)
+	}
+	for _, policy := range policies {
+		id := policy.GetId()
+		dependents = append(dependents, dependent{
+			description: fmt.Sprintf("exchange policy %q (%s)", policy.GetName(), id),
+			destroy: func(ctx context.Context) error {
+				return clients.exchangePolicies.DestroyExchangePolicy(ctx, id)
+			},
+		})
+	}
+
+	servers, err := clients.trustZoneServers.ListTrustZoneServers(ctx, &trustzoneserversvcpb.ListTrustZoneServersRequest_Filter{
+		TrustZoneId: trustZoneID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list trust zone servers: %w", err)
+	}
+	for _, server := range servers {
+		id := server.GetId()
+		dependents = append(dependents, dependent{
+			description: fmt.Sprintf("trust zone server %s in cluster %s", id, server.GetClusterId()),
+			destroy: func(ctx context.Context) error {
+				return clients.trustZoneServers.DestroyTrustZoneServer(ctx, id)
+			},
+			protected: true,
+		})
+	}
+
+	clusters, err := clients.clusters.ListClusters(ctx, &clustersvcpb.ListClustersRequest_Filter{
+		TrustZoneId: &trustZoneID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list clusters: %w", err)
+	}
+	for _, cluster := range clusters {
+		id := cluster.GetId()
+		dependents = append(dependents, dependent{
+			description: fmt.Sprintf("cluster %q (%s)", cluster.GetName(), id),
+			destroy: func(ctx context.Context) error {
+				return clients.clusters.DestroyCluster(ctx, id)
+			},
+			protected: true,
+		})
+	}
+
+	return dependents, nil
+}
+
+// deleteDependents deletes dependents in order, stopping at the first failure
+// since later objects may still be referenced by the one that failed. Objects
+// that no longer exist are skipped. Nothing is deleted if any dependent is
+// protected.
+func deleteDependents(ctx context.Context, dependents []dependent) error {
+	var protected []dependent
+	for _, d := range dependents {
+		if d.protected {
+			protected = append(protected, d)
+		}
+	}
+	if len(protected) > 0 {
+		return fmt.Errorf("cascade_delete does not delete trust zone servers or clusters, whose deletion_protection and retain_on_delete cannot be checked. Destroy these through their own resources first:%s", describeDependents(protected))
+	}
+
+	for _, d := range dependents {
+		if err := d.destroy(ctx); err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("could not delete %s: %w", d.description, err)
+		}
+	}
+	return nil
+}
+
+// describeDependents returns a bulleted list of dependents, one per line.
+func describeDependents(dependents []dependent) string {
+	var b strings.Builder
+	for _, d := range dependents {
+		fmt.Fprintf(&b, "\n  - %s", d.description)
+	}
+	return b.String()
+}
@@ -0,0 +1,223 @@
+package trustzone
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apbindingpb "github.com/cofide/cofide-api-sdk/gen/go/proto/ap_binding/v1alpha1"
+	clusterpb "github.com/cofide/cofide-api-sdk/gen/go/proto/cluster/v1alpha1"
+	apbindingsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/ap_binding_service/v1alpha1"
+	clustersvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/cluster_service/v1alpha1"
+	exchangepolicysvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/exchange_policy_service/v1alpha1"
+	federationsvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/federation_service/v1alpha1"
+	trustzoneserversvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_server_service/v1alpha1"
+	exchangepolicypb "github.com/cofide/cofide-api-sdk/gen/go/proto/exchange_policy/v1alpha1"
+	federationpb "github.com/cofide/cofide-api-sdk/gen/go/proto/federation/v1alpha1"
+	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeDependentClient lists fixed objects and records the objects deleted.
+// Deleting failID fails and deleting goneID reports that it does not exist.
+type fakeDependentClient struct {
+	federations      []*federationpb.Federation
+	bindings         []*apbindingpb.APBinding
+	exchangePolicies []*exchangepolicypb.ExchangePolicy
+	servers          []*trustzoneserverpb.TrustZoneServer
+	clusters         []*clusterpb.Cluster
+
+	failID  string
+	goneID  string
+	deleted []string
+}
+
+func (c *fakeDependentClient) destroy(id string) error {
+	switch id {
+	case c.failID:
+		return errors.New("delete failed")
+	case c.goneID:
+		return status.Error(codes.NotFound, "not found")
+	}
+	c.deleted = append(c.deleted, id)
+	return nil
+}
+
+func (c *fakeDependentClient) ListFederations(_ context.Context, filter *federationsvcpb.ListFederationsRequest_Filter) ([]*federationpb.Federation, error) {
+	var federations []*federationpb.Federation
+	for _, federation := range c.federations {
+		if (filter.TrustZoneId == nil || federation.GetTrustZoneId() == *filter.TrustZoneId) &&
+			(filter.RemoteTrustZoneId == nil || federation.GetRemoteTrustZoneId() == *filter.RemoteTrustZoneId) {
+			federations = append(federations, federation)
+		}
+	}
+	return federations, nil
+}
+
+func (c *fakeDependentClient) DestroyFederation(_ context.Context, id string) error {
+	return c.destroy(id)
+}
+
+func (c *fakeDependentClient) ListAPBindings(_ context.Context, filter *apbindingsvcpb.ListAPBindingsRequest_Filter) ([]*apbindingpb.APBinding, error) {
+	var bindings []*apbindingpb.APBinding
+	for _, binding := range c.bindings {
+		if binding.GetTrustZoneId() == *filter.TrustZoneId {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
+
+func (c *fakeDependentClient) DestroyAPBinding(_ context.Context, id string) error {
+	return c.destroy(id)
+}
+
+func (c *fakeDependentClient) ListExchangePolicies(_ context.Context, filter *exchangepolicysvcpb.ListExchangePoliciesRequest_Filter) ([]*exchangepolicypb.ExchangePolicy, error) {
+	var policies []*exchangepolicypb.ExchangePolicy
+	for _, policy := range c.exchangePolicies {
+		if policy.GetTrustZoneId() == filter.TrustZoneId {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+func (c *fakeDependentClient) DestroyExchangePolicy(_ context.Context, id string) error {
+	return c.destroy(id)
+}
+
+func (c *fakeDependentClient) ListTrustZoneServers(_ context.Context, filter *trustzoneserversvcpb.ListTrustZoneServersRequest_Filter) ([]*trustzoneserverpb.TrustZoneServer, error) {
+	var servers []*trustzoneserverpb.TrustZoneServer
+	for _, server := range c.servers {
+		if server.GetTrustZoneId() == filter.TrustZoneId {
+			servers = append(servers, server)
+		}
+	}
+	return servers, nil
+}
+
+func (c *fakeDependentClient) DestroyTrustZoneServer(_ context.Context, id string) error {
+	return c.destroy(id)
+}
+
+func (c *fakeDependentClient) ListClusters(_ context.Context, filter *clustersvcpb.ListClustersRequest_Filter) ([]*clusterpb.Cluster, error) {
+	var clusters []*clusterpb.Cluster
+	for _, cluster := range c.clusters {
+		if cluster.GetTrustZoneId() == *filter.TrustZoneId {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters, nil
+}
+
+func (c *fakeDependentClient) DestroyCluster(_ context.Context, id string) error {
+	return c.destroy(id)
+}
+
+func (c *fakeDependentClient) clients() dependentClients {
+	return dependentClients{
+		federations:      c,
+		apBindings:       c,
+		exchangePolicies: c,
+		trustZoneServers: c,
+		clusters:         c,
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func newFakeDependentClient() *fakeDependentClient {
+	return &fakeDependentClient{
+		federations: []*federationpb.Federation{
+			{Id: ptr("fed-1"), TrustZoneId: ptr("tz-1"), RemoteTrustZoneId: ptr("tz-2")},
+			{Id: ptr("fed-2"), TrustZoneId: ptr("tz-2"), RemoteTrustZoneId: ptr("tz-1")},
+			{Id: ptr("fed-3"), TrustZoneId: ptr("tz-2"), RemoteTrustZoneId: ptr("tz-3")},
+		},
+		bindings: []*apbindingpb.APBinding{
+			{Id: ptr("apb-1"), TrustZoneId: ptr("tz-1"), PolicyId: ptr("ap-1")},
+			{Id: ptr("apb-2"), TrustZoneId: ptr("tz-2"), PolicyId: ptr("ap-1")},
+		},
+		exchangePolicies: []*exchangepolicypb.ExchangePolicy{
+			{Id: "ep-1", Name: "policy", TrustZoneId: "tz-1"},
+		},
+		servers: []*trustzoneserverpb.TrustZoneServer{
+			{Id: "tzs-1", TrustZoneId: "tz-1", ClusterId: "c-1"},
+		},
+		clusters: []*clusterpb.Cluster{
+			{Id: ptr("c-1"), Name: ptr("prod"), TrustZoneId: ptr("tz-1")},
+			{Id: ptr("c-2"), Name: ptr("other"), TrustZoneId: ptr("tz-2")},
+		},
+	}
+}
+
+func TestListDependents(t *testing.T) {
+	client := newFakeDependentClient()
+
+	dependents, err := listDependents(context.Background(), client.clients(), "tz-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, `
+  - federation fed-1 from trust zone tz-1 to tz-2
+  - federation fed-2 from trust zone tz-2 to tz-1
+  - attestation policy binding apb-1 of policy ap-1
+  - exchange policy "policy" (ep-1)
+  - trust zone server tzs-1 in cluster c-1
+  - cluster "prod" (c-1)`, describeDependents(dependents))
+
+}
+
+func TestDeleteDependents(t *testing.T) {
+	client := newFakeDependentClient()
+	client.servers = nil
+	client.clusters = nil
+
+	dependents, err := listDependents(context.Background(), client.clients(), "tz-1")
+	require.NoError(t, err)
+
+	require.NoError(t, deleteDependents(context.Background(), dependents))
+	assert.Equal(t, []string{"fed-1", "fed-2", "apb-1", "ep-1"}, client.deleted)
+}
+
+func TestDeleteDependentsProtected(t *testing.T) {
+	client := newFakeDependentClient()
+
+	dependents, err := listDependents(context.Background(), client.clients(), "tz-1")
+	require.NoError(t, err)
+
+	err = deleteDependents(context.Background(), dependents)
+	assert.EqualError(t, err, `cascade_delete does not delete trust zone servers or clusters, whose deletion_protection and retain_on_delete cannot be checked. Destroy these through their own resources first:
+  - trust zone server tzs-1 in cluster c-1
+  - cluster "prod" (c-1)`)
+	assert.Empty(t, client.deleted, "nothing should be deleted while a protected trust zone server remains")
+}
+
+func TestListDependentsNone(t *testing.T) {
+	dependents, err := listDependents(context.Background(), newFakeDependentClient().clients(), "tz-3")
+	require.NoError(t, err)
+
+	var descriptions []string
+	for _, d := range dependents {
+		descriptions = append(descriptions, d.description)
+	}
+	assert.Equal(t, []string{"federation fed-3 from trust zone tz-2 to tz-3"}, descriptions)
+}
+
+func TestDeleteDependentsStopsAtFailure(t *testing.T) {
+	client := newFakeDependentClient()
+	client.servers = nil
+	client.clusters = nil
+	client.goneID = "fed-2"
+	client.failID = "apb-1"
+
+	dependents, err := listDependents(context.Background(), client.clients(), "tz-1")
+	require.NoError(t, err)
+
+	err = deleteDependents(context.Background(), dependents)
+	assert.EqualError(t, err, "could not delete attestation policy binding apb-1 of policy ap-1: delete failed")
+	assert.Equal(t, []string{"fed-1"}, client.deleted, "the exchange policy should not be deleted after the binding failed")
+}
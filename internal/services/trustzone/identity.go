@@ -0,0 +1,39 @@
+package trustzone
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithIdentity = (*TrustZoneResource)(nil)
+
+// TrustZoneIdentityModel is the resource identity of a trust zone.
+type TrustZoneIdentityModel struct {
+	OrgID types.String `tfsdk:"org_id"`
+	ID    types.String `tfsdk:"id"`
+}
+
+func newIdentityModel(m TrustZoneModel) TrustZoneIdentityModel {
+	return TrustZoneIdentityModel{
+		OrgID: m.OrgID,
+		ID:    m.ID,
+	}
+}
+
+func (t *TrustZoneResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"org_id": identityschema.StringAttribute{
+				Description:       "The ID of the organization.",
+				OptionalForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "The ID of the trust zone.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package trustzone
+
+import (
+	"context"
+	"fmt"
+
+	trustzonesvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_service/v1alpha1"
+	trustzonepb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ list.ListResource              = &TrustZoneResource{}
+	_ list.ListResourceWithConfigure = &TrustZoneResource{}
+)
+
+// NewListResource returns the list resource for trust zones, which lets
+// `terraform query` enumerate existing trust zones for import.
+func NewListResource() list.ListResource {
+	return &TrustZoneResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists Cofide Connect trust zones.",
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: "Filter by organization ID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (t *TrustZoneResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (t *TrustZoneResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config TrustZoneListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &trustzonesvcpb.ListTrustZonesRequest_Filter{
+		OrgId: config.OrgID.ValueStringPointer(),
+	}
+	trustZones, err := t.client.TrustZoneV1Alpha1().ListTrustZones(ctx, filter)
+	if err != nil {
+		diags.AddError(
+			"Error listing trust zones",
+			fmt.Sprintf("Could not list trust zones: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.ListResults(ctx, req, trustZones, func(trustZone *trustzonepb.TrustZone) (util.ListResult, diag.Diagnostics) {
+		model := protoToModel(trustZone)
+		return util.ListResult{
+			DisplayName: trustZone.GetName(),
+			Resource: TrustZoneResourceModel{
+				TrustZoneModel:     model,
+				DeletionProtection: tftypes.BoolValue(deletionProtectionDefault),
+				CascadeDelete:      tftypes.BoolValue(false),
+				AdoptExisting:      tftypes.BoolValue(false),
+				RetainOnDelete:     tftypes.BoolValue(false),
+			},
+			Identity: newIdentityModel(model),
+		}, nil
+	})
+}
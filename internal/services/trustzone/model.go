@@ -12,3 +12,19 @@ type TrustZoneModel struct {
 	BundleEndpointProfile types.String `tfsdk:"bundle_endpoint_profile"`
 	JWTIssuer             types.String `tfsdk:"jwt_issuer"`
 }
+
+// TrustZoneResourceModel is the model of the trust zone resource, which adds
+// attributes kept in Terraform state only to those shared with the data
+// source.
+type TrustZoneResourceModel struct {
+	TrustZoneModel
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	CascadeDelete      types.Bool `tfsdk:"cascade_delete"`
+	AdoptExisting      types.Bool `tfsdk:"adopt_existing"`
+	RetainOnDelete     types.Bool `tfsdk:"retain_on_delete"`
+}
+
+// TrustZoneListModel is the configuration of the trust zone list resource.
+type TrustZoneListModel struct {
+	OrgID types.String `tfsdk:"org_id"`
+}
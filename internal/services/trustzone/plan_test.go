@@ -0,0 +1,20 @@
+package trustzone_test
+
+import (
+	"testing"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+)
+
+// TestPlanManagementZoneRemoved checks that removing is_management_zone from
+// the configuration keeps the value Cofide Connect set rather than replacing
+// the trust zone.
+func TestPlanManagementZoneRemoved(t *testing.T) {
+	providertest.RunPlanTests(t, "cofide_connect_trust_zone", []providertest.PlanTest{
+		{
+			Name:   "is_management_zone removed from configuration",
+			State:  map[string]any{"id": "tz-1", "name": "tz", "org_id": "org-1", "trust_domain": "td", "is_management_zone": true},
+			Config: map[string]any{"name": "tz", "trust_domain": "td"},
+		},
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	trustzonepb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone/v1alpha1"
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
 	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,9 +16,8 @@ import (
 )
 
 var (
-	_ resource.Resource                   = &TrustZoneResource{}
-	_ resource.ResourceWithImportState    = &TrustZoneResource{}
-	_ resource.ResourceWithValidateConfig = &TrustZoneResource{}
+	_ resource.Resource                = &TrustZoneResource{}
+	_ resource.ResourceWithImportState = &TrustZoneResource{}
 )
 
 type TrustZoneResource struct {
@@ -51,7 +51,7 @@ func (t *TrustZoneResource) Configure(ctx context.Context, req resource.Configur
 }
 
 func (t *TrustZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan TrustZoneModel
+	var plan TrustZoneResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -72,6 +72,9 @@ func (t *TrustZoneResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	createResp, err := t.client.TrustZoneV1Alpha1().CreateTrustZone(ctx, trustZone)
+	if util.ShouldAdopt(plan.AdoptExisting, err) {
+		createResp, err = adoptTrustZone(ctx, t.client.TrustZoneV1Alpha1(), trustZone)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating trust zone",
@@ -80,25 +83,32 @@ func (t *TrustZoneResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	state := TrustZoneModel{
-		ID:                    tftypes.StringValue(createResp.GetId()),
-		Name:                  tftypes.StringValue(createResp.GetName()),
-		TrustDomain:           tftypes.StringValue(createResp.GetTrustDomain()),
-		OrgID:                 tftypes.StringValue(createResp.GetOrgId()),
-		IsManagementZone:      tftypes.BoolValue(createResp.GetIsManagementZone()),
-		BundleEndpointURL:     tftypes.StringValue(createResp.GetBundleEndpointUrl()),
-		BundleEndpointProfile: tftypes.StringValue(createResp.GetBundleEndpointProfile().String()),
-		JWTIssuer:             tftypes.StringValue(createResp.GetJwtIssuer()),
+	state := TrustZoneResourceModel{
+		TrustZoneModel: TrustZoneModel{
+			ID:                    tftypes.StringValue(createResp.GetId()),
+			Name:                  tftypes.StringValue(createResp.GetName()),
+			TrustDomain:           tftypes.StringValue(createResp.GetTrustDomain()),
+			OrgID:                 tftypes.StringValue(createResp.GetOrgId()),
+			IsManagementZone:      tftypes.BoolValue(createResp.GetIsManagementZone()),
+			BundleEndpointURL:     tftypes.StringValue(createResp.GetBundleEndpointUrl()),
+			BundleEndpointProfile: tftypes.StringValue(createResp.GetBundleEndpointProfile().String()),
+			JWTIssuer:             tftypes.StringValue(createResp.GetJwtIssuer()),
+		},
+		DeletionProtection: plan.DeletionProtection,
+		CascadeDelete:      plan.CascadeDelete,
+		AdoptExisting:      plan.AdoptExisting,
+		RetainOnDelete:     plan.RetainOnDelete,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state.TrustZoneModel))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
 func (t *TrustZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state TrustZoneModel
+	var state TrustZoneResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -119,46 +129,52 @@ func (t *TrustZoneResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	newState := TrustZoneModel{
-		ID:                    tftypes.StringValue(trustZone.GetId()),
-		Name:                  tftypes.StringValue(trustZone.GetName()),
-		TrustDomain:           tftypes.StringValue(trustZone.GetTrustDomain()),
-		OrgID:                 tftypes.StringValue(trustZone.GetOrgId()),
-		IsManagementZone:      tftypes.BoolValue(trustZone.GetIsManagementZone()),
-		BundleEndpointURL:     tftypes.StringValue(trustZone.GetBundleEndpointUrl()),
-		BundleEndpointProfile: tftypes.StringValue(trustZone.GetBundleEndpointProfile().String()),
-		JWTIssuer:             tftypes.StringValue(trustZone.GetJwtIssuer()),
+	newState := TrustZoneResourceModel{
+		TrustZoneModel:     protoToModel(trustZone),
+		DeletionProtection: tftypes.BoolValue(util.DeletionProtected(state.DeletionProtection, deletionProtectionDefault)),
+		CascadeDelete:      tftypes.BoolValue(state.CascadeDelete.ValueBool()),
+		AdoptExisting:      tftypes.BoolValue(state.AdoptExisting.ValueBool()),
+		RetainOnDelete:     tftypes.BoolValue(state.RetainOnDelete.ValueBool()),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState.TrustZoneModel))...)
 }
 
 func (t *TrustZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan TrustZoneModel
+	var plan TrustZoneResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state TrustZoneModel
+	var state TrustZoneResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	trustZoneID := state.ID.ValueString()
 
-	trustZone := &trustzonepb.TrustZone{
-		Id:               &trustZoneID,
-		Name:             plan.Name.ValueString(),
-		TrustDomain:      plan.TrustDomain.ValueString(),
-		IsManagementZone: plan.IsManagementZone.ValueBool(),
+	stateOnly, err := util.IsStateOnlyUpdate(req, "deletion_protection", "cascade_delete", "adopt_existing", "retain_on_delete")
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating trust zone", err.Error())
+		return
+	}
+	if stateOnly {
+		state.DeletionProtection = plan.DeletionProtection
+		state.CascadeDelete = plan.CascadeDelete
+		state.AdoptExisting = plan.AdoptExisting
+		state.RetainOnDelete = plan.RetainOnDelete
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state.TrustZoneModel))...)
+		return
 	}
 
-	if util.IsStringAttributeNonEmpty(plan.OrgID) {
-		trustZone.OrgId = plan.OrgID.ValueStringPointer()
+	trustZone := &trustzonepb.TrustZone{
+		Name:        plan.Name.ValueString(),
+		TrustDomain: plan.TrustDomain.ValueString(),
 	}
 
-	updateResp, err := t.client.TrustZoneV1Alpha1().UpdateTrustZone(ctx, trustZone)
+	updateResp, err := updateTrustZone(ctx, t.client.TrustZoneV1Alpha1(), trustZone, state.TrustZoneModel)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating trust zone", fmt.Sprintf("Could not update trust zone: %s", err.Error()))
 		return
@@ -173,29 +189,75 @@ func (t *TrustZoneResource) Update(ctx context.Context, req resource.UpdateReque
 		orgIDStr = tftypes.StringNull()
 	}
 
-	newState := TrustZoneModel{
-		ID:                    tftypes.StringValue(updateResp.GetId()),
-		Name:                  tftypes.StringValue(updateResp.GetName()),
-		TrustDomain:           tftypes.StringValue(updateResp.GetTrustDomain()),
-		OrgID:                 orgIDStr,
-		IsManagementZone:      tftypes.BoolValue(updateResp.GetIsManagementZone()),
-		BundleEndpointURL:     tftypes.StringValue(updateResp.GetBundleEndpointUrl()),
-		BundleEndpointProfile: tftypes.StringValue(updateResp.GetBundleEndpointProfile().String()),
-		JWTIssuer:             tftypes.StringValue(updateResp.GetJwtIssuer()),
+	newState := TrustZoneResourceModel{
+		TrustZoneModel: TrustZoneModel{
+			ID:                    tftypes.StringValue(updateResp.GetId()),
+			Name:                  tftypes.StringValue(updateResp.GetName()),
+			TrustDomain:           tftypes.StringValue(updateResp.GetTrustDomain()),
+			OrgID:                 orgIDStr,
+			IsManagementZone:      tftypes.BoolValue(updateResp.GetIsManagementZone()),
+			BundleEndpointURL:     tftypes.StringValue(updateResp.GetBundleEndpointUrl()),
+			BundleEndpointProfile: tftypes.StringValue(updateResp.GetBundleEndpointProfile().String()),
+			JWTIssuer:             tftypes.StringValue(updateResp.GetJwtIssuer()),
+		},
+		DeletionProtection: plan.DeletionProtection,
+		CascadeDelete:      plan.CascadeDelete,
+		AdoptExisting:      plan.AdoptExisting,
+		RetainOnDelete:     plan.RetainOnDelete,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(newState.TrustZoneModel))...)
 }
 
 func (t *TrustZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state TrustZoneModel
+	var state TrustZoneResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	err := t.client.TrustZoneV1Alpha1().DestroyTrustZone(ctx, state.ID.ValueString())
+	if state.RetainOnDelete.ValueBool() {
+		resp.Diagnostics.Append(util.RetainedWarning("trust zone", state.Name.ValueString()))
+		return
+	}
+
+	if util.DeletionProtected(state.DeletionProtection, deletionProtectionDefault) {
+		resp.Diagnostics.Append(util.DeletionProtectionError("trust zone", state.Name.ValueString()))
+		return
+	}
+
+	trustZoneID := state.ID.ValueString()
+
+	dependents, err := listDependents(ctx, newDependentClients(t.client), trustZoneID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting trust zone",
+			fmt.Sprintf("Could not find the objects referencing trust zone %q: %s", state.Name.ValueString(), err),
+		)
+		return
+	}
+
+	if len(dependents) > 0 {
+		if !state.CascadeDelete.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Cannot delete trust zone in use",
+				fmt.Sprintf("The trust zone %q is still referenced by:%s\n\nDelete these first, or set cascade_delete to true and apply the change to delete them with the trust zone.", state.Name.ValueString(), describeDependents(dependents)),
+			)
+			return
+		}
+
+		if err := deleteDependents(ctx, dependents); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting trust zone",
+				fmt.Sprintf("Could not delete the objects referencing trust zone %q: %s", state.Name.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	err = t.client.TrustZoneV1Alpha1().DestroyTrustZone(ctx, trustZoneID)
 	if err != nil {
 		if status.Code(err) != codes.NotFound {
 			resp.Diagnostics.AddError(
@@ -209,21 +271,14 @@ func (t *TrustZoneResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (t *TrustZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
-
-func (t *TrustZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data TrustZoneModel
-
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
+	if req.ID != "" {
+		id, err := importid.TrustZone(ctx, t.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing trust zone", err.Error())
+			return
+		}
+		req.ID = id
 	}
 
-	if !data.IsManagementZone.IsNull() {
-		resp.Diagnostics.AddWarning(
-			"is_management_zone is immutable",
-			"The is_management_zone field cannot be modified after creation. Create a new trust zone instead.",
-		)
-	}
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -4,17 +4,27 @@ import (
 	"context"
 
 	"github.com/cofide/terraform-provider-cofide/internal/planmodifiers"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 )
 
-var _ resource.ResourceWithConfigValidators = (*TrustZoneResource)(nil)
+var (
+	_ resource.ResourceWithConfigValidators = (*TrustZoneResource)(nil)
+	_ resource.ResourceWithUpgradeState     = (*TrustZoneResource)(nil)
+)
+
+// deletionProtectionDefault is the default of deletion_protection. Deleting a
+// trust zone takes down the identity of every workload in it.
+const deletionProtectionDefault = true
 
 func ResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Manages a Cofide Connect trust zone. A trust zone contains a SPIFFE trust domain.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -29,12 +39,13 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 				Required:    true,
 			},
 			"org_id": schema.StringAttribute{
-				Description: "The ID of the organization.",
+				Description: "The ID of the organization. Cannot be changed after creation.",
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					planmodifiers.OptionalComputedModifier{},
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"trust_domain": schema.StringAttribute{
@@ -48,6 +59,7 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 				PlanModifiers: []planmodifier.Bool{
 					planmodifiers.OptionalComputedModifier{},
 					boolplanmodifier.UseStateForUnknown(),
+					boolplanmodifier.RequiresReplace(),
 				},
 			},
 			"bundle_endpoint_url": schema.StringAttribute{
@@ -71,6 +83,15 @@ func ResourceSchema(ctx context.Context) schema.Schema {
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": util.DeletionProtectionAttribute("trust zone", deletionProtectionDefault),
+			"adopt_existing":      util.AdoptExistingAttribute("trust zone", "name and organization"),
+			"retain_on_delete":    util.RetainOnDeleteAttribute("trust zone"),
+			"cascade_delete": schema.BoolAttribute{
+				Description: "Whether deleting the trust zone first deletes the objects that reference it: federations to or from it, attestation policy bindings and exchange policies, in that order. Trust zone servers and clusters are never deleted this way, as their own `deletion_protection` and `retain_on_delete` cannot be checked; deleting a trust zone that they still reference fails and lists them. Otherwise deleting a trust zone that is still referenced fails and lists the references. Changing it does not change the trust zone in Cofide Connect. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 		},
 	}
 }
@@ -79,6 +100,13 @@ func (t *TrustZoneResource) Schema(ctx context.Context, req resource.SchemaReque
 	resp.Schema = ResourceSchema(ctx)
 }
 
+func (t *TrustZoneResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 1 made is_management_zone and org_id force replacement.
+		0: util.UnchangedStateUpgrader(ResourceSchema(ctx)),
+	}
+}
+
 func (t *TrustZoneResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{}
 }
@@ -0,0 +1,20 @@
+package trustzone
+
+import (
+	"context"
+
+	trustzonepb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+)
+
+// updateTrustZone updates the trust zone in state to trustZone. The fields
+// that cannot be changed after creation are sent as they are in state, as a
+// change to them replaces the trust zone rather than updating it.
+func updateTrustZone(ctx context.Context, client trustZoneClient, trustZone *trustzonepb.TrustZone, state TrustZoneModel) (*trustzonepb.TrustZone, error) {
+	trustZone.Id = state.ID.ValueStringPointer()
+	trustZone.IsManagementZone = state.IsManagementZone.ValueBool()
+	if util.IsStringAttributeNonEmpty(state.OrgID) {
+		trustZone.OrgId = state.OrgID.ValueStringPointer()
+	}
+	return client.UpdateTrustZone(ctx, trustZone)
+}
@@ -0,0 +1,36 @@
+package trustzone
+
+import (
+	"context"
+	"testing"
+
+	trustzonepb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateTrustZoneKeepsImmutableFields(t *testing.T) {
+	client := &fakeTrustZoneClient{}
+	state := TrustZoneModel{
+		ID:               tftypes.StringValue("tz-1"),
+		OrgID:            tftypes.StringValue("org-1"),
+		Name:             tftypes.StringValue("prod"),
+		TrustDomain:      tftypes.StringValue("prod.example.com"),
+		IsManagementZone: tftypes.BoolValue(true),
+	}
+
+	_, err := updateTrustZone(context.Background(), client, &trustzonepb.TrustZone{
+		OrgId:       ptr("org-2"),
+		Name:        "renamed",
+		TrustDomain: "prod.example.com",
+	}, state)
+	require.NoError(t, err)
+
+	require.Len(t, client.updated, 1)
+	updated := client.updated[0]
+	assert.Equal(t, "tz-1", updated.GetId())
+	assert.Equal(t, "renamed", updated.GetName())
+	assert.Equal(t, "org-1", updated.GetOrgId())
+	assert.True(t, updated.GetIsManagementZone())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 )
 
@@ -56,11 +57,11 @@ func (d *TrustZoneServerDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	helmValues := helmValuesFromProto(server.GetHelmValues())
-	state, diags := trustZoneServerFromProto(server, helmValues)
+	state, diags := trustZoneServerFromProto(server)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.HelmValuesObject = util.HelmValuesObjectFromProto(server.GetHelmValues(), util.NewHelmValuesObjectNull())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
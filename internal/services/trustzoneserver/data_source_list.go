@@ -75,17 +75,16 @@ func (d *TrustZoneServersDataSource) Read(ctx context.Context, req datasource.Re
 	}
 
 	for _, server := range servers {
-		helmValues := helmValuesFromProto(server.GetHelmValues())
-		serverModel, diags := trustZoneServerFromProto(server, helmValues)
+		serverModel, diags := trustZoneServerFromProto(server)
 		resp.Diagnostics.Append(diags...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		state.TrustZoneServers = append(state.TrustZoneServers, serverModel)
+		state.TrustZoneServers = append(state.TrustZoneServers, listItemFromModel(serverModel))
 	}
 
 	if state.TrustZoneServers == nil {
-		state.TrustZoneServers = []TrustZoneServerModel{}
+		state.TrustZoneServers = []TrustZoneServerListItemModel{}
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -3,6 +3,7 @@ package trustzoneserver
 import (
 	"context"
 
+	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
@@ -40,8 +41,21 @@ func DataSourceSchema(_ context.Context) schema.Schema {
 				Computed:    true,
 			},
 			"helm_values": schema.StringAttribute{
-				Description: "Helm values configured for the server install (JSON).",
+				Description: "Helm values configured for the server install (JSON). Sensitive, as the values include any set through the resource's write-only `sensitive_helm_values`.",
 				Computed:    true,
+				Sensitive:   true,
+				CustomType:  util.HelmValuesType{},
+			},
+			"helm_values_object": schema.DynamicAttribute{
+				Description: "Helm values configured for the server install, as an object. Sensitive, like `helm_values`.",
+				Computed:    true,
+				Sensitive:   true,
+				CustomType:  util.HelmValuesObjectType{},
+			},
+			"helm_values_merged": schema.StringAttribute{
+				Description: "Helm values configured for the server install, as a JSON document. Sensitive, like `helm_values`.",
+				Computed:    true,
+				Sensitive:   true,
 			},
 			"status": schema.SingleNestedAttribute{
 				Description: "The current lifecycle status of the trust zone server.",
@@ -126,8 +140,15 @@ func ListDataSourceSchema(_ context.Context) schema.Schema {
 							Computed:    true,
 						},
 						"helm_values": schema.StringAttribute{
-							Description: "Helm values configured for the server install (JSON).",
+							Description: "Helm values configured for the server install (JSON). Sensitive, as the values include any set through the resource's write-only `sensitive_helm_values`.",
+							Computed:    true,
+							Sensitive:   true,
+							CustomType:  util.HelmValuesType{},
+						},
+						"helm_values_merged": schema.StringAttribute{
+							Description: "Helm values configured for the server install, as a JSON document. Sensitive, like `helm_values`.",
 							Computed:    true,
+							Sensitive:   true,
 						},
 						"status": schema.SingleNestedAttribute{
 							Description: "The current lifecycle status of the trust zone server.",
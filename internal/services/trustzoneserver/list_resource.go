@@ -0,0 +1,96 @@
+package trustzoneserver
+
+import (
+	"context"
+	"fmt"
+
+	trustzoneserversvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_server_service/v1alpha1"
+	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ list.ListResource              = &TrustZoneServerResource{}
+	_ list.ListResourceWithConfigure = &TrustZoneServerResource{}
+)
+
+// NewListResource returns the list resource for trust zone servers, which lets
+// `terraform query` enumerate existing trust zone servers for import.
+func NewListResource() list.ListResource {
+	return &TrustZoneServerResource{}
+}
+
+func ListResourceSchema(_ context.Context) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists Cofide Connect trust zone servers.",
+		Attributes: map[string]listschema.Attribute{
+			"org_id": listschema.StringAttribute{
+				Description: "Filter by organization ID.",
+				Optional:    true,
+			},
+			"trust_zone_id": listschema.StringAttribute{
+				Description: "Filter by trust zone ID.",
+				Optional:    true,
+			},
+			"cluster_id": listschema.StringAttribute{
+				Description: "Filter by cluster ID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *TrustZoneServerResource) ListResourceConfigSchema(ctx context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = ListResourceSchema(ctx)
+}
+
+func (r *TrustZoneServerResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config TrustZoneServerListModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	filter := &trustzoneserversvcpb.ListTrustZoneServersRequest_Filter{
+		OrgId:       config.OrgID.ValueString(),
+		TrustZoneId: config.TrustZoneID.ValueString(),
+		ClusterId:   config.ClusterID.ValueString(),
+	}
+	servers, err := r.client.TrustZoneServerV1Alpha1().ListTrustZoneServers(ctx, filter)
+	if err != nil {
+		diags.AddError(
+			"Error listing trust zone servers",
+			fmt.Sprintf("Could not list trust zone servers: %s", err),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = util.ListResults(ctx, req, servers, func(server *trustzoneserverpb.TrustZoneServer) (util.ListResult, diag.Diagnostics) {
+		model, diags := trustZoneServerFromProto(server)
+		if diags.HasError() {
+			return util.ListResult{}, diags
+		}
+		// As on import, the Helm values are left out, since sensitive values
+		// merged into them cannot be told apart from the rest.
+		model.HelmValues = util.NewHelmValuesNull()
+		model.HelmValuesMerged = tftypes.StringNull()
+		return util.ListResult{
+			DisplayName: server.GetId(),
+			Resource: TrustZoneServerResourceModel{
+				TrustZoneServerModel: model,
+				DeletionProtection:   tftypes.BoolValue(deletionProtectionDefault),
+				RetainOnDelete:       tftypes.BoolValue(false),
+				ValidateHelmValues:   tftypes.BoolValue(true),
+				HelmValuesLayers:     tftypes.ListNull(util.HelmValuesType{}),
+			},
+			Identity: newIdentityModel(model),
+		}, diags
+	})
+}
@@ -1,6 +1,10 @@
 package trustzoneserver
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/structpb"
+)
 
 type TrustZoneServerModel struct {
 	ID                       types.String               `tfsdk:"id"`
@@ -9,11 +13,74 @@ type TrustZoneServerModel struct {
 	KubernetesNamespace      types.String               `tfsdk:"kubernetes_namespace"`
 	KubernetesServiceAccount types.String               `tfsdk:"kubernetes_service_account"`
 	OrgID                    types.String               `tfsdk:"org_id"`
-	HelmValues               types.String               `tfsdk:"helm_values"`
+	HelmValues               util.HelmValues            `tfsdk:"helm_values"`
+	HelmValuesObject         util.HelmValuesObject      `tfsdk:"helm_values_object"`
+	HelmValuesMerged         types.String               `tfsdk:"helm_values_merged"`
 	Status                   types.Object               `tfsdk:"status"`
 	ConnectK8sPsatConfig     *ConnectK8sPsatConfigModel `tfsdk:"connect_k8s_psat_config"`
 }
 
+// TrustZoneServerResourceModel is the model of the trust zone server
+// resource, which adds attributes kept in Terraform state only to those
+// shared with the data sources.
+type TrustZoneServerResourceModel struct {
+	TrustZoneServerModel
+	DeletionProtection types.Bool          `tfsdk:"deletion_protection"`
+	WaitForStatus      *WaitForStatusModel `tfsdk:"wait_for_status"`
+	RetainOnDelete     types.Bool          `tfsdk:"retain_on_delete"`
+	ValidateHelmValues types.Bool          `tfsdk:"validate_helm_values"`
+
+	// HelmValuesLayers is kept in state only, as the API returns the merged
+	// values.
+	HelmValuesLayers types.List `tfsdk:"helm_values_layers"`
+
+	// SensitiveHelmValues is write-only, so always null outside the
+	// configuration.
+	SensitiveHelmValues        util.HelmValues `tfsdk:"sensitive_helm_values"`
+	SensitiveHelmValuesVersion types.Int64     `tfsdk:"sensitive_helm_values_version"`
+}
+
+// helmValuesForms returns the forms the Helm values may be configured in.
+func (m TrustZoneServerResourceModel) helmValuesForms() util.HelmValuesForms {
+	return util.HelmValuesForms{
+		Document: m.HelmValues,
+		Object:   m.HelmValuesObject,
+		Layers:   m.HelmValuesLayers,
+	}
+}
+
+// setHelmValues sets the Helm values returned by the API, in the form they
+// were configured in according to prior. The sensitive values at
+// sensitivePaths are left out.
+func (m *TrustZoneServerResourceModel) setHelmValues(values *structpb.Struct, prior util.HelmValuesForms, sensitivePaths [][]string) error {
+	values, err := util.WithoutSensitiveHelmValues(values, sensitivePaths, prior)
+	if err != nil {
+		return err
+	}
+	forms, err := util.HelmValuesFormsFromProto(values, prior)
+	if err != nil {
+		return err
+	}
+	merged, err := util.HelmValuesJSON(values)
+	if err != nil {
+		return err
+	}
+
+	m.HelmValues = forms.Document
+	m.HelmValuesObject = forms.Object
+	m.HelmValuesLayers = forms.Layers
+	m.HelmValuesMerged = merged
+	return nil
+}
+
+// WaitForStatusModel configures waiting for a trust zone server to reach a
+// status after it is created or updated.
+type WaitForStatusModel struct {
+	Status       types.String `tfsdk:"status"`
+	PollInterval types.String `tfsdk:"poll_interval"`
+	Timeout      types.String `tfsdk:"timeout"`
+}
+
 type TrustZoneServerStatusModel struct {
 	Status             types.String `tfsdk:"status"`
 	LastTransitionTime types.String `tfsdk:"last_transition_time"`
@@ -25,8 +92,46 @@ type ConnectK8sPsatConfigModel struct {
 }
 
 type TrustZoneServersDataSourceModel struct {
-	TrustZoneID      types.String           `tfsdk:"trust_zone_id"`
-	ClusterID        types.String           `tfsdk:"cluster_id"`
-	OrgID            types.String           `tfsdk:"org_id"`
-	TrustZoneServers []TrustZoneServerModel `tfsdk:"trust_zone_servers"`
+	TrustZoneID      types.String                   `tfsdk:"trust_zone_id"`
+	ClusterID        types.String                   `tfsdk:"cluster_id"`
+	OrgID            types.String                   `tfsdk:"org_id"`
+	TrustZoneServers []TrustZoneServerListItemModel `tfsdk:"trust_zone_servers"`
+}
+
+// TrustZoneServerListItemModel is a trust zone server in the list data
+// source. It omits helm_values_object, as the framework does not support
+// dynamic attributes nested in lists.
+type TrustZoneServerListItemModel struct {
+	ID                       types.String               `tfsdk:"id"`
+	TrustZoneID              types.String               `tfsdk:"trust_zone_id"`
+	ClusterID                types.String               `tfsdk:"cluster_id"`
+	KubernetesNamespace      types.String               `tfsdk:"kubernetes_namespace"`
+	KubernetesServiceAccount types.String               `tfsdk:"kubernetes_service_account"`
+	OrgID                    types.String               `tfsdk:"org_id"`
+	HelmValues               util.HelmValues            `tfsdk:"helm_values"`
+	HelmValuesMerged         types.String               `tfsdk:"helm_values_merged"`
+	Status                   types.Object               `tfsdk:"status"`
+	ConnectK8sPsatConfig     *ConnectK8sPsatConfigModel `tfsdk:"connect_k8s_psat_config"`
+}
+
+func listItemFromModel(m TrustZoneServerModel) TrustZoneServerListItemModel {
+	return TrustZoneServerListItemModel{
+		ID:                       m.ID,
+		TrustZoneID:              m.TrustZoneID,
+		ClusterID:                m.ClusterID,
+		KubernetesNamespace:      m.KubernetesNamespace,
+		KubernetesServiceAccount: m.KubernetesServiceAccount,
+		OrgID:                    m.OrgID,
+		HelmValues:               m.HelmValues,
+		HelmValuesMerged:         m.HelmValuesMerged,
+		Status:                   m.Status,
+		ConnectK8sPsatConfig:     m.ConnectK8sPsatConfig,
+	}
+}
+
+// TrustZoneServerListModel is the configuration of the trust zone server list resource.
+type TrustZoneServerListModel struct {
+	OrgID       types.String `tfsdk:"org_id"`
+	TrustZoneID types.String `tfsdk:"trust_zone_id"`
+	ClusterID   types.String `tfsdk:"cluster_id"`
 }
@@ -0,0 +1,97 @@
+package trustzoneserver_test
+
+import (
+	"testing"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const resourceType = "cofide_connect_trust_zone_server"
+
+// serverAttributes returns the attributes of a trust zone server, overridden
+// by overrides.
+func serverAttributes(overrides map[string]any) map[string]any {
+	attributes := map[string]any{"trust_zone_id": "tz-1", "cluster_id": "c-1"}
+	for name, value := range overrides {
+		attributes[name] = value
+	}
+	return attributes
+}
+
+// TestValidateWaitForStatus checks that the status to wait for must be a
+// trust zone server status, given with or without its prefix.
+func TestValidateWaitForStatus(t *testing.T) {
+	waitFor := func(status string) map[string]any {
+		return serverAttributes(map[string]any{"wait_for_status": map[string]any{"status": status}})
+	}
+
+	providertest.RunValidateTests(t, resourceType, []providertest.ValidateTest{
+		{
+			Name:   "without prefix",
+			Config: waitFor("provisioned"),
+		},
+		{
+			Name:        "misspelled",
+			Config:      waitFor("PROVISONED"),
+			WantSummary: "Invalid status",
+			WantDetail:  `"PROVISONED" is not a trust zone server status`,
+		},
+	})
+}
+
+// TestValidateHelmValues checks that Helm values configured in one form
+// conflict with the others, and that values must be accepted by the chart's
+// values schema.
+func TestValidateHelmValues(t *testing.T) {
+	replicaCountValues := func(replicaCount tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(
+			tftypes.Object{AttributeTypes: map[string]tftypes.Type{"replicaCount": replicaCount.Type()}},
+			map[string]tftypes.Value{"replicaCount": replicaCount},
+		)
+	}
+	replicas := replicaCountValues(tftypes.NewValue(tftypes.Number, 2))
+
+	providertest.RunValidateTests(t, resourceType, []providertest.ValidateTest{
+		{
+			Name:   "object",
+			Config: serverAttributes(map[string]any{"helm_values_object": replicas}),
+		},
+		{
+			Name:        "object of the wrong type",
+			Config:      serverAttributes(map[string]any{"helm_values_object": replicaCountValues(tftypes.NewValue(tftypes.String, "two"))}),
+			WantSummary: "Invalid Helm value",
+			WantDetail:  "replicaCount: expected integer, got string",
+		},
+		{
+			Name:        "layers",
+			Config:      serverAttributes(map[string]any{"helm_values_layers": []any{"logLevel: info", "logLevel: verbose"}}),
+			WantSummary: "Invalid Helm value",
+			WantDetail:  "logLevel: must be one of",
+		},
+		{
+			Name:        "sensitive wrong type",
+			Config:      serverAttributes(map[string]any{"sensitive_helm_values": "replicaCount: two", "sensitive_helm_values_version": 1}),
+			WantSummary: "Invalid Helm value",
+			WantDetail:  "replicaCount: expected integer, got string",
+		},
+		{
+			Name:        "both forms",
+			Config:      serverAttributes(map[string]any{"helm_values": "replicaCount: 2", "helm_values_object": replicas}),
+			WantSummary: "Invalid Attribute Combination",
+		},
+		{
+			Name:        "object and layers",
+			Config:      serverAttributes(map[string]any{"helm_values_layers": []any{"replicaCount: 2"}, "helm_values_object": replicas}),
+			WantSummary: "Invalid Attribute Combination",
+		},
+	})
+}
+
+// TestPlanHelmValuesMerged checks that the merged Helm values are planned
+// from layers merged the way Helm merges values files.
+func TestPlanHelmValuesMerged(t *testing.T) {
+	planned := providertest.PlanCreate(t, providertest.NewServer(t), resourceType, serverAttributes(map[string]any{"helm_values_layers": []any{"replicas: 1", "replicas: 3"}}))
+	assert.Equal(t, `{"replicas":3}`, providertest.String(t, planned, "helm_values_merged"))
+}
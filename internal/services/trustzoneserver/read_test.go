@@ -0,0 +1,69 @@
+package trustzoneserver_test
+
+import (
+	"testing"
+
+	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/cofide/terraform-provider-cofide/internal/providertest"
+)
+
+// secret is a value set through sensitive_helm_values, which the API returns
+// merged into the Helm values.
+const secret = "hunter2"
+
+func newClient(t *testing.T) *providertest.Client {
+	t.Helper()
+
+	values, err := structpb.NewStruct(map[string]any{
+		"spire-server": map[string]any{"logLevel": "info"},
+		"datastore":    map[string]any{"password": secret},
+	})
+	require.NoError(t, err)
+	return &providertest.Client{TrustZoneServers: []*trustzoneserverpb.TrustZoneServer{{
+		Id:          "tzs-1",
+		TrustZoneId: "tz-1",
+		ClusterId:   "c-1",
+		OrgId:       "org-1",
+		HelmValues:  values,
+	}}}
+}
+
+// TestImportWithholdsHelmValues checks that the Helm values of an imported
+// trust zone server are not stored in state, as any of them may be
+// sensitive.
+func TestImportWithholdsHelmValues(t *testing.T) {
+	server := providertest.NewServerWithClient(t, newClient(t))
+
+	state := providertest.ImportAndRead(t, server, resourceType, "tzs-1")
+	providertest.AssertNotExposed(t, server.ResourceSchema(t, resourceType).Block, state, secret)
+	assert.Equal(t, "c-1", providertest.String(t, state, "cluster_id"))
+}
+
+// TestListWithholdsHelmValues checks that the trust zone servers listed by
+// `terraform query`, and so exported, have no Helm values.
+func TestListWithholdsHelmValues(t *testing.T) {
+	server := providertest.NewServerWithClient(t, newClient(t))
+
+	resources := providertest.List(t, server, resourceType, map[string]any{})
+	require.Len(t, resources, 1)
+	providertest.AssertNotExposed(t, server.ResourceSchema(t, resourceType).Block, resources[0], secret)
+}
+
+// TestDataSourcesHelmValuesSensitive checks that the data sources return the
+// Helm values only in attributes marked sensitive.
+func TestDataSourcesHelmValuesSensitive(t *testing.T) {
+	server := providertest.NewServerWithClient(t, newClient(t))
+
+	state := providertest.ReadDataSource(t, server, resourceType, map[string]any{"id": "tzs-1"})
+	providertest.AssertNotExposed(t, server.DataSourceSchema(t, resourceType).Block, state, secret)
+	assert.Contains(t, providertest.String(t, state, "helm_values_merged"), secret)
+
+	listType := resourceType + "s"
+	state = providertest.ReadDataSource(t, server, listType, map[string]any{})
+	providertest.AssertNotExposed(t, server.DataSourceSchema(t, listType).Block, state, secret)
+	assert.Contains(t, state.String(), secret)
+}
@@ -7,6 +7,8 @@ import (
 	trustzoneserversvcpb "github.com/cofide/cofide-api-sdk/gen/go/proto/connect/trust_zone_server_service/v1alpha1"
 	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
 	sdkclient "github.com/cofide/cofide-api-sdk/pkg/connect/client"
+	"github.com/cofide/terraform-provider-cofide/internal/helmschema"
+	"github.com/cofide/terraform-provider-cofide/internal/importid"
 	"github.com/cofide/terraform-provider-cofide/internal/util"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -59,7 +61,7 @@ func (r *TrustZoneServerResource) Configure(_ context.Context, req resource.Conf
 }
 
 func (r *TrustZoneServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan TrustZoneServerModel
+	var plan TrustZoneServerResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -91,6 +93,45 @@ func (r *TrustZoneServerResource) Create(ctx context.Context, req resource.Creat
 		server.HelmValues = helmValues
 	}
 
+	if !plan.HelmValuesObject.IsNull() {
+		helmValues, err := util.HelmValuesObjectToProto(plan.HelmValuesObject)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing helm_values_object",
+				fmt.Sprintf("Failed to parse helm_values_object: %s", err),
+			)
+			return
+		}
+		server.HelmValues = helmValues
+	}
+
+	if !plan.HelmValuesLayers.IsNull() {
+		helmValues, err := util.HelmValuesLayersToProto(plan.HelmValuesLayers)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing helm_values_layers",
+				fmt.Sprintf("Failed to parse helm_values_layers: %s", err),
+			)
+			return
+		}
+		server.HelmValues = helmValues
+	}
+
+	var sensitiveHelmValues util.HelmValues
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_helm_values"), &sensitiveHelmValues)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	helmValues, sensitivePaths, err := util.MergeSensitiveHelmValues(server.HelmValues, sensitiveHelmValues)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing sensitive_helm_values",
+			fmt.Sprintf("Failed to parse sensitive_helm_values: %s", err),
+		)
+		return
+	}
+	server.HelmValues = helmValues
+
 	if plan.ConnectK8sPsatConfig != nil {
 		cfg, cfgDiags := connectK8sPsatConfigToProto(ctx, plan.ConnectK8sPsatConfig)
 		resp.Diagnostics.Append(cfgDiags...)
@@ -109,16 +150,35 @@ func (r *TrustZoneServerResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	state, diags := trustZoneServerFromProto(createResp, plan.HelmValues)
+	createResp, waitErr := r.waitForStatus(ctx, createResp, plan.WaitForStatus)
+
+	model, diags := trustZoneServerFromProto(createResp)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state := TrustZoneServerResourceModel{
+		TrustZoneServerModel: model,
+		DeletionProtection:   plan.DeletionProtection,
+		WaitForStatus:        plan.WaitForStatus,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ValidateHelmValues:   plan.ValidateHelmValues,
+	}
+	state.SensitiveHelmValuesVersion = plan.SensitiveHelmValuesVersion
+	if err := state.setHelmValues(createResp.GetHelmValues(), plan.helmValuesForms(), sensitivePaths); err != nil {
+		resp.Diagnostics.AddError("Error processing trust zone server data", fmt.Sprintf("Could not process helm_values: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(util.SetSensitiveHelmValuesPaths(ctx, resp.Private, sensitivePaths)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(model))...)
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Error waiting for trust zone server", waitErr.Error())
+	}
 }
 
 func (r *TrustZoneServerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state TrustZoneServerModel
+	var state TrustZoneServerResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
@@ -139,35 +199,67 @@ func (r *TrustZoneServerResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	helmValues, err := util.HelmValuesForState(server.GetHelmValues(), state.HelmValues)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error processing trust zone server data",
-			fmt.Sprintf("Could not process helm_values: %s", err),
-		)
+	model, diags := trustZoneServerFromProto(server)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	newState, diags := trustZoneServerFromProto(server, helmValues)
+	newState := TrustZoneServerResourceModel{
+		TrustZoneServerModel: model,
+		DeletionProtection:   tftypes.BoolValue(util.DeletionProtected(state.DeletionProtection, deletionProtectionDefault)),
+		WaitForStatus:        state.WaitForStatus,
+		RetainOnDelete:       tftypes.BoolValue(state.RetainOnDelete.ValueBool()),
+		ValidateHelmValues:   tftypes.BoolValue(helmschema.Enabled(state.ValidateHelmValues)),
+	}
+	newState.SensitiveHelmValuesVersion = state.SensitiveHelmValuesVersion
+	sensitivePaths, diags := util.SensitiveHelmValuesPaths(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	imported, diags := util.HelmValuesImported(ctx, req.Private)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	helmValues := server.GetHelmValues()
+	if imported {
+		// Any of the imported values may have been sensitive.
+		helmValues = nil
+	}
+	if err := newState.setHelmValues(helmValues, state.helmValuesForms(), sensitivePaths); err != nil {
+		resp.Diagnostics.AddError("Error processing trust zone server data", fmt.Sprintf("Could not process helm_values: %s", err))
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(model))...)
 }
 
 func (r *TrustZoneServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan TrustZoneServerModel
+	var plan TrustZoneServerResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var state TrustZoneServerModel
+	var state TrustZoneServerResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	stateOnly, err := util.IsStateOnlyUpdate(req, "deletion_protection", "wait_for_status", "retain_on_delete", "validate_helm_values")
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating trust zone server", err.Error())
+		return
+	}
+	if stateOnly {
+		state.DeletionProtection = plan.DeletionProtection
+		state.WaitForStatus = plan.WaitForStatus
+		state.RetainOnDelete = plan.RetainOnDelete
+		state.ValidateHelmValues = plan.ValidateHelmValues
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(state.TrustZoneServerModel))...)
+		return
+	}
+
 	serverID := state.ID.ValueString()
 	server := &trustzoneserverpb.TrustZoneServer{
 		Id:          serverID,
@@ -187,6 +279,45 @@ func (r *TrustZoneServerResource) Update(ctx context.Context, req resource.Updat
 		server.HelmValues = helmValues
 	}
 
+	if !plan.HelmValuesObject.IsNull() {
+		helmValues, err := util.HelmValuesObjectToProto(plan.HelmValuesObject)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing helm_values_object",
+				fmt.Sprintf("Failed to parse helm_values_object: %s", err),
+			)
+			return
+		}
+		server.HelmValues = helmValues
+	}
+
+	if !plan.HelmValuesLayers.IsNull() {
+		helmValues, err := util.HelmValuesLayersToProto(plan.HelmValuesLayers)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing helm_values_layers",
+				fmt.Sprintf("Failed to parse helm_values_layers: %s", err),
+			)
+			return
+		}
+		server.HelmValues = helmValues
+	}
+
+	var sensitiveHelmValues util.HelmValues
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_helm_values"), &sensitiveHelmValues)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	helmValues, sensitivePaths, err := util.MergeSensitiveHelmValues(server.HelmValues, sensitiveHelmValues)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing sensitive_helm_values",
+			fmt.Sprintf("Failed to parse sensitive_helm_values: %s", err),
+		)
+		return
+	}
+	server.HelmValues = helmValues
+
 	if plan.ConnectK8sPsatConfig != nil {
 		cfg, cfgDiags := connectK8sPsatConfigToProto(ctx, plan.ConnectK8sPsatConfig)
 		resp.Diagnostics.Append(cfgDiags...)
@@ -205,12 +336,47 @@ func (r *TrustZoneServerResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	newState, diags := trustZoneServerFromProto(updateResp, plan.HelmValues)
+	updateResp, waitErr := r.waitForStatus(ctx, updateResp, plan.WaitForStatus)
+
+	model, diags := trustZoneServerFromProto(updateResp)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	newState := TrustZoneServerResourceModel{
+		TrustZoneServerModel: model,
+		DeletionProtection:   plan.DeletionProtection,
+		WaitForStatus:        plan.WaitForStatus,
+		RetainOnDelete:       plan.RetainOnDelete,
+		ValidateHelmValues:   plan.ValidateHelmValues,
+	}
+	newState.SensitiveHelmValuesVersion = plan.SensitiveHelmValuesVersion
+	if err := newState.setHelmValues(updateResp.GetHelmValues(), plan.helmValuesForms(), sensitivePaths); err != nil {
+		resp.Diagnostics.AddError("Error processing trust zone server data", fmt.Sprintf("Could not process helm_values: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(util.SetSensitiveHelmValuesPaths(ctx, resp.Private, sensitivePaths)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newIdentityModel(model))...)
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Error waiting for trust zone server", waitErr.Error())
+	}
+}
+
+// waitForStatus waits for server to reach the status configured by
+// wait_for_status, if any. It returns the server as last read, so that state
+// records its final status even if waiting fails.
+func (r *TrustZoneServerResource) waitForStatus(ctx context.Context, server *trustzoneserverpb.TrustZoneServer, model *WaitForStatusModel) (*trustzoneserverpb.TrustZoneServer, error) {
+	opts, ok, err := newWaitOptions(model)
+	if err != nil || !ok {
+		return server, err
+	}
+
+	latest, err := waitForStatus(ctx, r.client.TrustZoneServerV1Alpha1(), server.GetId(), opts)
+	if latest == nil {
+		latest = server
+	}
+	return latest, err
 }
 
 // newUpdateMask builds the update mask covering every field the resource can
@@ -227,13 +393,23 @@ func newUpdateMask() *trustzoneserversvcpb.UpdateTrustZoneServerRequest_UpdateMa
 }
 
 func (r *TrustZoneServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state TrustZoneServerModel
+	var state TrustZoneServerResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if state.RetainOnDelete.ValueBool() {
+		resp.Diagnostics.Append(util.RetainedWarning("trust zone server", state.ID.ValueString()))
+		return
+	}
+
+	if util.DeletionProtected(state.DeletionProtection, deletionProtectionDefault) {
+		resp.Diagnostics.Append(util.DeletionProtectionError("trust zone server", state.ID.ValueString()))
+		return
+	}
+
 	err := r.client.TrustZoneServerV1Alpha1().DestroyTrustZoneServer(ctx, state.ID.ValueString())
 	if err != nil {
 		if status.Code(err) != codes.NotFound {
@@ -247,27 +423,81 @@ func (r *TrustZoneServerResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *TrustZoneServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID != "" {
+		id, err := importid.TrustZoneServer(ctx, r.client, req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Error importing trust zone server", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+	resp.Diagnostics.Append(util.SetHelmValuesImported(ctx, resp.Private)...)
 }
 
 func (r *TrustZoneServerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data TrustZoneServerModel
+	var data TrustZoneServerResourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if data.WaitForStatus != nil {
+		if status := data.WaitForStatus.Status; !status.IsNull() && !status.IsUnknown() {
+			if err := validateStatus(status.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("wait_for_status").AtName("status"), "Invalid status", err.Error())
+			}
+		}
+		if _, err := parseDuration(data.WaitForStatus.PollInterval, defaultPollInterval); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("wait_for_status").AtName("poll_interval"), "Invalid poll interval", err.Error())
+		}
+		if _, err := parseDuration(data.WaitForStatus.Timeout, defaultWaitTimeout); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("wait_for_status").AtName("timeout"), "Invalid timeout", err.Error())
+		}
+	}
+
+	if helmschema.Enabled(data.ValidateHelmValues) {
+		resp.Diagnostics.Append(helmschema.ValidateForms(helmschema.SPIREServerChart, data.helmValuesForms(), helmschema.Attributes{
+			Document: path.Root("helm_values"),
+			Object:   path.Root("helm_values_object"),
+			Layers:   path.Root("helm_values_layers"),
+		})...)
+
+		sensitive := util.NewHelmValuesFormsNull()
+		sensitive.Document = data.SensitiveHelmValues
+		resp.Diagnostics.Append(helmschema.ValidateForms(helmschema.SPIREServerChart, sensitive, helmschema.Attributes{
+			Document: path.Root("sensitive_helm_values"),
+		})...)
+	}
 }
 
 // trustZoneServerFromProto converts a TrustZoneServer proto to a TrustZoneServerModel.
-// helmValues is passed separately to preserve the original YAML/JSON format from the plan.
-func trustZoneServerFromProto(server *trustzoneserverpb.TrustZoneServer, helmValues tftypes.String) (TrustZoneServerModel, diag.Diagnostics) {
+// Helm values are rendered as JSON in helm_values; the resource moves them to
+// the form they were configured in.
+func trustZoneServerFromProto(server *trustzoneserverpb.TrustZoneServer) (TrustZoneServerModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	helmValues, err := util.HelmValuesFromProto(server.GetHelmValues(), util.NewHelmValuesNull())
+	if err != nil {
+		diags.AddError("Error processing trust zone server data", fmt.Sprintf("Could not process helm_values: %s", err))
+		return TrustZoneServerModel{}, diags
+	}
+	helmValuesMerged, err := util.HelmValuesJSON(server.GetHelmValues())
+	if err != nil {
+		diags.AddError("Error processing trust zone server data", fmt.Sprintf("Could not process helm_values_merged: %s", err))
+		return TrustZoneServerModel{}, diags
+	}
+
 	model := TrustZoneServerModel{
-		ID:          tftypes.StringValue(server.GetId()),
-		TrustZoneID: tftypes.StringValue(server.GetTrustZoneId()),
-		ClusterID:   tftypes.StringValue(server.GetClusterId()),
-		OrgID:       tftypes.StringValue(server.GetOrgId()),
-		HelmValues:  helmValues,
+		ID:               tftypes.StringValue(server.GetId()),
+		TrustZoneID:      tftypes.StringValue(server.GetTrustZoneId()),
+		ClusterID:        tftypes.StringValue(server.GetClusterId()),
+		OrgID:            tftypes.StringValue(server.GetOrgId()),
+		HelmValues:       helmValues,
+		HelmValuesObject: util.NewHelmValuesObjectNull(),
+		HelmValuesMerged: helmValuesMerged,
 	}
 
 	if ns := server.GetKubernetesNamespace(); ns != "" {
@@ -282,7 +512,6 @@ func trustZoneServerFromProto(server *trustzoneserverpb.TrustZoneServer, helmVal
 		model.KubernetesServiceAccount = tftypes.StringNull()
 	}
 
-	var diags diag.Diagnostics
 	if s := server.GetStatus(); s != nil {
 		model.Status, diags = statusFromProto(s)
 	} else {
@@ -335,7 +564,7 @@ func connectK8sPsatConfigFromProto(cfg *trustzoneserverpb.ConnectK8SPsatConfig)
 }
 
 // parseHelmValues parses the helm_values field from a YAML/JSON string to a structpb.Struct.
-func parseHelmValues(valueStr tftypes.String) (*structpb.Struct, error) {
+func parseHelmValues(valueStr util.HelmValues) (*structpb.Struct, error) {
 	if valueStr.IsNull() || valueStr.ValueString() == "" {
 		return nil, nil
 	}
@@ -352,15 +581,3 @@ func parseHelmValues(valueStr tftypes.String) (*structpb.Struct, error) {
 
 	return helmValuesStruct, nil
 }
-
-// helmValuesFromProto converts a structpb.Struct to a JSON string for use in data sources.
-func helmValuesFromProto(apiValues *structpb.Struct) tftypes.String {
-	if apiValues == nil || len(apiValues.Fields) == 0 {
-		return tftypes.StringNull()
-	}
-	jsonBytes, err := apiValues.MarshalJSON()
-	if err != nil {
-		return tftypes.StringNull()
-	}
-	return tftypes.StringValue(string(jsonBytes))
-}
@@ -3,6 +3,11 @@ package trustzoneserver
 import (
 	"context"
 
+	"github.com/cofide/terraform-provider-cofide/internal/helmschema"
+	"github.com/cofide/terraform-provider-cofide/internal/planmodifiers"
+	"github.com/cofide/terraform-provider-cofide/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -12,6 +17,10 @@ import (
 
 var _ resource.ResourceWithConfigValidators = (*TrustZoneServerResource)(nil)
 
+// deletionProtectionDefault is the default of deletion_protection. Deleting a
+// trust zone server takes down the SPIRE server of its trust zone.
+const deletionProtectionDefault = true
+
 func ResourceSchema(_ context.Context) schema.Schema {
 	return schema.Schema{
 		MarkdownDescription: "Manages a Cofide Connect trust zone server. A trust zone server defines how the SPIRE server managing a trust zone should be deployed on a cluster.",
@@ -42,8 +51,8 @@ func ResourceSchema(_ context.Context) schema.Schema {
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"kubernetes_service_account": schema.StringAttribute{
@@ -51,8 +60,8 @@ func ResourceSchema(_ context.Context) schema.Schema {
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"org_id": schema.StringAttribute{
@@ -65,6 +74,39 @@ func ResourceSchema(_ context.Context) schema.Schema {
 			"helm_values": schema.StringAttribute{
 				Description: "Additional Helm values for the SPIRE server Helm chart installation, in YAML format. Use `yamlencode()` to generate from a Terraform map.",
 				Optional:    true,
+				CustomType:  util.HelmValuesType{},
+			},
+			"helm_values_object": schema.DynamicAttribute{
+				Description: "Additional Helm values for the SPIRE server Helm chart installation, as an object. Unlike `helm_values`, plans show changes to individual values. Conflicts with `helm_values` and `helm_values_layers`.",
+				Optional:    true,
+				CustomType:  util.HelmValuesObjectType{},
+			},
+			"helm_values_layers": schema.ListAttribute{
+				Description: "Additional Helm values for the SPIRE server Helm chart installation, as an ordered list of YAML or JSON documents merged the way Helm merges values files: maps are merged key by key, later documents taking precedence, while lists and other values are replaced, and a `null` value removes the key. Conflicts with `helm_values` and `helm_values_object`.",
+				Optional:    true,
+				ElementType: util.HelmValuesType{},
+			},
+			"helm_values_merged": schema.StringAttribute{
+				Description: "The Helm values sent to Cofide Connect, as a JSON document, whichever attribute they are configured in. The Helm values of an imported trust zone server are not read until they are next applied, as any of them may have been set through `sensitive_helm_values`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					planmodifiers.HelmValuesMergedModifier{
+						Document: path.Root("helm_values"),
+						Object:   path.Root("helm_values_object"),
+						Layers:   path.Root("helm_values_layers"),
+					},
+				},
+			},
+			"sensitive_helm_values": schema.StringAttribute{
+				Description: "Sensitive additional Helm values for the SPIRE server Helm chart installation, in YAML format, deep-merged over the other Helm values before they are sent to Cofide Connect. The values are write-only: they are never stored in Terraform state, and are left out of `helm_values_merged` and the other Helm values when read back. Changes are only sent when `sensitive_helm_values_version` changes. Requires Terraform 1.11 or later.",
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				CustomType:  util.HelmValuesType{},
+			},
+			"sensitive_helm_values_version": schema.Int64Attribute{
+				Description: "A version for `sensitive_helm_values`. Change it to send changed sensitive values, which Terraform cannot otherwise detect.",
+				Optional:    true,
 			},
 			"status": schema.SingleNestedAttribute{
 				Description: "The current lifecycle status of the trust zone server. Set by Cofide Connect.",
@@ -95,6 +137,27 @@ func ResourceSchema(_ context.Context) schema.Schema {
 					},
 				},
 			},
+			"deletion_protection":  util.DeletionProtectionAttribute("trust zone server", deletionProtectionDefault),
+			"retain_on_delete":     util.RetainOnDeleteAttribute("trust zone server"),
+			"validate_helm_values": helmschema.ValidateHelmValuesAttribute(),
+			"wait_for_status": schema.SingleNestedAttribute{
+				Description: "Wait after creating or updating the trust zone server until it reaches a status, so that resources depending on it find it ready. Changing it does not change the trust zone server in Cofide Connect.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"status": schema.StringAttribute{
+						Description: "The status to wait for, with or without the `TRUST_ZONE_SERVER_STATUS_` prefix (e.g. `PROVISIONED`). Waiting fails early if the server reaches a failed status.",
+						Required:    true,
+					},
+					"poll_interval": schema.StringAttribute{
+						Description: "The time to wait before polling the status again, as a duration (e.g. `10s`). The interval doubles after each poll, up to one minute. Defaults to `5s`.",
+						Optional:    true,
+					},
+					"timeout": schema.StringAttribute{
+						Description: "The longest time to wait for the status, as a duration (e.g. `15m`). Defaults to `10m`.",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -104,5 +167,11 @@ func (r *TrustZoneServerResource) Schema(ctx context.Context, req resource.Schem
 }
 
 func (r *TrustZoneServerResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
-	return []resource.ConfigValidator{}
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("helm_values"),
+			path.MatchRoot("helm_values_object"),
+			path.MatchRoot("helm_values_layers"),
+		),
+	}
 }
@@ -0,0 +1,163 @@
+package trustzoneserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	// statusPrefix prefixes the name of every trust zone server status.
+	statusPrefix = "TRUST_ZONE_SERVER_STATUS_"
+
+	defaultPollInterval = 5 * time.Second
+	maxPollInterval     = time.Minute
+	defaultWaitTimeout  = 10 * time.Minute
+)
+
+// trustZoneServerGetter is the part of the trust zone server API used to
+// wait for a server's status.
+type trustZoneServerGetter interface {
+	GetTrustZoneServer(ctx context.Context, id string) (*trustzoneserverpb.TrustZoneServer, error)
+}
+
+// waitOptions are the settings of wait_for_status.
+type waitOptions struct {
+	status       string
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+// newWaitOptions returns the settings of wait_for_status, applying defaults.
+// It returns false if model is nil, meaning there is nothing to wait for.
+func newWaitOptions(model *WaitForStatusModel) (waitOptions, bool, error) {
+	if model == nil {
+		return waitOptions{}, false, nil
+	}
+
+	opts := waitOptions{
+		status:       normalizeStatus(model.Status.ValueString()),
+		pollInterval: defaultPollInterval,
+		timeout:      defaultWaitTimeout,
+	}
+
+	var err error
+	if opts.pollInterval, err = parseDuration(model.PollInterval, defaultPollInterval); err != nil {
+		return waitOptions{}, false, fmt.Errorf("invalid poll_interval: %w", err)
+	}
+	if opts.timeout, err = parseDuration(model.Timeout, defaultWaitTimeout); err != nil {
+		return waitOptions{}, false, fmt.Errorf("invalid timeout: %w", err)
+	}
+
+	return opts, true, nil
+}
+
+func parseDuration(value tftypes.String, defaultValue time.Duration) (time.Duration, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%q is not positive", value.ValueString())
+	}
+	return d, nil
+}
+
+// normalizeStatus returns the full name of a status, which may be given
+// without its TRUST_ZONE_SERVER_STATUS_ prefix or in lower case.
+func normalizeStatus(s string) string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if !strings.HasPrefix(s, statusPrefix) {
+		s = statusPrefix + s
+	}
+	return s
+}
+
+// validateStatus returns an error if s, once normalized, is not a status a
+// trust zone server can reach.
+func validateStatus(s string) error {
+	if status := normalizeStatus(s); status != statusPrefix+"UNSPECIFIED" {
+		if _, ok := trustzoneserverpb.TrustZoneServerStatus_value[status]; ok {
+			return nil
+		}
+	}
+
+	var statuses []string
+	for name := range trustzoneserverpb.TrustZoneServerStatus_value {
+		if name = strings.TrimPrefix(name, statusPrefix); name != "UNSPECIFIED" {
+			statuses = append(statuses, name)
+		}
+	}
+	slices.Sort(statuses)
+	return fmt.Errorf("%q is not a trust zone server status; expected one of %s", s, strings.Join(statuses, ", "))
+}
+
+// isFailedStatus returns whether a status is a terminal failure, from which
+// the server will not reach another status without a change.
+func isFailedStatus(s string) bool {
+	return strings.HasSuffix(s, "_FAILED") || strings.HasSuffix(s, "_ERROR")
+}
+
+// waitForStatus polls the trust zone server id until its status is
+// opts.status, it reaches a failed status or opts.timeout elapses. The
+// interval between polls starts at opts.pollInterval and doubles after each
+// poll, up to a minute. The server last read is returned along with any
+// error, so that its final status can be recorded.
+func waitForStatus(ctx context.Context, client trustZoneServerGetter, id string, opts waitOptions) (*trustzoneserverpb.TrustZoneServer, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	var server *trustzoneserverpb.TrustZoneServer
+	interval := opts.pollInterval
+	for {
+		current, err := client.GetTrustZoneServer(ctx, id)
+		switch {
+		case err == nil:
+			server = current
+		case ctx.Err() == nil:
+			return server, fmt.Errorf("could not read trust zone server %q: %w", id, err)
+		}
+
+		if server != nil {
+			got := server.GetStatus().GetStatus().String()
+			if got == opts.status {
+				return server, nil
+			}
+			if isFailedStatus(got) {
+				return server, fmt.Errorf("trust zone server %q reached status %s%s", id, got, transitionTime(server))
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return server, fmt.Errorf("trust zone server %q did not reach status %s within %s; its status is %s%s", id, opts.status, opts.timeout, server.GetStatus().GetStatus(), transitionTime(server))
+			}
+			return server, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = min(interval*2, maxPollInterval)
+	}
+}
+
+// transitionTime describes when a server last changed status, if known.
+func transitionTime(server *trustzoneserverpb.TrustZoneServer) string {
+	t := server.GetStatus().GetLastTransitionTime()
+	if t == nil {
+		return ""
+	}
+	return " since " + t.AsTime().Format(time.RFC3339)
+}
@@ -0,0 +1,122 @@
+package trustzoneserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	trustzoneserverpb "github.com/cofide/cofide-api-sdk/gen/go/proto/trust_zone_server/v1alpha1"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeServerGetter returns the same server on every call, failing the first
+// failures calls.
+type fakeServerGetter struct {
+	server   *trustzoneserverpb.TrustZoneServer
+	failures int
+	calls    int
+}
+
+func (g *fakeServerGetter) GetTrustZoneServer(_ context.Context, id string) (*trustzoneserverpb.TrustZoneServer, error) {
+	g.calls++
+	if g.calls <= g.failures {
+		return nil, errors.New("unavailable")
+	}
+	return g.server, nil
+}
+
+func TestWaitForStatusReached(t *testing.T) {
+	getter := &fakeServerGetter{server: &trustzoneserverpb.TrustZoneServer{Id: "tzs-1"}}
+
+	server, err := waitForStatus(context.Background(), getter, "tzs-1", waitOptions{
+		status:       normalizeStatus("unspecified"),
+		pollInterval: time.Millisecond,
+		timeout:      time.Second,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tzs-1", server.GetId())
+	assert.Equal(t, 1, getter.calls)
+}
+
+func TestWaitForStatusTimeout(t *testing.T) {
+	getter := &fakeServerGetter{server: &trustzoneserverpb.TrustZoneServer{
+		Id: "tzs-1",
+		Status: &trustzoneserverpb.TrustZoneServer_Status{
+			LastTransitionTime: timestamppb.New(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+	}}
+
+	server, err := waitForStatus(context.Background(), getter, "tzs-1", waitOptions{
+		status:       normalizeStatus("PROVISIONED"),
+		pollInterval: time.Millisecond,
+		timeout:      50 * time.Millisecond,
+	})
+	assert.EqualError(t, err, `trust zone server "tzs-1" did not reach status TRUST_ZONE_SERVER_STATUS_PROVISIONED within 50ms; its status is TRUST_ZONE_SERVER_STATUS_UNSPECIFIED since 2026-01-02T03:04:05Z`)
+	assert.Equal(t, getter.server, server, "the server last read should be returned")
+	assert.Greater(t, getter.calls, 1)
+}
+
+func TestWaitForStatusReadError(t *testing.T) {
+	getter := &fakeServerGetter{failures: 1}
+
+	_, err := waitForStatus(context.Background(), getter, "tzs-1", waitOptions{
+		status:       normalizeStatus("PROVISIONED"),
+		pollInterval: time.Millisecond,
+		timeout:      time.Second,
+	})
+	assert.EqualError(t, err, `could not read trust zone server "tzs-1": unavailable`)
+}
+
+func TestNormalizeStatus(t *testing.T) {
+	for _, s := range []string{"PROVISIONED", "provisioned", " TRUST_ZONE_SERVER_STATUS_PROVISIONED"} {
+		assert.Equal(t, "TRUST_ZONE_SERVER_STATUS_PROVISIONED", normalizeStatus(s), s)
+	}
+}
+
+func TestValidateStatus(t *testing.T) {
+	for _, s := range []string{"PROVISIONED", "provisioned", "TRUST_ZONE_SERVER_STATUS_PROVISIONED"} {
+		assert.NoError(t, validateStatus(s), s)
+	}
+	for _, s := range []string{"PROVISONED", "unspecified", ""} {
+		err := validateStatus(s)
+		require.Error(t, err, s)
+		assert.Contains(t, err.Error(), "is not a trust zone server status; expected one of", s)
+		assert.Contains(t, err.Error(), "PROVISIONED", s)
+	}
+}
+
+func TestIsFailedStatus(t *testing.T) {
+	assert.True(t, isFailedStatus("TRUST_ZONE_SERVER_STATUS_FAILED"))
+	assert.True(t, isFailedStatus("TRUST_ZONE_SERVER_STATUS_PROVISIONING_ERROR"))
+	assert.False(t, isFailedStatus("TRUST_ZONE_SERVER_STATUS_PROVISIONED"))
+}
+
+func TestNewWaitOptions(t *testing.T) {
+	_, ok, err := newWaitOptions(nil)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	opts, ok, err := newWaitOptions(&WaitForStatusModel{
+		Status:       tftypes.StringValue("PROVISIONED"),
+		PollInterval: tftypes.StringValue("2s"),
+		Timeout:      tftypes.StringNull(),
+	})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, waitOptions{
+		status:       "TRUST_ZONE_SERVER_STATUS_PROVISIONED",
+		pollInterval: 2 * time.Second,
+		timeout:      defaultWaitTimeout,
+	}, opts)
+
+	_, _, err = newWaitOptions(&WaitForStatusModel{
+		Status:       tftypes.StringValue("PROVISIONED"),
+		PollInterval: tftypes.StringNull(),
+		Timeout:      tftypes.StringValue("-1m"),
+	})
+	assert.EqualError(t, err, `invalid timeout: "-1m" is not positive`)
+}
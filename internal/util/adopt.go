@@ -0,0 +1,56 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdoptExistingAttribute returns the adopt_existing attribute of a resource
+// managing the given kind of object, such as "trust zone", identified by key,
+// such as "name and organization". The attribute is kept in Terraform state
+// only.
+func AdoptExistingAttribute(kind, key string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Whether creating the %s adopts an existing %s with the same %s instead of failing because it already exists. The existing %s is updated to match the configuration. Changing it does not change the %s in Cofide Connect. Defaults to false.", kind, kind, key, kind, kind),
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+}
+
+// ShouldAdopt returns whether a create that failed with err should adopt the
+// existing object instead, because adopt_existing is enabled and the object
+// already exists.
+func ShouldAdopt(adoptExisting tftypes.Bool, err error) bool {
+	return adoptExisting.ValueBool() && status.Code(err) == codes.AlreadyExists
+}
+
+// AdoptionCandidate returns the single object among objects that matches,
+// which is the existing object to adopt. The kind and description of the
+// object, such as "trust zone" and `named "prod"`, are used in errors.
+func AdoptionCandidate[T interface{ GetId() string }](kind, description string, objects []T, match func(T) bool) (T, error) {
+	var matches []T
+	var ids []string
+	for _, object := range objects {
+		if match(object) {
+			matches = append(matches, object)
+			ids = append(ids, object.GetId())
+		}
+	}
+
+	var zero T
+	switch len(matches) {
+	case 0:
+		return zero, fmt.Errorf("no %s %s found to adopt", kind, description)
+	case 1:
+		return matches[0], nil
+	default:
+		return zero, fmt.Errorf("%s %s is ambiguous: found %d matches (IDs: %s); import the one to manage by ID instead", kind, description, len(matches), strings.Join(ids, ", "))
+	}
+}
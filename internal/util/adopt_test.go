@@ -0,0 +1,45 @@
+package util
+
+import (
+	"errors"
+	"testing"
+
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type object struct {
+	id, name string
+}
+
+func (o object) GetId() string { return o.id }
+
+func TestAdoptionCandidate(t *testing.T) {
+	objects := []object{{"a-1", "one"}, {"a-2", "two"}, {"a-3", "two"}}
+	named := func(name string) func(object) bool {
+		return func(o object) bool { return o.name == name }
+	}
+
+	got, err := AdoptionCandidate("thing", `named "one"`, objects, named("one"))
+	require.NoError(t, err)
+	assert.Equal(t, "a-1", got.id)
+
+	_, err = AdoptionCandidate("thing", `named "two"`, objects, named("two"))
+	assert.EqualError(t, err, `thing named "two" is ambiguous: found 2 matches (IDs: a-2, a-3); import the one to manage by ID instead`)
+
+	_, err = AdoptionCandidate("thing", `named "three"`, objects, named("three"))
+	assert.EqualError(t, err, `no thing named "three" found to adopt`)
+}
+
+func TestShouldAdopt(t *testing.T) {
+	exists := status.Error(codes.AlreadyExists, "exists")
+
+	assert.True(t, ShouldAdopt(tftypes.BoolValue(true), exists))
+	assert.False(t, ShouldAdopt(tftypes.BoolValue(false), exists))
+	assert.False(t, ShouldAdopt(tftypes.BoolNull(), exists))
+	assert.False(t, ShouldAdopt(tftypes.BoolValue(true), errors.New("unavailable")))
+	assert.False(t, ShouldAdopt(tftypes.BoolValue(true), nil))
+}
@@ -0,0 +1,322 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ basetypes.StringTypable                    = CACertificateType{}
+	_ basetypes.StringValuableWithSemanticEquals = CACertificate{}
+	_ xattr.ValidateableAttribute                = CACertificate{}
+)
+
+// CACertificateType is the type of attributes holding PEM-encoded CA
+// certificates, configured either as PEM or as base64-encoded PEM. Values of
+// this type holding the same certificates are semantically equal, whatever
+// their encoding, so that Terraform keeps the configured value rather than
+// the base64 returned by the provider.
+type CACertificateType struct {
+	basetypes.StringType
+}
+
+func (t CACertificateType) Equal(o attr.Type) bool {
+	other, ok := o.(CACertificateType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t CACertificateType) String() string {
+	return "util.CACertificateType"
+}
+
+func (t CACertificateType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return CACertificate{StringValue: in}, nil
+}
+
+func (t CACertificateType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type %T", value)
+	}
+	return CACertificate{StringValue: stringValue}, nil
+}
+
+func (t CACertificateType) ValueType(context.Context) attr.Value {
+	return CACertificate{}
+}
+
+// CACertificate is a value of CACertificateType.
+type CACertificate struct {
+	basetypes.StringValue
+}
+
+// NewCACertificateNull returns a null CA certificate.
+func NewCACertificateNull() CACertificate {
+	return CACertificate{StringValue: basetypes.NewStringNull()}
+}
+
+// NewCACertificateValue returns a CA certificate holding s, as PEM or
+// base64-encoded PEM.
+func NewCACertificateValue(s string) CACertificate {
+	return CACertificate{StringValue: basetypes.NewStringValue(s)}
+}
+
+// CACertificateFromBytes returns the PEM-encoded certificates returned by
+// the API as base64, or null if there are none.
+func CACertificateFromBytes(data []byte) CACertificate {
+	if len(data) == 0 {
+		return NewCACertificateNull()
+	}
+	return NewCACertificateValue(base64.StdEncoding.EncodeToString(data))
+}
+
+func (v CACertificate) Equal(o attr.Value) bool {
+	other, ok := o.(CACertificate)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v CACertificate) Type(context.Context) attr.Type {
+	return CACertificateType{}
+}
+
+// StringSemanticEquals returns true if both values hold the same
+// certificates. Values that do not parse are only equal if they are
+// identical, which the framework checks before calling this.
+func (v CACertificate) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(CACertificate)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	prior, err := v.certificates()
+	if err != nil {
+		return false, diags
+	}
+	current, err := newValue.certificates()
+	if err != nil || len(prior) != len(current) {
+		return false, diags
+	}
+	for i := range prior {
+		if !prior[i].Equal(current[i]) {
+			return false, diags
+		}
+	}
+	return true, diags
+}
+
+// ValidateAttribute reports values that are not PEM-encoded CA certificates,
+// or that hold a certificate that has expired, when planning rather than
+// applying. Values that are not yet known are validated once they are.
+func (v CACertificate) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+		return
+	}
+
+	certificates, err := v.certificates()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CA certificate", err.Error())
+		return
+	}
+	if err := CheckCACertificates(certificates, time.Now()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CA certificate", err.Error())
+	}
+}
+
+// Bytes returns the PEM-encoded certificates to send to the API.
+func (v CACertificate) Bytes() ([]byte, error) {
+	return DecodeCACertificate(v.ValueString())
+}
+
+func (v CACertificate) certificates() ([]*x509.Certificate, error) {
+	data, err := v.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return ParseCACertificates(data)
+}
+
+// DecodeCACertificate returns the PEM-encoded certificates in s, which holds
+// either PEM or base64-encoded PEM.
+func DecodeCACertificate(s string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(s), "-----BEGIN") {
+		return []byte(s), nil
+	}
+
+	// Base64 is often wrapped, as by the base64 command.
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		return nil, fmt.Errorf("not PEM, and not valid base64: %w", err)
+	}
+	return data, nil
+}
+
+// ParseCACertificates parses one or more PEM-encoded certificates.
+func ParseCACertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("PEM block %d is a %s, not a CERTIFICATE", len(certificates)+1, block.Type)
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", len(certificates)+1, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("no PEM-encoded certificate found")
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, errors.New("unexpected data after the last PEM-encoded certificate")
+	}
+	return certificates, nil
+}
+
+// CheckCACertificates returns an error if any of certificates is not a CA
+// certificate or has expired at now.
+func CheckCACertificates(certificates []*x509.Certificate, now time.Time) error {
+	for i, certificate := range certificates {
+		if !certificate.IsCA {
+			return fmt.Errorf("certificate %d (%s) is not a CA certificate", i+1, certificate.Subject)
+		}
+		if now.After(certificate.NotAfter) {
+			return fmt.Errorf("certificate %d (%s) expired at %s", i+1, certificate.Subject, certificate.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// CACertificateInfo describes CA certificates, for the computed attributes
+// alongside a certificate attribute.
+type CACertificateInfo struct {
+	// FingerprintSHA256 is the SHA-256 fingerprint of the first certificate,
+	// as lowercase hex.
+	FingerprintSHA256 basetypes.StringValue
+	// NotAfter is the earliest expiry time of the certificates, in RFC 3339
+	// format.
+	NotAfter basetypes.StringValue
+	// Subject is the subject of the first certificate, as an RFC 2253
+	// distinguished name.
+	Subject basetypes.StringValue
+}
+
+// NewCACertificateInfoNull returns the description of no certificates.
+func NewCACertificateInfoNull() CACertificateInfo {
+	return CACertificateInfo{
+		FingerprintSHA256: basetypes.NewStringNull(),
+		NotAfter:          basetypes.NewStringNull(),
+		Subject:           basetypes.NewStringNull(),
+	}
+}
+
+// NewCACertificateInfoUnknown returns the description of certificates that
+// are not yet known.
+func NewCACertificateInfoUnknown() CACertificateInfo {
+	return CACertificateInfo{
+		FingerprintSHA256: basetypes.NewStringUnknown(),
+		NotAfter:          basetypes.NewStringUnknown(),
+		Subject:           basetypes.NewStringUnknown(),
+	}
+}
+
+// CACertificateInfoFromBytes describes the PEM-encoded certificates in
+// data. Data that does not parse, such as certificates stored before they
+// were validated, is described as null.
+func CACertificateInfoFromBytes(data []byte) CACertificateInfo {
+	if len(data) == 0 {
+		return NewCACertificateInfoNull()
+	}
+	certificates, err := ParseCACertificates(data)
+	if err != nil {
+		return NewCACertificateInfoNull()
+	}
+
+	fingerprint := sha256.Sum256(certificates[0].Raw)
+	notAfter := certificates[0].NotAfter
+	for _, certificate := range certificates[1:] {
+		if certificate.NotAfter.Before(notAfter) {
+			notAfter = certificate.NotAfter
+		}
+	}
+	return CACertificateInfo{
+		FingerprintSHA256: basetypes.NewStringValue(hex.EncodeToString(fingerprint[:])),
+		NotAfter:          basetypes.NewStringValue(notAfter.UTC().Format(time.RFC3339)),
+		Subject:           basetypes.NewStringValue(certificates[0].Subject.String()),
+	}
+}
+
+// CACertificateInfoFromValue describes the certificates held by v.
+func CACertificateInfoFromValue(v CACertificate) CACertificateInfo {
+	if v.IsUnknown() {
+		return NewCACertificateInfoUnknown()
+	}
+	if v.IsNull() {
+		return NewCACertificateInfoNull()
+	}
+	data, err := v.Bytes()
+	if err != nil {
+		return NewCACertificateInfoNull()
+	}
+	return CACertificateInfoFromBytes(data)
+}
+
+// CACertificateField names a field of CACertificateInfo, and is the suffix of
+// the computed attribute holding it.
+type CACertificateField string
+
+const (
+	CACertificateFingerprintSHA256 CACertificateField = "fingerprint_sha256"
+	CACertificateNotAfter          CACertificateField = "not_after"
+	CACertificateSubject           CACertificateField = "subject"
+)
+
+// Field returns the value of field.
+func (i CACertificateInfo) Field(field CACertificateField) basetypes.StringValue {
+	switch field {
+	case CACertificateFingerprintSHA256:
+		return i.FingerprintSHA256
+	case CACertificateNotAfter:
+		return i.NotAfter
+	default:
+		return i.Subject
+	}
+}
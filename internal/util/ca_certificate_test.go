@@ -0,0 +1,160 @@
+package util
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCertificate returns a self-signed PEM-encoded certificate, and its DER
+// encoding.
+func newCertificate(t *testing.T, commonName string, isCA bool, notAfter time.Time) (string, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Cofide"}},
+		NotBefore:             notAfter.Add(-48 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), der
+}
+
+func TestDecodeCACertificate(t *testing.T) {
+	ca, _ := newCertificate(t, "ca", true, time.Now().Add(time.Hour))
+
+	got, err := DecodeCACertificate(ca)
+	require.NoError(t, err)
+	assert.Equal(t, ca, string(got))
+
+	got, err = DecodeCACertificate(base64.StdEncoding.EncodeToString([]byte(ca)))
+	require.NoError(t, err)
+	assert.Equal(t, ca, string(got))
+
+	wrapped := base64.StdEncoding.EncodeToString([]byte(ca))
+	wrapped = wrapped[:64] + "\n" + wrapped[64:] + "\n"
+	got, err = DecodeCACertificate(wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, ca, string(got), "wrapped base64 should be decoded")
+
+	_, err = DecodeCACertificate("not-valid-base64!!!")
+	assert.ErrorContains(t, err, "not PEM, and not valid base64")
+}
+
+func TestParseCACertificates(t *testing.T) {
+	root, _ := newCertificate(t, "root", true, time.Now().Add(time.Hour))
+	intermediate, _ := newCertificate(t, "intermediate", true, time.Now().Add(time.Hour))
+
+	certificates, err := ParseCACertificates([]byte(root + intermediate))
+	require.NoError(t, err)
+	require.Len(t, certificates, 2)
+	assert.Equal(t, "intermediate", certificates[1].Subject.CommonName)
+
+	_, err = ParseCACertificates([]byte("test-ca"))
+	assert.ErrorContains(t, err, "no PEM-encoded certificate found")
+
+	_, err = ParseCACertificates([]byte(root + "trailing"))
+	assert.ErrorContains(t, err, "unexpected data after the last PEM-encoded certificate")
+
+	key := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}))
+	_, err = ParseCACertificates([]byte(root + key))
+	assert.ErrorContains(t, err, "PEM block 2 is a PRIVATE KEY, not a CERTIFICATE")
+
+	_, err = ParseCACertificates(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")}))
+	assert.ErrorContains(t, err, "certificate 1:")
+}
+
+func TestCACertificateValidateAttribute(t *testing.T) {
+	now := time.Now()
+	ca, _ := newCertificate(t, "ca", true, now.Add(time.Hour))
+	leaf, _ := newCertificate(t, "leaf", false, now.Add(time.Hour))
+	expired, _ := newCertificate(t, "old", true, now.Add(-time.Hour))
+
+	tests := []struct {
+		name  string
+		value CACertificate
+		want  string
+	}{
+		{name: "null", value: NewCACertificateNull()},
+		{name: "empty", value: NewCACertificateValue("")},
+		{name: "PEM", value: NewCACertificateValue(ca)},
+		{name: "base64", value: NewCACertificateValue(base64.StdEncoding.EncodeToString([]byte(ca)))},
+		{name: "not a certificate", value: NewCACertificateValue(base64.StdEncoding.EncodeToString([]byte("test-ca"))), want: "no PEM-encoded certificate found"},
+		{name: "not a CA", value: NewCACertificateValue(ca + leaf), want: "certificate 2 (CN=leaf,O=Cofide) is not a CA certificate"},
+		{name: "expired", value: NewCACertificateValue(expired), want: "certificate 1 (CN=old,O=Cofide) expired at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &xattr.ValidateAttributeResponse{}
+			tt.value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("oidc_issuer_ca_cert")}, resp)
+			if tt.want == "" {
+				assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, "Invalid CA certificate", resp.Diagnostics[0].Summary())
+			assert.Contains(t, resp.Diagnostics[0].Detail(), tt.want)
+		})
+	}
+}
+
+func TestCACertificateSemanticEquals(t *testing.T) {
+	ca, _ := newCertificate(t, "ca", true, time.Now().Add(time.Hour))
+	other, _ := newCertificate(t, "other", true, time.Now().Add(time.Hour))
+	encoded := NewCACertificateValue(base64.StdEncoding.EncodeToString([]byte(ca)))
+
+	equal, diags := NewCACertificateValue(ca+"\n").StringSemanticEquals(context.Background(), encoded)
+	require.False(t, diags.HasError())
+	assert.True(t, equal, "PEM and base64-encoded PEM of the same certificate should be equal")
+
+	equal, diags = NewCACertificateValue(other).StringSemanticEquals(context.Background(), encoded)
+	require.False(t, diags.HasError())
+	assert.False(t, equal)
+
+	equal, diags = NewCACertificateValue(ca+other).StringSemanticEquals(context.Background(), encoded)
+	require.False(t, diags.HasError())
+	assert.False(t, equal)
+}
+
+func TestCACertificateInfoFromBytes(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	first, der := newCertificate(t, "first", true, notAfter)
+	second, _ := newCertificate(t, "second", true, notAfter.Add(-time.Hour))
+	fingerprint := sha256.Sum256(der)
+
+	info := CACertificateInfoFromBytes([]byte(first + second))
+	assert.Equal(t, hex.EncodeToString(fingerprint[:]), info.FingerprintSHA256.ValueString())
+	assert.Equal(t, notAfter.Add(-time.Hour).UTC().Format(time.RFC3339), info.NotAfter.ValueString(), "the earliest expiry should be reported")
+	assert.Equal(t, "CN=first,O=Cofide", info.Subject.ValueString())
+	assert.Equal(t, info.Subject, info.Field(CACertificateSubject))
+
+	assert.Equal(t, NewCACertificateInfoNull(), CACertificateInfoFromBytes(nil))
+	assert.Equal(t, NewCACertificateInfoNull(), CACertificateInfoFromBytes([]byte("test-ca")), "certificates that do not parse should be described as null")
+
+	assert.Equal(t, info, CACertificateInfoFromValue(CACertificateFromBytes([]byte(first+second))))
+	assert.Equal(t, NewCACertificateInfoUnknown(), CACertificateInfoFromValue(CACertificate{StringValue: types.StringUnknown()}))
+	assert.Equal(t, NewCACertificateInfoNull(), CACertificateInfoFromValue(NewCACertificateNull()))
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	tftypes "github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DeletionProtectionAttribute returns the deletion_protection attribute of a
+// resource managing the given kind of object, such as "trust zone". The
+// attribute is kept in Terraform state only.
+func DeletionProtectionAttribute(kind string, defaultValue bool) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Whether Terraform is prevented from deleting the %s. While true, destroying or replacing it fails; set it to false and apply first. Changing it does not change the %s in Cofide Connect. Defaults to %t.", kind, kind, defaultValue),
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(defaultValue),
+	}
+}
+
+// DeletionProtected returns whether deletion protection is enabled. A null
+// value, as in state written before the attribute was added or after import,
+// stands for the default.
+func DeletionProtected(value tftypes.Bool, defaultValue bool) bool {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultValue
+	}
+	return value.ValueBool()
+}
+
+// DeletionProtectionError returns the error reported when deleting an object
+// whose deletion protection is enabled.
+func DeletionProtectionError(kind, name string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		fmt.Sprintf("Cannot delete protected %s", kind),
+		fmt.Sprintf("The %s %q has deletion_protection enabled. Set deletion_protection to false and apply the change before deleting or replacing it.", kind, name),
+	)
+}
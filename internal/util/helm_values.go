@@ -0,0 +1,198 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ basetypes.StringTypable                    = HelmValuesType{}
+	_ basetypes.StringValuableWithSemanticEquals = HelmValues{}
+)
+
+// HelmValuesType is the type of attributes holding Helm values as a YAML or
+// JSON document. Values of this type that describe the same object are
+// semantically equal, whatever their format, key order, comments or
+// whitespace, so that Terraform keeps the configured document rather than
+// the JSON returned by the API.
+type HelmValuesType struct {
+	basetypes.StringType
+}
+
+func (t HelmValuesType) Equal(o attr.Type) bool {
+	other, ok := o.(HelmValuesType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t HelmValuesType) String() string {
+	return "util.HelmValuesType"
+}
+
+func (t HelmValuesType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return HelmValues{StringValue: in}, nil
+}
+
+func (t HelmValuesType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type %T", value)
+	}
+	return HelmValues{StringValue: stringValue}, nil
+}
+
+func (t HelmValuesType) ValueType(context.Context) attr.Value {
+	return HelmValues{}
+}
+
+// HelmValues is a value of HelmValuesType.
+type HelmValues struct {
+	basetypes.StringValue
+}
+
+// NewHelmValuesNull returns null Helm values.
+func NewHelmValuesNull() HelmValues {
+	return HelmValues{StringValue: basetypes.NewStringNull()}
+}
+
+// NewHelmValuesValue returns Helm values holding the document s.
+func NewHelmValuesValue(s string) HelmValues {
+	return HelmValues{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v HelmValues) Equal(o attr.Value) bool {
+	other, ok := o.(HelmValues)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v HelmValues) Type(context.Context) attr.Type {
+	return HelmValuesType{}
+}
+
+// StringSemanticEquals returns true if both values parse to the same object.
+// Values that do not parse are only equal if they are identical, which the
+// framework checks before calling this.
+func (v HelmValues) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(HelmValues)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	prior, err := ParseHelmValues(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	current, err := ParseHelmValues(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	return proto.Equal(prior, current), diags
+}
+
+// ParseHelmValues parses a YAML or JSON document of Helm values. An empty
+// document is an empty object.
+func ParseHelmValues(input string) (*structpb.Struct, error) {
+	var helmValues map[string]any
+	if err := yaml.Unmarshal([]byte(input), &helmValues); err != nil {
+		return nil, fmt.Errorf("invalid YAML/JSON: %w", err)
+	}
+
+	helmStruct, err := structpb.NewStruct(helmValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to Struct: %w", err)
+	}
+	return helmStruct, nil
+}
+
+// HelmValuesFromProto returns Helm values returned by the API as a JSON
+// document, or null if there are none. Null is never semantically equal to a
+// document, so prior is returned instead when it is an empty document such as
+// "" or "{}", so that configuring empty values does not show a diff.
+func HelmValuesFromProto(values *structpb.Struct, prior HelmValues) (HelmValues, error) {
+	if len(values.GetFields()) > 0 {
+		jsonBytes, err := values.MarshalJSON()
+		if err != nil {
+			return NewHelmValuesNull(), fmt.Errorf("could not marshal helm values to JSON: %w", err)
+		}
+		return NewHelmValuesValue(string(jsonBytes)), nil
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if parsed, err := ParseHelmValues(prior.ValueString()); err == nil && len(parsed.GetFields()) == 0 {
+			return prior, nil
+		}
+	}
+	return NewHelmValuesNull(), nil
+}
+
+// HelmValuesForms holds the attributes Helm values can be configured with:
+// a document, an object or a list of layers. At most one of them is set.
+type HelmValuesForms struct {
+	Document HelmValues
+	Object   HelmValuesObject
+	Layers   basetypes.ListValue
+}
+
+// NewHelmValuesFormsNull returns forms with no Helm values configured.
+func NewHelmValuesFormsNull() HelmValuesForms {
+	return HelmValuesForms{
+		Document: NewHelmValuesNull(),
+		Object:   NewHelmValuesObjectNull(),
+		Layers:   basetypes.NewListNull(HelmValuesType{}),
+	}
+}
+
+// ToProto returns the Helm values to send to the API for whichever form is
+// set, or nil if none is.
+func (f HelmValuesForms) ToProto() (*structpb.Struct, error) {
+	switch {
+	case !f.Document.IsNull():
+		return ParseHelmValues(f.Document.ValueString())
+	case !f.Object.IsNull():
+		return HelmValuesObjectToProto(f.Object)
+	default:
+		return HelmValuesLayersToProto(f.Layers)
+	}
+}
+
+// HelmValuesFormsFromProto returns Helm values returned by the API in the
+// form they were configured in, as given by prior, from the plan or state.
+// Values not configured in any form are returned as a document, such as
+// after an import.
+func HelmValuesFormsFromProto(values *structpb.Struct, prior HelmValuesForms) (HelmValuesForms, error) {
+	forms := NewHelmValuesFormsNull()
+	var err error
+	switch {
+	case !prior.Object.IsNull():
+		forms.Object = HelmValuesObjectFromProto(values, prior.Object)
+	case !prior.Layers.IsNull():
+		forms.Layers, err = HelmValuesLayersFromProto(values, prior.Layers)
+	default:
+		forms.Document, err = HelmValuesFromProto(values, prior.Document)
+	}
+	return forms, err
+}
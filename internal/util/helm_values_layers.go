@@ -0,0 +1,116 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// MergeHelmValues deep-merges overlay into base the way Helm merges values
+// files: maps are merged key by key, and any other value, including a list,
+// replaces the value in base. A null in overlay replaces the value in base
+// and is kept, so that Helm also deletes the chart's default for that key.
+// base is not modified.
+func MergeHelmValues(base, overlay map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range overlay {
+		overlayMap, overlayIsMap := value.(map[string]any)
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		if overlayIsMap && baseIsMap {
+			merged[key] = MergeHelmValues(baseMap, overlayMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// HelmValuesLayersToProto parses an ordered list of Helm values documents
+// and merges them with MergeHelmValues, later documents taking precedence.
+// It returns nil for a null list.
+func HelmValuesLayersToProto(layers basetypes.ListValue) (*structpb.Struct, error) {
+	if layers.IsUnknown() {
+		return nil, ErrUnknownHelmValue
+	}
+	if layers.IsNull() {
+		return nil, nil
+	}
+
+	merged := map[string]any{}
+	for i, element := range layers.Elements() {
+		if element.IsUnknown() {
+			return nil, ErrUnknownHelmValue
+		}
+		layer, ok := element.(HelmValues)
+		if !ok {
+			return nil, fmt.Errorf("layer %d: unexpected value type %T", i, element)
+		}
+		if layer.IsNull() {
+			continue
+		}
+
+		parsed, err := ParseHelmValues(layer.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("layer %d: %w", i, err)
+		}
+		merged = MergeHelmValues(merged, parsed.AsMap())
+	}
+
+	helmStruct, err := structpb.NewStruct(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to Struct: %w", err)
+	}
+	return helmStruct, nil
+}
+
+// HelmValuesLayersFromProto returns prior if its layers merge to the Helm
+// values returned by the API. Otherwise the values were changed outside
+// Terraform, and it returns a single layer holding them, so that the next
+// plan restores the configured layers.
+func HelmValuesLayersFromProto(values *structpb.Struct, prior basetypes.ListValue) (basetypes.ListValue, error) {
+	merged, err := HelmValuesLayersToProto(prior)
+	if err == nil && equalHelmValues(merged, values) {
+		return prior, nil
+	}
+
+	document, err := HelmValuesJSON(values)
+	if err != nil {
+		return basetypes.ListValue{}, err
+	}
+	if document.IsNull() {
+		document = basetypes.NewStringValue("{}")
+	}
+	return basetypes.NewListValueMust(HelmValuesType{}, []attr.Value{HelmValues{StringValue: document}}), nil
+}
+
+// equalHelmValues returns whether a and b hold the same Helm values, treating
+// nil as empty.
+func equalHelmValues(a, b *structpb.Struct) bool {
+	if len(a.GetFields()) == 0 || len(b.GetFields()) == 0 {
+		return len(a.GetFields()) == len(b.GetFields())
+	}
+	return proto.Equal(a, b)
+}
+
+// HelmValuesJSON returns Helm values as a JSON document with sorted keys, so
+// that the same values always give the same document, or null if there are
+// none.
+func HelmValuesJSON(values *structpb.Struct) (basetypes.StringValue, error) {
+	if len(values.GetFields()) == 0 {
+		return basetypes.NewStringNull(), nil
+	}
+
+	jsonBytes, err := json.Marshal(values.AsMap())
+	if err != nil {
+		return basetypes.NewStringNull(), fmt.Errorf("could not marshal helm values to JSON: %w", err)
+	}
+	return basetypes.NewStringValue(string(jsonBytes)), nil
+}
@@ -0,0 +1,131 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newLayers(documents ...string) types.List {
+	elements := make([]attr.Value, 0, len(documents))
+	for _, document := range documents {
+		elements = append(elements, NewHelmValuesValue(document))
+	}
+	return types.ListValueMust(HelmValuesType{}, elements)
+}
+
+func TestMergeHelmValues(t *testing.T) {
+	base := map[string]any{
+		"global":       map[string]any{"trustDomain": "example.org", "logLevel": "info"},
+		"args":         []any{"-a", "-b"},
+		"replicas":     float64(1),
+		"nodeSelector": map[string]any{"pool": "system"},
+	}
+	overlay := map[string]any{
+		"global":       map[string]any{"logLevel": "debug"},
+		"args":         []any{"-c"},
+		"nodeSelector": nil,
+		"tolerations":  []any{},
+	}
+
+	assert.Equal(t, map[string]any{
+		"global":       map[string]any{"trustDomain": "example.org", "logLevel": "debug"},
+		"args":         []any{"-c"},
+		"replicas":     float64(1),
+		"nodeSelector": nil,
+		"tolerations":  []any{},
+	}, MergeHelmValues(base, overlay))
+	assert.Equal(t, map[string]any{"trustDomain": "example.org", "logLevel": "info"}, base["global"], "base should not be modified")
+}
+
+func TestHelmValuesLayersToProto(t *testing.T) {
+	got, err := HelmValuesLayersToProto(newLayers(
+		"global:\n  trustDomain: example.org\n  logLevel: info\n",
+		`{"global": {"logLevel": "debug"}}`,
+		"",
+	))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"global": map[string]any{"trustDomain": "example.org", "logLevel": "debug"}}, got.AsMap())
+
+	got, err = HelmValuesLayersToProto(types.ListNull(HelmValuesType{}))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = HelmValuesLayersToProto(newLayers("replicas: 1", "replicas: ["))
+	assert.ErrorContains(t, err, "layer 1: invalid YAML/JSON")
+
+	_, err = HelmValuesLayersToProto(types.ListValueMust(HelmValuesType{}, []attr.Value{HelmValues{StringValue: types.StringUnknown()}}))
+	assert.ErrorIs(t, err, ErrUnknownHelmValue)
+}
+
+func TestHelmValuesLayersFromProto(t *testing.T) {
+	layers := newLayers("replicas: 1\n", "replicas: 2\n")
+
+	values, err := structpb.NewStruct(map[string]any{"replicas": 2})
+	require.NoError(t, err)
+	got, err := HelmValuesLayersFromProto(values, layers)
+	require.NoError(t, err)
+	assert.Equal(t, layers, got, "layers that merge to the values returned should be kept")
+
+	changed, err := structpb.NewStruct(map[string]any{"replicas": 3})
+	require.NoError(t, err)
+	got, err = HelmValuesLayersFromProto(changed, layers)
+	require.NoError(t, err)
+	assert.Equal(t, newLayers(`{"replicas":3}`), got, "values changed outside Terraform should replace the layers")
+
+	empty := newLayers("{}")
+	got, err = HelmValuesLayersFromProto(nil, empty)
+	require.NoError(t, err)
+	assert.Equal(t, empty, got)
+}
+
+func TestHelmValuesJSON(t *testing.T) {
+	values, err := structpb.NewStruct(map[string]any{"b": map[string]any{"y": 2, "x": 1}, "a": true})
+	require.NoError(t, err)
+
+	got, err := HelmValuesJSON(values)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":true,"b":{"x":1,"y":2}}`, got.ValueString())
+
+	got, err = HelmValuesJSON(&structpb.Struct{})
+	require.NoError(t, err)
+	assert.True(t, got.IsNull())
+}
+
+func TestHelmValuesFormsFromProto(t *testing.T) {
+	values, err := structpb.NewStruct(map[string]any{"key": "value"})
+	require.NoError(t, err)
+
+	forms, err := HelmValuesFormsFromProto(values, NewHelmValuesFormsNull())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"value"}`, forms.Document.ValueString())
+	assert.True(t, forms.Object.IsNull(), "values should be read as a document unless configured otherwise")
+	assert.True(t, forms.Layers.IsNull())
+
+	prior := NewHelmValuesFormsNull()
+	prior.Object = newHelmValuesObject(t)
+	forms, err = HelmValuesFormsFromProto(values, prior)
+	require.NoError(t, err)
+	assert.True(t, forms.Document.IsNull())
+	got, err := HelmValuesObjectToProto(forms.Object)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"key": "value"}, got.AsMap())
+
+	prior = NewHelmValuesFormsNull()
+	prior.Layers = newLayers("key: other\n", "key: value\n")
+	forms, err = HelmValuesFormsFromProto(values, prior)
+	require.NoError(t, err)
+	assert.True(t, forms.Document.IsNull())
+	assert.True(t, forms.Object.IsNull())
+	assert.Equal(t, prior.Layers, forms.Layers)
+
+	empty := NewHelmValuesFormsNull()
+	empty.Object = HelmValuesObject{DynamicValue: types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{}))}
+	forms, err = HelmValuesFormsFromProto(nil, empty)
+	require.NoError(t, err)
+	assert.Equal(t, empty.Object, forms.Object, "an empty object should be kept when the API returns no values")
+}
@@ -0,0 +1,268 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	_ basetypes.DynamicTypable                    = HelmValuesObjectType{}
+	_ basetypes.DynamicValuableWithSemanticEquals = HelmValuesObject{}
+	_ xattr.ValidateableAttribute                 = HelmValuesObject{}
+)
+
+// ErrUnknownHelmValue is returned when converting Helm values that are not
+// yet known.
+var ErrUnknownHelmValue = errors.New("helm values must be known")
+
+// HelmValuesObjectType is the type of attributes holding Helm values as a
+// native Terraform object, so that plans show changes to individual values.
+// Values of this type that describe the same object are semantically equal,
+// whatever the Terraform types used to describe it, such as a map or an
+// object, or a list or a tuple.
+type HelmValuesObjectType struct {
+	basetypes.DynamicType
+}
+
+func (t HelmValuesObjectType) Equal(o attr.Type) bool {
+	other, ok := o.(HelmValuesObjectType)
+	if !ok {
+		return false
+	}
+	return t.DynamicType.Equal(other.DynamicType)
+}
+
+func (t HelmValuesObjectType) String() string {
+	return "util.HelmValuesObjectType"
+}
+
+func (t HelmValuesObjectType) ValueFromDynamic(_ context.Context, in basetypes.DynamicValue) (basetypes.DynamicValuable, diag.Diagnostics) {
+	return HelmValuesObject{DynamicValue: in}, nil
+}
+
+func (t HelmValuesObjectType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.DynamicType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicValue, ok := value.(basetypes.DynamicValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type %T", value)
+	}
+	return HelmValuesObject{DynamicValue: dynamicValue}, nil
+}
+
+func (t HelmValuesObjectType) ValueType(context.Context) attr.Value {
+	return HelmValuesObject{}
+}
+
+// HelmValuesObject is a value of HelmValuesObjectType.
+type HelmValuesObject struct {
+	basetypes.DynamicValue
+}
+
+// NewHelmValuesObjectNull returns null Helm values.
+func NewHelmValuesObjectNull() HelmValuesObject {
+	return HelmValuesObject{DynamicValue: basetypes.NewDynamicNull()}
+}
+
+func (v HelmValuesObject) Equal(o attr.Value) bool {
+	other, ok := o.(HelmValuesObject)
+	if !ok {
+		return false
+	}
+	return v.DynamicValue.Equal(other.DynamicValue)
+}
+
+func (v HelmValuesObject) Type(context.Context) attr.Type {
+	return HelmValuesObjectType{}
+}
+
+// DynamicSemanticEquals returns true if both values convert to the same Helm
+// values.
+func (v HelmValuesObject) DynamicSemanticEquals(_ context.Context, newValuable basetypes.DynamicValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(HelmValuesObject)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	prior, err := HelmValuesObjectToProto(v)
+	if err != nil {
+		return false, diags
+	}
+	current, err := HelmValuesObjectToProto(newValue)
+	if err != nil {
+		return false, diags
+	}
+	return proto.Equal(prior, current), diags
+}
+
+// ValidateAttribute reports values that cannot be sent as Helm values, such
+// as a string instead of an object, when planning rather than applying.
+// Values that are not yet known are validated once they are.
+func (v HelmValuesObject) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := HelmValuesObjectToProto(v); err != nil && !errors.Is(err, ErrUnknownHelmValue) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Helm values", err.Error())
+	}
+}
+
+// HelmValuesObjectToProto converts Helm values configured as an object to
+// the Struct sent to the API. It returns nil for null values.
+func HelmValuesObjectToProto(v HelmValuesObject) (*structpb.Struct, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	value, err := helmValueToAny(v.DynamicValue)
+	if err != nil {
+		return nil, err
+	}
+	values, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("helm values must be an object, got %s", v.UnderlyingValue().Type(context.Background()))
+	}
+
+	helmStruct, err := structpb.NewStruct(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to Struct: %w", err)
+	}
+	return helmStruct, nil
+}
+
+// helmValueToAny converts a Terraform value to the Go value structpb
+// expects. Objects and maps become map[string]any, and lists, sets and
+// tuples become []any.
+func helmValueToAny(value attr.Value) (any, error) {
+	if value.IsUnknown() {
+		return nil, ErrUnknownHelmValue
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return helmValueToAny(v.UnderlyingValue())
+	case basetypes.ObjectValue:
+		return helmValuesToMap(v.Attributes())
+	case basetypes.MapValue:
+		return helmValuesToMap(v.Elements())
+	case basetypes.TupleValue:
+		return helmValuesToSlice(v.Elements())
+	case basetypes.ListValue:
+		return helmValuesToSlice(v.Elements())
+	case basetypes.SetValue:
+		return helmValuesToSlice(v.Elements())
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.NumberValue:
+		f, _ := v.ValueBigFloat().Float64()
+		return f, nil
+	case basetypes.Int64Value:
+		return float64(v.ValueInt64()), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	default:
+		return nil, fmt.Errorf("unsupported helm value type %s", value.Type(context.Background()))
+	}
+}
+
+func helmValuesToMap(values map[string]attr.Value) (map[string]any, error) {
+	result := make(map[string]any, len(values))
+	for key, value := range values {
+		converted, err := helmValueToAny(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		result[key] = converted
+	}
+	return result, nil
+}
+
+func helmValuesToSlice(values []attr.Value) ([]any, error) {
+	result := make([]any, 0, len(values))
+	for i, value := range values {
+		converted, err := helmValueToAny(value)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// HelmValuesObjectFromProto returns Helm values returned by the API as an
+// object, or null if there are none. As for HelmValuesFromProto, prior is
+// returned instead when it is an empty object.
+func HelmValuesObjectFromProto(values *structpb.Struct, prior HelmValuesObject) HelmValuesObject {
+	if len(values.GetFields()) > 0 {
+		return HelmValuesObject{DynamicValue: basetypes.NewDynamicValue(helmStructValue(values))}
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if parsed, err := HelmValuesObjectToProto(prior); err == nil && len(parsed.GetFields()) == 0 {
+			return prior
+		}
+	}
+	return NewHelmValuesObjectNull()
+}
+
+// helmStructValue converts a Struct to an object value. Lists become tuples,
+// as their elements may be of different types.
+func helmStructValue(s *structpb.Struct) attr.Value {
+	attrTypes := make(map[string]attr.Type, len(s.GetFields()))
+	attrValues := make(map[string]attr.Value, len(s.GetFields()))
+	for key, field := range s.GetFields() {
+		value := helmValue(field)
+		attrTypes[key] = value.Type(context.Background())
+		attrValues[key] = value
+	}
+	return basetypes.NewObjectValueMust(attrTypes, attrValues)
+}
+
+func helmValue(v *structpb.Value) attr.Value {
+	switch kind := v.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		return helmStructValue(kind.StructValue)
+	case *structpb.Value_ListValue:
+		elemTypes := make([]attr.Type, 0, len(kind.ListValue.GetValues()))
+		elems := make([]attr.Value, 0, len(kind.ListValue.GetValues()))
+		for _, elem := range kind.ListValue.GetValues() {
+			value := helmValue(elem)
+			elemTypes = append(elemTypes, value.Type(context.Background()))
+			elems = append(elems, value)
+		}
+		return basetypes.NewTupleValueMust(elemTypes, elems)
+	case *structpb.Value_StringValue:
+		return basetypes.NewStringValue(kind.StringValue)
+	case *structpb.Value_BoolValue:
+		return basetypes.NewBoolValue(kind.BoolValue)
+	case *structpb.Value_NumberValue:
+		return basetypes.NewNumberValue(big.NewFloat(kind.NumberValue))
+	default:
+		return basetypes.NewDynamicNull()
+	}
+}
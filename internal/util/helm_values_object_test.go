@@ -0,0 +1,105 @@
+package util
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHelmValuesObject returns the object Terraform would send for
+//
+//	{ spire-server = { replicas = 2, args = ["-v", true] }, global = null }
+func newHelmValuesObject(t *testing.T) HelmValuesObject {
+	t.Helper()
+
+	argsTypes := []attr.Type{types.StringType, types.BoolType}
+	args := types.TupleValueMust(argsTypes, []attr.Value{types.StringValue("-v"), types.BoolValue(true)})
+	serverTypes := map[string]attr.Type{"replicas": types.NumberType, "args": types.TupleType{ElemTypes: argsTypes}}
+	server := types.ObjectValueMust(serverTypes, map[string]attr.Value{
+		"replicas": types.NumberValue(big.NewFloat(2)),
+		"args":     args,
+	})
+	values := types.ObjectValueMust(
+		map[string]attr.Type{"spire-server": types.ObjectType{AttrTypes: serverTypes}, "global": types.DynamicType},
+		map[string]attr.Value{"spire-server": server, "global": types.DynamicNull()},
+	)
+	return HelmValuesObject{DynamicValue: types.DynamicValue(values)}
+}
+
+func TestHelmValuesObjectToProto(t *testing.T) {
+	got, err := HelmValuesObjectToProto(newHelmValuesObject(t))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"spire-server": map[string]any{"replicas": float64(2), "args": []any{"-v", true}},
+		"global":       nil,
+	}, got.AsMap())
+
+	got, err = HelmValuesObjectToProto(NewHelmValuesObjectNull())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = HelmValuesObjectToProto(HelmValuesObject{DynamicValue: types.DynamicValue(types.StringValue("replicas: 2"))})
+	assert.EqualError(t, err, "helm values must be an object, got basetypes.StringType")
+}
+
+func TestHelmValuesObjectRoundTrip(t *testing.T) {
+	configured := newHelmValuesObject(t)
+	values, err := HelmValuesObjectToProto(configured)
+	require.NoError(t, err)
+
+	got := HelmValuesObjectFromProto(values, NewHelmValuesObjectNull())
+	require.False(t, got.IsNull())
+
+	equal, diags := configured.DynamicSemanticEquals(context.Background(), got)
+	require.False(t, diags.HasError())
+	assert.True(t, equal, "values read back should be semantically equal to those configured")
+}
+
+func TestHelmValuesObjectSemanticEquals(t *testing.T) {
+	object := HelmValuesObject{DynamicValue: types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"replicas": types.NumberType},
+		map[string]attr.Value{"replicas": types.NumberValue(big.NewFloat(2))},
+	))}
+	sameMap := HelmValuesObject{DynamicValue: types.DynamicValue(types.MapValueMust(
+		types.NumberType,
+		map[string]attr.Value{"replicas": types.NumberValue(big.NewFloat(2))},
+	))}
+	differentMap := HelmValuesObject{DynamicValue: types.DynamicValue(types.MapValueMust(
+		types.NumberType,
+		map[string]attr.Value{"replicas": types.NumberValue(big.NewFloat(3))},
+	))}
+
+	equal, diags := object.DynamicSemanticEquals(context.Background(), sameMap)
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+
+	equal, diags = object.DynamicSemanticEquals(context.Background(), differentMap)
+	require.False(t, diags.HasError())
+	assert.False(t, equal)
+}
+
+func TestHelmValuesObjectValidateAttribute(t *testing.T) {
+	validate := func(v attr.Value) diag.Diagnostics {
+		resp := &xattr.ValidateAttributeResponse{}
+		HelmValuesObject{DynamicValue: types.DynamicValue(v)}.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("helm_values_object")}, resp)
+		return resp.Diagnostics
+	}
+
+	assert.False(t, validate(newHelmValuesObject(t).UnderlyingValue()).HasError())
+	assert.False(t, validate(types.ObjectValueMust(
+		map[string]attr.Type{"replicas": types.NumberType},
+		map[string]attr.Value{"replicas": types.NumberUnknown()},
+	)).HasError(), "values that are not yet known should be validated once they are")
+
+	diags := validate(types.StringValue("replicas: 2"))
+	require.True(t, diags.HasError())
+	assert.Equal(t, "helm values must be an object, got basetypes.StringType", diags[0].Detail())
+}
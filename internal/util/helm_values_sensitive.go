@@ -0,0 +1,206 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// sensitiveHelmValuesPathsKey is the private state key holding the paths of
+// the sensitive Helm values last sent to the API. Only the paths are kept,
+// never the values.
+const sensitiveHelmValuesPathsKey = "sensitive_helm_values_paths"
+
+// importedHelmValuesKey is the private state key marking the Helm values of
+// an imported resource, which have not been sent to the API since.
+const importedHelmValuesKey = "imported_helm_values"
+
+// PrivateStateGetter is implemented by the private state of resource requests.
+type PrivateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// PrivateStateSetter is implemented by the private state of resource
+// responses.
+type PrivateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// MergeSensitiveHelmValues deep-merges sensitive Helm values, configured in a
+// write-only attribute, over values with MergeHelmValues. It returns the
+// merged values to send to the API, and the paths of the sensitive values so
+// that they can be removed from the values the API returns. values is
+// returned unchanged if sensitive is null.
+func MergeSensitiveHelmValues(values *structpb.Struct, sensitive HelmValues) (*structpb.Struct, [][]string, error) {
+	if sensitive.IsNull() || sensitive.IsUnknown() {
+		return values, nil, nil
+	}
+
+	parsed, err := ParseHelmValues(sensitive.ValueString())
+	if err != nil {
+		return nil, nil, err
+	}
+	sensitiveValues := parsed.AsMap()
+
+	merged, err := structpb.NewStruct(MergeHelmValues(values.AsMap(), sensitiveValues))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert to Struct: %w", err)
+	}
+	return merged, HelmValuesPaths(sensitiveValues), nil
+}
+
+// HelmValuesPaths returns the paths to the values that are not maps, sorted.
+// Lists are not descended into, as a list replaces the one it is merged over.
+func HelmValuesPaths(values map[string]any) [][]string {
+	var paths [][]string
+	for key, value := range values {
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			for _, path := range HelmValuesPaths(nested) {
+				paths = append(paths, append([]string{key}, path...))
+			}
+			continue
+		}
+		paths = append(paths, []string{key})
+	}
+	slices.SortFunc(paths, slices.Compare)
+	return paths
+}
+
+// WithoutSensitiveHelmValues returns a copy of values without the sensitive
+// values at paths, merged in by MergeSensitiveHelmValues, so that they are
+// never stored in state. Where prior, the values configured in the
+// non-sensitive forms, has a value at one of the paths, that value is kept
+// instead, as it was overridden by the sensitive value rather than changed.
+// Maps left empty are removed, so that sensitive values merged in from a map
+// absent from prior leave no trace.
+func WithoutSensitiveHelmValues(values *structpb.Struct, paths [][]string, prior HelmValuesForms) (*structpb.Struct, error) {
+	if len(paths) == 0 || values == nil {
+		return values, nil
+	}
+
+	priorValues, err := prior.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	configured := priorValues.AsMap()
+
+	remaining := values.AsMap()
+	for _, path := range paths {
+		removeHelmValue(remaining, path)
+		if value, ok := lookupHelmValue(configured, path); ok {
+			setHelmValue(remaining, path, value)
+		}
+	}
+
+	stripped, err := structpb.NewStruct(remaining)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to Struct: %w", err)
+	}
+	return stripped, nil
+}
+
+// lookupHelmValue returns the value at path in values, if there is one.
+func lookupHelmValue(values map[string]any, path []string) (any, bool) {
+	for i, key := range path {
+		value, ok := values[key]
+		if !ok {
+			return nil, false
+		}
+		if i == len(path)-1 {
+			return value, true
+		}
+		if values, ok = value.(map[string]any); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// setHelmValue sets the value at path in values, adding maps as needed.
+func setHelmValue(values map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		nested, ok := values[key].(map[string]any)
+		if !ok {
+			nested = map[string]any{}
+			values[key] = nested
+		}
+		values = nested
+	}
+	values[path[len(path)-1]] = value
+}
+
+// removeHelmValue removes the value at path from values, and any maps left
+// empty by doing so.
+func removeHelmValue(values map[string]any, path []string) {
+	if len(path) == 0 {
+		return
+	}
+	key := path[0]
+	if len(path) == 1 {
+		delete(values, key)
+		return
+	}
+
+	nested, ok := values[key].(map[string]any)
+	if !ok {
+		return
+	}
+	removeHelmValue(nested, path[1:])
+	if len(nested) == 0 {
+		delete(values, key)
+	}
+}
+
+// SensitiveHelmValuesPaths returns the paths of the sensitive Helm values
+// recorded in private state by SetSensitiveHelmValuesPaths.
+func SensitiveHelmValuesPaths(ctx context.Context, private PrivateStateGetter) ([][]string, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, sensitiveHelmValuesPathsKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil, diags
+	}
+
+	var paths [][]string
+	if err := json.Unmarshal(data, &paths); err != nil {
+		diags.AddError("Error reading private state", fmt.Sprintf("Could not read the paths of sensitive Helm values: %s", err))
+	}
+	return paths, diags
+}
+
+// SetSensitiveHelmValuesPaths records the paths of the sensitive Helm values
+// sent to the API in private state, or removes them if there are none. As
+// the values have just been sent, any mark set by SetHelmValuesImported is
+// removed.
+func SetSensitiveHelmValuesPaths(ctx context.Context, private PrivateStateSetter, paths [][]string) diag.Diagnostics {
+	diags := private.SetKey(ctx, importedHelmValuesKey, nil)
+	if len(paths) == 0 {
+		diags.Append(private.SetKey(ctx, sensitiveHelmValuesPathsKey, nil)...)
+		return diags
+	}
+
+	data, err := json.Marshal(paths)
+	if err != nil {
+		diags.AddError("Error writing private state", fmt.Sprintf("Could not record the paths of sensitive Helm values: %s", err))
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, sensitiveHelmValuesPathsKey, data)...)
+	return diags
+}
+
+// SetHelmValuesImported marks the Helm values of an imported resource in
+// private state. Sensitive values merged in before the import cannot be told
+// apart from the rest, so the resource's Read withholds the Helm values from
+// state until they are next sent to the API.
+func SetHelmValuesImported(ctx context.Context, private PrivateStateSetter) diag.Diagnostics {
+	return private.SetKey(ctx, importedHelmValuesKey, []byte("true"))
+}
+
+// HelmValuesImported returns whether the Helm values were marked by
+// SetHelmValuesImported, and have not been sent to the API since.
+func HelmValuesImported(ctx context.Context, private PrivateStateGetter) (bool, diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, importedHelmValuesKey)
+	return len(data) > 0, diags
+}
@@ -0,0 +1,134 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// fakePrivateState stores private state keys in memory.
+type fakePrivateState map[string][]byte
+
+func (p fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(p, key)
+		return nil
+	}
+	p[key] = value
+	return nil
+}
+
+func TestMergeSensitiveHelmValues(t *testing.T) {
+	values, err := structpb.NewStruct(map[string]any{
+		"global": map[string]any{"logLevel": "info"},
+		"token":  "placeholder",
+	})
+	require.NoError(t, err)
+
+	merged, paths, err := MergeSensitiveHelmValues(values, NewHelmValuesValue("token: secret\ndatabase:\n  password: hunter2\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"global":   map[string]any{"logLevel": "info"},
+		"token":    "secret",
+		"database": map[string]any{"password": "hunter2"},
+	}, merged.AsMap())
+	assert.Equal(t, [][]string{{"database", "password"}, {"token"}}, paths)
+
+	merged, paths, err = MergeSensitiveHelmValues(values, NewHelmValuesNull())
+	require.NoError(t, err)
+	assert.Same(t, values, merged)
+	assert.Nil(t, paths)
+
+	_, _, err = MergeSensitiveHelmValues(values, NewHelmValuesValue("token: ["))
+	assert.Error(t, err)
+}
+
+func TestHelmValuesPaths(t *testing.T) {
+	assert.Equal(t, [][]string{
+		{"a", "b", "c"},
+		{"a", "d"},
+		{"e"},
+		{"f"},
+	}, HelmValuesPaths(map[string]any{
+		"f": []any{map[string]any{"g": 1}},
+		"a": map[string]any{"d": nil, "b": map[string]any{"c": "x"}},
+		"e": map[string]any{},
+	}))
+}
+
+func TestWithoutSensitiveHelmValues(t *testing.T) {
+	values, err := structpb.NewStruct(map[string]any{
+		"global":   map[string]any{"logLevel": "info"},
+		"token":    "secret",
+		"database": map[string]any{"password": "hunter2"},
+	})
+	require.NoError(t, err)
+	paths := [][]string{{"database", "password"}, {"token"}}
+
+	got, err := WithoutSensitiveHelmValues(values, paths, NewHelmValuesFormsNull())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"global": map[string]any{"logLevel": "info"}}, got.AsMap(), "sensitive values and the maps left empty should be removed")
+
+	prior := NewHelmValuesFormsNull()
+	prior.Document = NewHelmValuesValue("global:\n  logLevel: info\ntoken: placeholder\n")
+	got, err = WithoutSensitiveHelmValues(values, paths, prior)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"global": map[string]any{"logLevel": "info"},
+		"token":  "placeholder",
+	}, got.AsMap(), "values overridden by sensitive values should be kept as configured")
+
+	got, err = WithoutSensitiveHelmValues(values, nil, prior)
+	require.NoError(t, err)
+	assert.Same(t, values, got)
+}
+
+func TestSensitiveHelmValuesPaths(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+
+	paths, diags := SensitiveHelmValuesPaths(ctx, private)
+	require.False(t, diags.HasError())
+	assert.Nil(t, paths)
+
+	want := [][]string{{"database", "password"}, {"token"}}
+	require.False(t, SetSensitiveHelmValuesPaths(ctx, private, want).HasError())
+	assert.JSONEq(t, `[["database","password"],["token"]]`, string(private[sensitiveHelmValuesPathsKey]))
+	paths, diags = SensitiveHelmValuesPaths(ctx, private)
+	require.False(t, diags.HasError())
+	assert.Equal(t, want, paths)
+
+	require.False(t, SetSensitiveHelmValuesPaths(ctx, private, nil).HasError())
+	assert.NotContains(t, private, sensitiveHelmValuesPathsKey)
+
+	private[sensitiveHelmValuesPathsKey] = []byte(`{}`)
+	_, diags = SensitiveHelmValuesPaths(ctx, private)
+	assert.True(t, diags.HasError())
+}
+
+func TestHelmValuesImported(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+
+	imported, diags := HelmValuesImported(ctx, private)
+	require.False(t, diags.HasError())
+	assert.False(t, imported)
+
+	require.False(t, SetHelmValuesImported(ctx, private).HasError())
+	imported, diags = HelmValuesImported(ctx, private)
+	require.False(t, diags.HasError())
+	assert.True(t, imported)
+
+	require.False(t, SetSensitiveHelmValuesPaths(ctx, private, [][]string{{"token"}}).HasError())
+	imported, diags = HelmValuesImported(ctx, private)
+	require.False(t, diags.HasError())
+	assert.False(t, imported)
+}
@@ -0,0 +1,132 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestHelmValuesSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name  string
+		prior string
+		value string
+		want  bool
+	}{
+		{
+			name:  "YAML and JSON",
+			prior: "key: value\n",
+			value: `{"key":"value"}`,
+			want:  true,
+		},
+		{
+			name:  "key order and whitespace",
+			prior: `{"b": {"y": 2, "x": 1}, "a": true}`,
+			value: `{"a":true,"b":{"x":1,"y":2}}`,
+			want:  true,
+		},
+		{
+			name:  "comments",
+			prior: "# replicas for HA\nreplicas: 2 # at least two\n",
+			value: `{"replicas":2}`,
+			want:  true,
+		},
+		{
+			name:  "empty documents",
+			prior: "",
+			value: "{}",
+			want:  true,
+		},
+		{
+			name:  "different values",
+			prior: "replicas: 2\n",
+			value: `{"replicas":3}`,
+			want:  false,
+		},
+		{
+			name:  "case sensitive keys",
+			prior: "extraEnv: {}\n",
+			value: `{"extraenv":{}}`,
+			want:  false,
+		},
+		{
+			name:  "invalid document",
+			prior: "key: [",
+			value: `{"key":"value"}`,
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := NewHelmValuesValue(tt.prior).StringSemanticEquals(context.Background(), NewHelmValuesValue(tt.value))
+			require.False(t, diags.HasError())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHelmValuesFromProto(t *testing.T) {
+	nonEmptyStruct, err := structpb.NewStruct(map[string]any{"key": "value"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		apiValues *structpb.Struct
+		prior     HelmValues
+		wantNull  bool
+		wantPrior bool
+		wantJSON  string
+	}{
+		{
+			name:      "nil API, null prior returns null",
+			apiValues: nil,
+			prior:     NewHelmValuesNull(),
+			wantNull:  true,
+		},
+		{
+			name:      "nil API, empty string prior is preserved",
+			apiValues: nil,
+			prior:     NewHelmValuesValue(""),
+			wantPrior: true,
+		},
+		{
+			name:      "empty struct API, empty JSON prior is preserved",
+			apiValues: &structpb.Struct{},
+			prior:     NewHelmValuesValue("{}"),
+			wantPrior: true,
+		},
+		{
+			// Values were removed outside Terraform, which should show drift.
+			name:      "nil API, non-empty prior returns null",
+			apiValues: nil,
+			prior:     NewHelmValuesValue(`{"key": "value"}`),
+			wantNull:  true,
+		},
+		{
+			// Semantic equality, not this function, keeps a matching prior.
+			name:      "non-empty API returns API JSON",
+			apiValues: nonEmptyStruct,
+			prior:     NewHelmValuesValue("key: value\n"),
+			wantJSON:  `{"key":"value"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HelmValuesFromProto(tt.apiValues, tt.prior)
+			require.NoError(t, err)
+			switch {
+			case tt.wantNull:
+				assert.True(t, got.IsNull(), "expected null, got %q", got.ValueString())
+			case tt.wantPrior:
+				assert.Equal(t, tt.prior, got)
+			default:
+				assert.JSONEq(t, tt.wantJSON, got.ValueString())
+			}
+		})
+	}
+}
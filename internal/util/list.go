@@ -0,0 +1,53 @@
+package util
+
+import (
+	"context"
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// ListResult is a single object returned by a list resource.
+type ListResult struct {
+	// DisplayName is a human-readable name for the object.
+	DisplayName string
+	// Resource is the resource model of the object.
+	Resource any
+	// Identity is the resource identity model of the object.
+	Identity any
+}
+
+// ListResults returns the result stream for a list resource. convert maps
+// each object returned by the API to a ListResult. At most req.Limit results
+// are returned, if set.
+func ListResults[T any](ctx context.Context, req list.ListRequest, objects []T, convert func(T) (ListResult, diag.Diagnostics)) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		for i, object := range objects {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+
+			converted, diags := convert(object)
+			result.Diagnostics.Append(diags...)
+			if diags.HasError() {
+				if !push(result) {
+					return
+				}
+				continue
+			}
+
+			result.DisplayName = converted.DisplayName
+			result.Diagnostics.Append(result.Identity.Set(ctx, converted.Identity)...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, converted.Resource)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testListModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+type testListIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+func TestListResults(t *testing.T) {
+	ctx := context.Background()
+
+	newRequest := func(limit int64, includeResource bool) list.ListRequest {
+		return list.ListRequest{
+			Limit:           limit,
+			IncludeResource: includeResource,
+			ResourceSchema: schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":   schema.StringAttribute{Computed: true},
+					"name": schema.StringAttribute{Required: true},
+				},
+			},
+			ResourceIdentitySchema: identityschema.Schema{
+				Attributes: map[string]identityschema.Attribute{
+					"id": identityschema.StringAttribute{RequiredForImport: true},
+				},
+			},
+		}
+	}
+
+	convert := func(name string) (ListResult, diag.Diagnostics) {
+		if name == "" {
+			return ListResult{}, diag.Diagnostics{diag.NewErrorDiagnostic("Error", "empty name")}
+		}
+		return ListResult{
+			DisplayName: name,
+			Resource:    testListModel{ID: types.StringValue(name + "-id"), Name: types.StringValue(name)},
+			Identity:    testListIdentityModel{ID: types.StringValue(name + "-id")},
+		}, nil
+	}
+
+	tests := []struct {
+		name            string
+		objects         []string
+		limit           int64
+		includeResource bool
+		wantNames       []string
+		wantErrors      int
+	}{
+		{
+			name:      "no objects",
+			objects:   nil,
+			wantNames: nil,
+		},
+		{
+			name:      "all objects without limit",
+			objects:   []string{"a", "b", "c"},
+			wantNames: []string{"a", "b", "c"},
+		},
+		{
+			name:      "limit truncates results",
+			objects:   []string{"a", "b", "c"},
+			limit:     2,
+			wantNames: []string{"a", "b"},
+		},
+		{
+			name:            "resource included when requested",
+			objects:         []string{"a"},
+			includeResource: true,
+			wantNames:       []string{"a"},
+		},
+		{
+			name:       "conversion errors are reported per result",
+			objects:    []string{"a", "", "c"},
+			wantNames:  []string{"a", "", "c"},
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			var errors int
+
+			for result := range ListResults(ctx, newRequest(tt.limit, tt.includeResource), tt.objects, convert) {
+				names = append(names, result.DisplayName)
+				if result.Diagnostics.HasError() {
+					errors++
+					continue
+				}
+
+				var identity testListIdentityModel
+				require.False(t, result.Identity.Get(ctx, &identity).HasError())
+				assert.Equal(t, result.DisplayName+"-id", identity.ID.ValueString())
+
+				if tt.includeResource {
+					var model testListModel
+					require.False(t, result.Resource.Get(ctx, &model).HasError())
+					assert.Equal(t, result.DisplayName, model.Name.ValueString())
+				} else {
+					assert.True(t, result.Resource.Raw.IsNull(), "resource should not be set")
+				}
+			}
+
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.wantErrors, errors)
+		})
+	}
+}
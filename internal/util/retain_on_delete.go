@@ -0,0 +1,30 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+)
+
+// RetainOnDeleteAttribute returns the retain_on_delete attribute of a
+// resource managing the given kind of object, such as "cluster". The
+// attribute is kept in Terraform state only.
+func RetainOnDeleteAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Whether destroying or replacing the resource only removes it from Terraform state, leaving the %s in Cofide Connect. Deletion protection does not apply, as nothing is deleted. Changing it does not change the %s in Cofide Connect. Defaults to false.", kind, kind),
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	}
+}
+
+// RetainedWarning returns the warning reported when an object is removed from
+// Terraform state without being deleted.
+func RetainedWarning(kind, name string) diag.Diagnostic {
+	return diag.NewWarningDiagnostic(
+		fmt.Sprintf("Retained %s", kind),
+		fmt.Sprintf("The %s %q was removed from Terraform state but not deleted, as retain_on_delete is true. It continues to exist in Cofide Connect.", kind, name),
+	)
+}
@@ -0,0 +1,54 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// UnchangedStateUpgrader returns a state upgrader for a schema version bump
+// that changed only plan behaviour, such as an attribute gaining
+// RequiresReplace. The stored state already matches priorSchema's type and is
+// carried over as is.
+func UnchangedStateUpgrader(priorSchema schema.Schema) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		PriorSchema: &priorSchema,
+		StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			resp.State.Raw = req.State.Raw
+		},
+	}
+}
+
+// IsStateOnlyUpdate returns whether an update changes only the named
+// attributes, which are kept in Terraform state alone, so that the object
+// itself needs no update. Planned values that are unknown because they are
+// computed, rather than configured, are taken to be unchanged.
+func IsStateOnlyUpdate(req resource.UpdateRequest, stateOnly ...string) (bool, error) {
+	var config, plan, state map[string]tftypes.Value
+	if err := req.Config.Raw.As(&config); err != nil {
+		return false, fmt.Errorf("could not read configuration: %w", err)
+	}
+	if err := req.Plan.Raw.As(&plan); err != nil {
+		return false, fmt.Errorf("could not read plan: %w", err)
+	}
+	if err := req.State.Raw.As(&state); err != nil {
+		return false, fmt.Errorf("could not read state: %w", err)
+	}
+
+	for name, planned := range plan {
+		if slices.Contains(stateOnly, name) {
+			continue
+		}
+		if !planned.IsKnown() && config[name].IsNull() {
+			continue
+		}
+		if !planned.Equal(state[name]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
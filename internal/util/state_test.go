@@ -0,0 +1,69 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStateOnlyUpdate(t *testing.T) {
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":                tftypes.String,
+		"endpoint":            tftypes.String,
+		"deletion_protection": tftypes.Bool,
+	}}
+	object := func(name, endpoint any, deletionProtection bool) tftypes.Value {
+		return tftypes.NewValue(typ, map[string]tftypes.Value{
+			"name":                tftypes.NewValue(tftypes.String, name),
+			"endpoint":            tftypes.NewValue(tftypes.String, endpoint),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, deletionProtection),
+		})
+	}
+	state := object("tz", "https://tz.example.com", true)
+
+	tests := []struct {
+		name   string
+		config tftypes.Value
+		plan   tftypes.Value
+		want   bool
+	}{
+		{
+			name:   "only deletion_protection changed",
+			config: object("tz", nil, false),
+			plan:   object("tz", "https://tz.example.com", false),
+			want:   true,
+		},
+		{
+			name:   "computed value unknown",
+			config: object("tz", nil, false),
+			plan:   object("tz", tftypes.UnknownValue, false),
+			want:   true,
+		},
+		{
+			name:   "name changed",
+			config: object("renamed", nil, false),
+			plan:   object("renamed", "https://tz.example.com", false),
+		},
+		{
+			name:   "configured value unknown",
+			config: object(tftypes.UnknownValue, nil, true),
+			plan:   object(tftypes.UnknownValue, "https://tz.example.com", true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IsStateOnlyUpdate(resource.UpdateRequest{
+				Config: tfsdk.Config{Raw: tt.config},
+				Plan:   tfsdk.Plan{Raw: tt.plan},
+				State:  tfsdk.State{Raw: state},
+			}, "deletion_protection")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
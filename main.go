@@ -3,21 +3,44 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/cofide/terraform-provider-cofide/internal"
+	"github.com/cofide/terraform-provider-cofide/internal/cofidectl"
+	"github.com/cofide/terraform-provider-cofide/internal/export"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
-
-
 var (
 	// These will be set by the goreleaser configuration
 	// to appropriate values for the compiled binary.
 	version string = "dev"
 )
 
+// subcommands are the command-line tools built into the provider binary,
+// keyed by the first argument that selects them.
+var subcommands = map[string]func(args []string) error{
+	"export": func(args []string) error {
+		return export.Main(context.Background(), args, internal.NewProvider(version), version, os.Stdout, os.Stderr)
+	},
+	"convert-config": func(args []string) error {
+		return cofidectl.Main(args, os.Stdout, os.Stderr)
+	},
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -35,6 +35,8 @@ assert_eq "cluster_trust_provider_k8s_psat_spire_server_audience" "spire-server"
 assert_not_empty "cluster_trust_provider_k8s_psat_api_server_ca_cert"
 assert_not_empty "cluster_oidc_issuer_url"
 assert_not_empty "cluster_oidc_issuer_ca_cert"
+assert_eq "cluster_oidc_issuer_ca_cert_subject" "CN=kubernetes"
+assert_eq "cluster_oidc_issuer_ca_cert_not_after" "2036-01-04T11:03:17Z"
 
 sa_namespace=$(echo "$outputs" | jq -r '.cluster_trust_provider_k8s_psat_allowed_service_accounts.value[0].namespace')
 sa_name=$(echo "$outputs" | jq -r '.cluster_trust_provider_k8s_psat_allowed_service_accounts.value[0].service_account_name')
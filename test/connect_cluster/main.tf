@@ -47,7 +47,7 @@ resource "cofide_connect_cluster" "cluster" {
   external_server = true
 
   oidc_issuer_url     = "https://oidc.example.com"
-  oidc_issuer_ca_cert = base64encode(file("oidc-issuer-ca.crt"))
+  oidc_issuer_ca_cert = file("oidc-issuer-ca.crt")
 
   depends_on = [
     cofide_connect_trust_zone.trust_zone
@@ -80,6 +80,14 @@ output "cluster_oidc_issuer_ca_cert" {
   sensitive = true
 }
 
+output "cluster_oidc_issuer_ca_cert_subject" {
+  value = data.cofide_connect_cluster.cluster.oidc_issuer_ca_cert_subject
+}
+
+output "cluster_oidc_issuer_ca_cert_not_after" {
+  value = data.cofide_connect_cluster.cluster.oidc_issuer_ca_cert_not_after
+}
+
 output "cluster_trust_provider_k8s_psat_enabled" {
   value = data.cofide_connect_cluster.cluster.trust_provider.k8s_psat_config.enabled
 }
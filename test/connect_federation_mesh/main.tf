@@ -0,0 +1,15 @@
+data "cofide_connect_organization" "org" {
+  name = "default"
+}
+
+resource "cofide_connect_trust_zone" "trust_zone" {
+  for_each = toset(["a", "b", "c"])
+
+  name         = "test-tz-mesh-${each.key}"
+  org_id       = data.cofide_connect_organization.org.id
+  trust_domain = "test-tz-mesh-${each.key}.cofide.dev"
+}
+
+resource "cofide_connect_federation_mesh" "federation_mesh" {
+  trust_zone_ids = [for trust_zone in cofide_connect_trust_zone.trust_zone : trust_zone.id]
+}
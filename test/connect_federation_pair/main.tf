@@ -0,0 +1,20 @@
+data "cofide_connect_organization" "org" {
+  name = "default"
+}
+
+resource "cofide_connect_trust_zone" "trust_zone_a" {
+  name         = "test-tz-pair-a"
+  org_id       = data.cofide_connect_organization.org.id
+  trust_domain = "test-tz-pair-a.cofide.dev"
+}
+
+resource "cofide_connect_trust_zone" "trust_zone_b" {
+  name         = "test-tz-pair-b"
+  org_id       = data.cofide_connect_organization.org.id
+  trust_domain = "test-tz-pair-b.cofide.dev"
+}
+
+resource "cofide_connect_federation_pair" "federation_pair" {
+  trust_zone_id        = cofide_connect_trust_zone.trust_zone_a.id
+  remote_trust_zone_id = cofide_connect_trust_zone.trust_zone_b.id
+}
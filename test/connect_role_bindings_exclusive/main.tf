@@ -0,0 +1,23 @@
+data "cofide_connect_organization" "org" {
+  name = "default"
+}
+
+resource "cofide_connect_trust_zone" "trust_zone" {
+  name         = "test-role-bindings-exclusive-tz"
+  org_id       = data.cofide_connect_organization.org.id
+  trust_domain = "test-rbe-tz.cofide.dev"
+}
+
+resource "cofide_connect_role_bindings_exclusive" "role_bindings" {
+  role_id = "admin"
+  resource = {
+    type = "TrustZone"
+    id   = cofide_connect_trust_zone.trust_zone.id
+  }
+  users  = ["test-user-subject"]
+  groups = ["test-group-claim-value"]
+}
+
+output "role_bindings_id" {
+  value = cofide_connect_role_bindings_exclusive.role_bindings.id
+}
@@ -0,0 +1,42 @@
+data "cofide_connect_organization" "org" {
+  name = "default"
+}
+
+resource "cofide_connect_trust_zone" "trust_zone" {
+  name         = "tzep-tz"
+  org_id       = data.cofide_connect_organization.org.id
+  trust_domain = "tzep-tz.cofide.dev"
+}
+
+resource "cofide_connect_trust_zone_exchange_policies" "exchange_policies" {
+  trust_zone_id = cofide_connect_trust_zone.trust_zone.id
+
+  exchange_policies = [
+    {
+      name   = "test-tzep-deny"
+      action = "DENY"
+
+      subject_identity = [
+        { exact = "spiffe://tzep-tz.cofide.dev/untrusted-workload" }
+      ]
+    },
+    {
+      name   = "test-tzep-allow"
+      action = "ALLOW"
+
+      subject_identity = [
+        { glob = "spiffe://tzep-tz.cofide.dev/ns/foo/sa/*" }
+      ]
+
+      target_audience = [
+        { exact = "https://api.tzep-tz.cofide.dev" }
+      ]
+
+      outbound_scopes = ["read"]
+    },
+  ]
+}
+
+output "exchange_policy_ids" {
+  value = cofide_connect_trust_zone_exchange_policies.exchange_policies.exchange_policies[*].id
+}
@@ -0,0 +1,46 @@
+data "cofide_connect_organization" "org" {
+  name = "default"
+}
+
+resource "cofide_connect_trust_zone" "trust_zone" {
+  for_each = toset(["a", "b"])
+
+  name         = "test-tz-wi-${each.key}"
+  org_id       = data.cofide_connect_organization.org.id
+  trust_domain = "test-tz-wi-${each.key}.cofide.dev"
+}
+
+resource "cofide_connect_workload_identity" "workload_identity" {
+  name   = "test-workload-identity"
+  org_id = data.cofide_connect_organization.org.id
+
+  kubernetes = {
+    namespace_selector = {
+      match_labels = {
+        "kubernetes.io/metadata.name" = "default"
+      }
+    }
+  }
+
+  bindings = [
+    {
+      trust_zone_id = cofide_connect_trust_zone.trust_zone["a"].id
+      federations = [
+        {
+          trust_zone_id = cofide_connect_trust_zone.trust_zone["b"].id
+        }
+      ]
+    },
+    {
+      trust_zone_id = cofide_connect_trust_zone.trust_zone["b"].id
+    },
+  ]
+}
+
+output "attestation_policy_id" {
+  value = cofide_connect_workload_identity.workload_identity.id
+}
+
+output "ap_binding_ids" {
+  value = cofide_connect_workload_identity.workload_identity.bindings[*].id
+}
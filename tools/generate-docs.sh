@@ -32,6 +32,7 @@ trap cleanup EXIT
 # 2. Create the directory structure tfplugindocs expects
 mkdir -p "$GEN_EXAMPLES_DIR/resources"
 mkdir -p "$GEN_EXAMPLES_DIR/data-sources"
+mkdir -p "$GEN_EXAMPLES_DIR/list-resources"
 
 # 3. Process resources
 echo "Preparing resource examples..."
@@ -82,7 +83,18 @@ for d_dir in "$TMP_EXAMPLES_DIR"/data-sources/*; do
   done
 done
 
-# 5. Run the documentation generator
+# 5. Process list resources. Query files are already self-contained, so they
+#    are copied as-is.
+echo "Preparing list resource examples..."
+for l_dir in "$TMP_EXAMPLES_DIR"/list-resources/*; do
+  if [ ! -d "$l_dir" ]; then continue; fi
+
+  lr_name=$(basename "$l_dir")
+  mkdir -p "$GEN_EXAMPLES_DIR/list-resources/$lr_name"
+  cp "$l_dir"/*.tfquery.hcl "$GEN_EXAMPLES_DIR/list-resources/$lr_name/"
+done
+
+# 6. Run the documentation generator
 echo "Running tfplugindocs..."
 go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
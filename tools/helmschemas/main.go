@@ -0,0 +1,140 @@
+// Command helmschemas embeds the values schema of a chart version in the
+// provider. It extracts values.schema.json from the chart archive, writes it
+// to internal/helmschema/schemas/<chart>/<version>.json and records the
+// archive and the file's digest in internal/helmschema/schemas/sources.json.
+// The version must then be added to helmschema.ChartVersions.
+//
+// Usage:
+//
+//	go run ./tools/helmschemas -chart cofide-spire -version 0.5 -archive <chart .tgz file or URL>
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cofide/terraform-provider-cofide/internal/helmschema"
+)
+
+const schemasDir = "internal/helmschema/schemas"
+
+func main() {
+	var chart, version, archive string
+
+	flag.StringVar(&chart, "chart", "", "the name of the chart, such as cofide-spire")
+	flag.StringVar(&version, "version", "", "the chart version")
+	flag.StringVar(&archive, "archive", "", "the chart archive, as a file or an http(s) URL")
+	flag.Parse()
+
+	if chart == "" || version == "" || archive == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := extractSchema(archive, chart)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var schema helmschema.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		log.Fatalf("invalid values schema in %s: %s", archive, err)
+	}
+
+	file := helmschema.SchemaFile(helmschema.Chart(chart), version)
+	path := filepath.Join(schemasDir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := recordSource(file, helmschema.SchemaSource{
+		Chart:        helmschema.Chart(chart),
+		ChartVersion: version,
+		Source:       archive,
+		SHA256:       helmschema.Digest(data),
+	}); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %s from %s\n", path, archive)
+}
+
+// extractSchema returns the values schema of chart from a chart archive, in
+// which the chart's files are under a directory named after it.
+func extractSchema(archive, chart string) ([]byte, error) {
+	r, err := open(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read chart archive %s: %w", archive, err)
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("chart archive %s has no %s/values.schema.json", archive, chart)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read chart archive %s: %w", archive, err)
+		}
+		if header.Name == chart+"/values.schema.json" {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+func open(archive string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(archive, "http://") && !strings.HasPrefix(archive, "https://") {
+		return os.Open(archive)
+	}
+
+	resp, err := http.Get(archive)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("could not download %s: %s", archive, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// recordSource sets the source of the schema file in the sources file.
+func recordSource(file string, source helmschema.SchemaSource) error {
+	path := filepath.Join(schemasDir, helmschema.SourcesFile)
+
+	sources := map[string]helmschema.SchemaSource{}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &sources); err != nil {
+			return fmt.Errorf("invalid %s: %w", path, err)
+		}
+	}
+
+	sources[file] = source
+	data, err = json.MarshalIndent(sources, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,67 @@
+// Command schemasnapshot writes the provider schema to a JSON snapshot, or
+// compares it against a previously released snapshot.
+//
+// Usage:
+//
+//	go run ./tools/schemasnapshot -out internal/testdata/released_schema.json
+//	go run ./tools/schemasnapshot -diff internal/testdata/released_schema.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/cofide/terraform-provider-cofide/internal"
+	"github.com/cofide/terraform-provider-cofide/internal/schemacheck"
+)
+
+func main() {
+	var out, diff string
+
+	flag.StringVar(&out, "out", "", "write the snapshot to this file instead of stdout")
+	flag.StringVar(&diff, "diff", "", "compare the current schema against this snapshot and report changes")
+	flag.Parse()
+
+	current, err := schemacheck.Take(context.Background(), internal.NewProvider("snapshot")())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if diff != "" {
+		released, err := schemacheck.Load(diff)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		breaking := false
+		for _, change := range schemacheck.Diff(released, current) {
+			label := "additive"
+			if change.Breaking {
+				label = "BREAKING"
+				breaking = true
+			}
+			fmt.Printf("%-8s %s\n", label, change)
+		}
+		if breaking {
+			os.Exit(1)
+		}
+		return
+	}
+
+	data, err := current.Marshal()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(out, data, 0o644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}